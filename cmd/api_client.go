@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+// exitOnAPIError reports a failed SDK call and exits. API errors are shown with their status
+// code, a 404 is replaced by notFound when it is set, and anything else, such as a network
+// failure, is reported as "Failed to <action>".
+func exitOnAPIError(err error, action, notFound string) {
+	var apiErr *api.Error
+	switch {
+	case notFound != "" && errors.Is(err, api.ErrNotFound):
+		Error(notFound)
+	case errors.As(err, &apiErr):
+		Error(apiErr.Error())
+	default:
+		Error(fmt.Sprintf("Failed to %s: %s", action, err))
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const auditLogFilename = "audit.log"
+
+// Request bodies are kept in the audit log up to this size
+const maxAuditRequest = 4096
+
+var (
+	auditSince   string
+	auditUser    string
+	auditAction  string
+	auditCrontab string
+	auditLimit   int
+	auditJSON    bool
+	auditContent bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show changes made through the dashboard and MCP server",
+	Long: `
+Cronitor audit shows the audit log of every change made through the dashboard or its MCP server: edited, created and
+deleted jobs and crontabs, jobs run or killed, settings changes and CLI updates. Requests that were denied are logged too.
+
+Each entry records the time, the signed in user, their IP address, whether the request came from the browser, an MCP
+client or a hub, and the crontab and line it changed with the crontab's content before and after.
+
+Example:
+  $ cronitor audit
+      > Show the last 50 changes
+
+  $ cronitor audit --since 24h --user alice
+      > Show what alice changed in the last day
+
+  $ cronitor audit --action job --crontab /etc/cron.d/backup --content
+      > Show job changes in one crontab with the crontab before and after each change
+
+  $ cronitor audit --since 2024-05-01 --json
+      > Output entries since a date as JSON
+	`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		filter := lib.AuditFilter{User: auditUser, Action: auditAction, Crontab: auditCrontab, Limit: auditLimit}
+		if auditSince != "" {
+			since, err := parseAuditSince(auditSince, time.Now())
+			if err != nil {
+				fatal(err.Error(), 1)
+			}
+			filter.Since = since
+		}
+
+		entries, err := dashAuditLog().Read(filter)
+		if err != nil {
+			fatal(fmt.Sprintf("Cannot read audit log: %v", err), 1)
+		}
+
+		if auditJSON {
+			output, _ := json.MarshalIndent(entries, "", "  ")
+			fmt.Println(string(output))
+			return
+		}
+
+		if len(entries) == 0 {
+			printWarningText("No audit log entries found", false)
+			return
+		}
+
+		table := &UITable{Headers: []string{"TIME", "USER", "IP", "SOURCE", "ACTION", "TARGET", "STATUS"}}
+		for _, entry := range entries {
+			target := entry.Crontab
+			if entry.Line > 0 {
+				target = fmt.Sprintf("%s:%d", target, entry.Line)
+			}
+			table.Rows = append(table.Rows, []string{
+				entry.Time.Local().Format("2006-01-02 15:04:05"),
+				entry.User,
+				entry.ClientIP,
+				entry.Source,
+				entry.Action,
+				target,
+				strconv.Itoa(entry.Status),
+			})
+		}
+		fmt.Print(table.Render())
+
+		if auditContent {
+			for _, entry := range entries {
+				if entry.Before == "" && entry.After == "" {
+					continue
+				}
+				fmt.Printf("\n%s %s %s\n", entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Action, entry.Crontab)
+				fmt.Printf("--- before\n%s+++ after\n%s", withTrailingNewline(entry.Before), withTrailingNewline(entry.After))
+			}
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().StringVar(&auditSince, "since", "", "Only show entries after a duration ago (e.g. 24h) or a date (e.g. 2024-05-01)")
+	auditCmd.Flags().StringVar(&auditUser, "user", "", "Only show entries by this dashboard user")
+	auditCmd.Flags().StringVar(&auditAction, "action", "", "Only show this action, e.g. job.update, or a group of actions, e.g. job")
+	auditCmd.Flags().StringVar(&auditCrontab, "crontab", "", "Only show entries for this crontab, e.g. /etc/crontab or user:deploy")
+	auditCmd.Flags().IntVar(&auditLimit, "limit", 50, "Show at most this many of the newest entries (0 for all)")
+	auditCmd.Flags().BoolVarP(&auditJSON, "json", "j", false, "Output as JSON")
+	auditCmd.Flags().BoolVar(&auditContent, "content", false, "Show crontab content before and after each change")
+}
+
+func withTrailingNewline(value string) string {
+	if value != "" && !strings.HasSuffix(value, "\n") {
+		return value + "\n"
+	}
+	return value
+}
+
+// parseAuditSince accepts a duration before now, a date or an RFC 3339 time
+func parseAuditSince(value string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+	return time.Time{}, fmt.Errorf("--since must be a duration like 24h, a date like 2024-05-01 or an RFC 3339 time")
+}
+
+func auditLogPath() string {
+	if path := viper.GetString(varAuditLog); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(configFilePath()), auditLogFilename)
+}
+
+func dashAuditLog() *lib.AuditLog {
+	return &lib.AuditLog{Path: auditLogPath(), Syslog: viper.GetBool(varAuditSyslog)}
+}
+
+// auditActionName names the action a mutating request takes
+func auditActionName(path string, method string) string {
+	actions := map[string]string{
+		"POST /api/jobs":           "job.create",
+		"PUT /api/jobs":            "job.update",
+		"DELETE /api/jobs":         "job.delete",
+		"POST /api/jobs/run":       "job.run",
+		"POST /api/jobs/kill":      "job.kill",
+		"POST /api/crontabs":       "crontab.create",
+		"PUT /api/crontabs":        "crontab.update",
+		"PUT /api/crontabs/":       "crontab.update",
+		"POST /api/settings":       "settings.update",
+		"PUT /api/settings":        "settings.update",
+		"POST /api/signup":         "signup",
+		"POST /api/update/perform": "cli.update",
+	}
+	if action, ok := actions[method+" "+path]; ok {
+		return action
+	}
+	return strings.ToLower(method) + " " + path
+}
+
+// auditResponseWriter records the status of the response while passing streamed output straight through
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// auditDashRoute writes an audit log entry for every POST, PUT and DELETE request to a route, including requests
+// that are denied. Crontabs are read before and after the request so the entry shows exactly what changed.
+// A hub does not have the crontabs it changes, so it only records the request.
+func auditDashRoute(path string, handler http.HandlerFunc, captureContent bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" && r.Method != "PUT" && r.Method != "DELETE" {
+			handler(w, r)
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			body, _ = ioutil.ReadAll(r.Body)
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		entry := lib.AuditEntry{
+			Time:     time.Now(),
+			ClientIP: getClientIP(r),
+			Source:   "dashboard",
+			Action:   auditActionName(path, r.Method),
+		}
+		if client := r.Header.Get(lib.AuditClientHeader); client != "" {
+			entry.Source = client
+		}
+		if user := dashUserFromRequest(r); user != nil {
+			entry.User = user.Username
+		}
+
+		var target struct {
+			CrontabFilename string `json:"crontab_filename"`
+			Filename        string `json:"filename"`
+			Key             string `json:"key"`
+			Code            string `json:"code"`
+			LineNumber      int    `json:"line_number"`
+		}
+		json.Unmarshal(body, &target)
+		entry.Crontab = target.CrontabFilename
+		if entry.Crontab == "" {
+			entry.Crontab = target.Filename
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/crontabs/") {
+			entry.Crontab = crontabFilenameFromPath(r.URL.Path)
+		}
+		entry.Key = target.Key
+		entry.Line = target.LineNumber
+
+		// Settings and signups carry API keys and passwords, which are never written to the log
+		isSettings := strings.HasPrefix(entry.Action, "settings.")
+		if !isSettings && entry.Action != "signup" && len(body) > 0 && len(body) <= maxAuditRequest && json.Valid(body) {
+			entry.Request = json.RawMessage(body)
+		}
+
+		changesCrontab := strings.HasPrefix(entry.Action, "job.") && entry.Action != "job.run" && entry.Action != "job.kill" || strings.HasPrefix(entry.Action, "crontab.")
+		if captureContent && changesCrontab && entry.Crontab != "" {
+			entry.Before = readCrontabForAudit(entry.Crontab, &entry)
+		}
+		if captureContent && isSettings {
+			entry.Before = readSettingsForAudit()
+		}
+
+		recorder := &auditResponseWriter{ResponseWriter: w}
+		handler(recorder, r)
+		entry.Status = recorder.status
+		if entry.Status == 0 {
+			entry.Status = http.StatusOK
+		}
+
+		if entry.Succeeded() && captureContent && changesCrontab && entry.Crontab != "" {
+			entry.After = readCrontabForAudit(entry.Crontab, nil)
+		}
+		if entry.Succeeded() && captureContent && isSettings {
+			entry.After = readSettingsForAudit()
+		}
+
+		if err := dashAuditLog().Append(entry); err != nil {
+			log(fmt.Sprintf("Failed to write audit log: %v", err))
+		}
+	}
+}
+
+// crontabFilenameFromPath turns /api/crontabs/etc/cron.d/backup into /etc/cron.d/backup
+func crontabFilenameFromPath(path string) string {
+	filename := strings.TrimPrefix(path, "/api/crontabs/")
+	if !strings.HasPrefix(filename, "user:") {
+		filename = "/" + filename
+	}
+	return filename
+}
+
+// readCrontabForAudit returns the content of a crontab and fills in the line number of the job being changed
+func readCrontabForAudit(filename string, entry *lib.AuditEntry) string {
+	crontab, err := lib.GetCrontab(filename)
+	if err != nil {
+		return ""
+	}
+
+	if entry != nil && entry.Line == 0 && entry.Key != "" {
+		for _, line := range crontab.Lines {
+			if line.IsJob && line.Key(crontab.CanonicalName()) == entry.Key {
+				entry.Line = line.LineNumber
+				break
+			}
+		}
+	}
+	return crontab.Write()
+}
+
+// auditSecretKeys are config values replaced by a fingerprint in the audit log, so a change is visible but the value is not
+var auditSecretKeys = []string{varApiKey, varPingApiKey, varDashPassword, "password"}
+
+// readSettingsForAudit returns the config file with secrets replaced by fingerprints
+func readSettingsForAudit() string {
+	data, err := ioutil.ReadFile(configFilePath())
+	if err != nil {
+		return ""
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return ""
+	}
+	redactAuditSecrets(settings)
+
+	output, _ := json.MarshalIndent(settings, "", "  ")
+	return string(output)
+}
+
+func redactAuditSecrets(values map[string]interface{}) {
+	for key, value := range values {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			redactAuditSecrets(typed)
+		case string:
+			if typed != "" && contains(auditSecretKeys, key) {
+				values[key] = fmt.Sprintf("[redacted %x]", sha256.Sum256([]byte(typed)))[:22] + "]"
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+)
+
+func TestAuditDashRouteRecordsCrontabChanges(t *testing.T) {
+	dir := t.TempDir()
+	defer viper.Set(varAuditLog, "")
+	viper.Set(varAuditLog, filepath.Join(dir, "audit.log"))
+
+	crontabFile := filepath.Join(dir, "backup")
+	os.WriteFile(crontabFile, []byte("0 * * * * backup.sh\n"), 0644)
+
+	update := auditDashRoute("/api/crontabs/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), "report.sh") {
+			t.Errorf("expected the handler to receive the request body, got %q", body)
+		}
+		os.WriteFile(crontabFile, []byte("0 * * * * backup.sh\n30 2 * * * report.sh\n"), 0644)
+		w.WriteHeader(http.StatusOK)
+	}, true)
+
+	request := httptest.NewRequest("PUT", "/api/crontabs"+crontabFile, strings.NewReader(`{"lines":"30 2 * * * report.sh"}`))
+	request.Header.Set(lib.AuditClientHeader, "mcp")
+	update(httptest.NewRecorder(), withDashUser(request, &DashUser{Username: "alice", Role: dashRoleEditor}))
+
+	denied := auditDashRoute("/api/jobs/run", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	}, true)
+	denied(httptest.NewRecorder(), withDashUser(httptest.NewRequest("POST", "/api/jobs/run", strings.NewReader(`{"command":"backup.sh"}`)), &DashUser{Username: "bob", Role: dashRoleViewer}))
+
+	// Reads are never audited
+	read := auditDashRoute("/api/jobs", func(w http.ResponseWriter, r *http.Request) {}, true)
+	read(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/jobs", nil))
+
+	entries, err := dashAuditLog().Read(lib.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+
+	change := entries[0]
+	if change.Action != "crontab.update" || change.User != "alice" || change.Source != "mcp" || change.Crontab != crontabFile || change.Status != 200 {
+		t.Errorf("unexpected crontab entry %+v", change)
+	}
+	if strings.Contains(change.Before, "report.sh") || !strings.Contains(change.After, "report.sh") {
+		t.Errorf("expected before and after crontab content, got %q and %q", change.Before, change.After)
+	}
+
+	if entries[1].Action != "job.run" || entries[1].User != "bob" || entries[1].Status != http.StatusForbidden || entries[1].Source != "dashboard" {
+		t.Errorf("expected the denied run to be recorded, got %+v", entries[1])
+	}
+}
+
+func TestRedactAuditSecrets(t *testing.T) {
+	settings := map[string]interface{}{
+		varApiKey:       "secret-key",
+		varHostname:     "web-1",
+		"mcp_instances": map[string]interface{}{"prod": map[string]interface{}{"url": "https://prod", "password": "hunter2"}},
+	}
+	redactAuditSecrets(settings)
+
+	if value := settings[varApiKey].(string); !strings.HasPrefix(value, "[redacted ") || strings.Contains(value, "secret-key") {
+		t.Errorf("expected the API key to be redacted, got %q", value)
+	}
+	if settings[varHostname] != "web-1" {
+		t.Errorf("expected other settings to be kept, got %v", settings[varHostname])
+	}
+	prod := settings["mcp_instances"].(map[string]interface{})["prod"].(map[string]interface{})
+	if prod["password"] == "hunter2" || prod["url"] != "https://prod" {
+		t.Errorf("expected nested passwords to be redacted, got %v", prod)
+	}
+}
+
+func TestParseAuditSince(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	if since, err := parseAuditSince("24h", now); err != nil || !since.Equal(now.Add(-24*time.Hour)) {
+		t.Errorf("expected a duration before now, got %v, %v", since, err)
+	}
+	if since, err := parseAuditSince("2024-05-01T00:00:00Z", now); err != nil || since.Day() != 1 {
+		t.Errorf("expected an RFC 3339 time, got %v, %v", since, err)
+	}
+	if _, err := parseAuditSince("yesterday", now); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/cronitorio/cronitor-cli/lib"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -22,9 +23,24 @@ type ConfigFile struct {
 	DashPassword       string                       `json:"CRONITOR_DASH_PASS"`
 	AllowedIPs         string                       `json:"CRONITOR_ALLOWED_IPS"`
 	CorsAllowedOrigins string                       `json:"CRONITOR_CORS_ALLOWED_ORIGINS"`
+	DashTLSCert        string                       `json:"CRONITOR_DASH_TLS_CERT,omitempty"`
+	DashTLSKey         string                       `json:"CRONITOR_DASH_TLS_KEY,omitempty"`
+	DashTLSSelfSigned  bool                         `json:"CRONITOR_DASH_TLS_SELF_SIGNED,omitempty"`
+	DashTLSClientCA    string                       `json:"CRONITOR_DASH_TLS_CLIENT_CA,omitempty"`
 	Users              string                       `json:"CRONITOR_USERS"`
 	ApiVersion         string                       `json:"CRONITOR_API_VERSION,omitempty"`
+	CronDialect        string                       `json:"CRONITOR_CRON_DIALECT,omitempty"`
+	StateDir           string                       `json:"CRONITOR_STATE_DIR,omitempty"`
+	RunHistoryDays     int                          `json:"CRONITOR_RUN_HISTORY_DAYS,omitempty"`
+	RunHistoryRuns     int                          `json:"CRONITOR_RUN_HISTORY_RUNS,omitempty"`
+	AuditLog           string                       `json:"CRONITOR_AUDIT_LOG,omitempty"`
+	AuditSyslog        bool                         `json:"CRONITOR_AUDIT_SYSLOG,omitempty"`
+	CABundle           string                       `json:"CRONITOR_CA_BUNDLE,omitempty"`
+	URL                string                       `json:"CRONITOR_URL,omitempty"`
+	Profile            string                       `json:"CRONITOR_PROFILE,omitempty"`
+	Profiles           map[string]ProfileConfig     `json:"profiles,omitempty"`
 	MCPEnabled         bool                         `json:"CRONITOR_MCP_ENABLED,omitempty"`
+	MCPReadOnly        bool                         `json:"CRONITOR_MCP_READ_ONLY,omitempty"`
 	MCPInstances       map[string]MCPInstanceConfig `json:"mcp_instances,omitempty"`
 }
 
@@ -32,6 +48,7 @@ type MCPInstanceConfig struct {
 	URL      string `json:"url"`
 	Username string `json:"username"`
 	Password string `json:"password"`
+	ReadOnly bool   `json:"read_only,omitempty"`
 }
 
 // configureCmd represents the configure command
@@ -51,19 +68,35 @@ You can use a default config file for some things and environment variables or c
 
 Environment variables that are read:
   CRONITOR_API_KEY
+  CRONITOR_CA_BUNDLE
   CRONITOR_CONFIG
+  CRONITOR_CRON_DIALECT
   CRONITOR_EXCLUDE_TEXT
   CRONITOR_HOSTNAME
   CRONITOR_LOG
   CRONITOR_PING_API_KEY
+  CRONITOR_PROFILE
+  CRONITOR_TIMEOUT
+  CRONITOR_URL
   CRONITOR_USERS
 
+HTTPS_PROXY, HTTP_PROXY and NO_PROXY are honored for requests to Cronitor.
+
 Example setting your API Key:
   $ cronitor configure --api-key 4319e94e890a013dbaca57c2df2ff60c2
 
 Example setting common exclude text for use with 'cronitor discover':
-  $ cronitor configure -e "/var/app/code/path/" -e "/var/app/bin/" -e "> /dev/null"`,
+  $ cronitor configure -e "/var/app/code/path/" -e "/var/app/bin/" -e "> /dev/null"
+
+Example adding a profile for a second account, see 'cronitor profile':
+  $ cronitor configure --profile staging --api-key 9a5c1d2e7f... --env staging`,
 	Run: func(cmd *cobra.Command, args []string) {
+		// These flags share their names with global ones, which are the flags viper is bound to
+		for flag, key := range map[string]string{"env": varEnv, "ping-api-key": varPingApiKey} {
+			if cmd.Flags().Changed(flag) {
+				viper.Set(key, cmd.Flags().Lookup(flag).Value.String())
+			}
+		}
 
 		configData := ConfigFile{}
 		configData.ApiKey = viper.GetString(varApiKey)
@@ -76,9 +109,22 @@ Example setting common exclude text for use with 'cronitor discover':
 		configData.DashPassword = viper.GetString(varDashPassword)
 		configData.AllowedIPs = viper.GetString(varAllowedIPs)
 		configData.CorsAllowedOrigins = viper.GetString("CRONITOR_CORS_ALLOWED_ORIGINS")
+		configData.DashTLSCert = viper.GetString(varDashTLSCert)
+		configData.DashTLSKey = viper.GetString(varDashTLSKey)
+		configData.DashTLSSelfSigned = viper.GetBool(varDashTLSSelfSigned)
+		configData.DashTLSClientCA = viper.GetString(varDashTLSClientCA)
 		configData.Users = viper.GetString(varUsers)
 		configData.ApiVersion = viper.GetString(varApiVersion)
+		configData.CronDialect = viper.GetString(varCronDialect)
+		configData.StateDir = viper.GetString(varStateDir)
+		configData.RunHistoryDays = viper.GetInt(varRunHistoryDays)
+		configData.RunHistoryRuns = viper.GetInt(varRunHistoryRuns)
+		configData.AuditLog = viper.GetString(varAuditLog)
+		configData.AuditSyslog = viper.GetBool(varAuditSyslog)
+		configData.CABundle = viper.GetString(varCABundle)
+		configData.URL = viper.GetString(varURL)
 		configData.MCPEnabled = viper.GetBool(varMCPEnabled)
+		configData.MCPReadOnly = viper.GetBool(varMCPReadOnly)
 
 		// Load MCP instances if configured
 		if viper.IsSet("mcp_instances") {
@@ -98,36 +144,80 @@ Example setting common exclude text for use with 'cronitor discover':
 					if password, ok := configMap["password"].(string); ok {
 						instance.Password = password
 					}
+					if readOnly, ok := configMap["read_only"].(bool); ok {
+						instance.ReadOnly = readOnly
+					}
 					configData.MCPInstances[name] = instance
 				}
 			}
 		}
 
+		// Account settings go to the active profile, and the top-level ones are kept as written
+		existing, err := readConfigFile()
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+		configData.Profile = existing.Profile
+		configData.Profiles = existing.Profiles
+		profile := activeProfile()
+		if profile != "" {
+			var found bool
+			if profile, found = findProfile(existing, profile); !found {
+				// A new profile starts empty rather than with the keys of another account
+				applyProfileSettings(cmd, ProfileConfig{})
+			}
+			if configData.Profiles == nil {
+				configData.Profiles = make(map[string]ProfileConfig)
+			}
+			account := profileFromViper()
+			if account.URL == existing.URL {
+				account.URL = ""
+			}
+			if account.ApiVersion == existing.ApiVersion {
+				account.ApiVersion = ""
+			}
+			configData.Profiles[profile] = account
+			configData.ApiKey = existing.ApiKey
+			configData.PingApiAuthKey = existing.PingApiAuthKey
+			configData.Env = existing.Env
+			configData.URL = existing.URL
+			configData.ApiVersion = existing.ApiVersion
+		}
+
 		fmt.Println("\nConfiguration File:")
 		fmt.Println(configFilePath())
 
+		fmt.Println("\nProfile:")
+		if profile == "" {
+			fmt.Println(defaultProfile)
+		} else {
+			fmt.Println(profile)
+		}
+
 		fmt.Println("\nVersion:")
 		fmt.Println(Version)
 
+		account := profileFromViper()
 		fmt.Println("\nAPI Key:")
-		if configData.ApiKey == "" {
+		if account.ApiKey == "" {
 			fmt.Println("Not Set")
 		} else {
-			fmt.Println(configData.ApiKey)
+			fmt.Println(account.ApiKey)
 		}
 
 		fmt.Println("\nPing API Key:")
-		if configData.PingApiAuthKey == "" {
+		if account.PingApiAuthKey == "" {
 			fmt.Println("Not Set")
 		} else {
-			fmt.Println(configData.PingApiAuthKey)
+			fmt.Println(account.PingApiAuthKey)
 		}
 
 		fmt.Println("\nEnvironment:")
-		if configData.Env == "" {
+		if account.Env == "" {
 			fmt.Println("Not Set")
 		} else {
-			fmt.Println(configData.Env)
+			fmt.Println(account.Env)
 		}
 
 		fmt.Println("\nHostname:")
@@ -164,6 +254,18 @@ Example setting common exclude text for use with 'cronitor discover':
 			fmt.Println(configData.AllowedIPs)
 		}
 
+		fmt.Println("\nDashboard TLS:")
+		if configData.DashTLSCert != "" {
+			fmt.Println(configData.DashTLSCert)
+		} else if configData.DashTLSSelfSigned {
+			fmt.Println("Self-signed certificate")
+		} else {
+			fmt.Println("Off")
+		}
+		if configData.DashTLSClientCA != "" {
+			fmt.Printf("Client certificates signed by %s required\n", configData.DashTLSClientCA)
+		}
+
 		fmt.Println("\nUsers:")
 		if configData.Users == "" {
 			fmt.Println("Current user only")
@@ -171,20 +273,57 @@ Example setting common exclude text for use with 'cronitor discover':
 			fmt.Println(configData.Users)
 		}
 
+		fmt.Println("\nCron Dialect:")
+		if configData.CronDialect == "" {
+			fmt.Printf("Auto-detected (%s)\n", lib.DetectDialect())
+		} else {
+			fmt.Println(configData.CronDialect)
+		}
+
+		fmt.Println("\nState Directory:")
+		if configData.StateDir == "" {
+			fmt.Printf("Default (%s)\n", lib.DefaultStateDir())
+		} else {
+			fmt.Println(configData.StateDir)
+		}
+
+		fmt.Println("\nRun History:")
+		fmt.Printf("%d days, %d runs per job\n", runHistoryDays(), runHistoryRuns())
+
+		fmt.Println("\nAudit Log:")
+		fmt.Println(auditLogPath())
+		if configData.AuditSyslog {
+			fmt.Println("Copied to syslog")
+		}
+
+		fmt.Println("\nCA Bundle:")
+		if configData.CABundle == "" {
+			fmt.Println("System certificates only")
+		} else {
+			fmt.Println(configData.CABundle)
+		}
+
 		fmt.Println("\nAPI Version:")
-		if configData.ApiVersion == "" {
+		if account.ApiVersion == "" {
 			fmt.Println("Not Set (API default)")
 		} else {
-			fmt.Println(configData.ApiVersion)
+			fmt.Println(account.ApiVersion)
 		}
 
 		fmt.Println("\nMCP Enabled:")
 		fmt.Println(configData.MCPEnabled)
+		if configData.MCPReadOnly {
+			fmt.Println("Default instance is read-only")
+		}
 
 		if len(configData.MCPInstances) > 0 {
 			fmt.Println("\nMCP Instances:")
 			for name, instance := range configData.MCPInstances {
-				fmt.Printf("  %s: %s\n", name, instance.URL)
+				if instance.ReadOnly {
+					fmt.Printf("  %s: %s (read-only)\n", name, instance.URL)
+				} else {
+					fmt.Printf("  %s: %s\n", name, instance.URL)
+				}
 			}
 		}
 
@@ -233,6 +372,17 @@ func init() {
 	configureCmd.Flags().String("env", "", "Environment name (e.g. staging, production)")
 	configureCmd.Flags().String("users", "", "Comma-separated list of users whose crontabs to include")
 	configureCmd.Flags().Bool(varMCPEnabled, false, "Enable MCP instances")
+	configureCmd.Flags().Bool("mcp-read-only", false, "Only offer MCP tools that read, not ones that change jobs, monitors or issues, on the default instance")
+	configureCmd.Flags().String("dash-tls-cert", "", "Path to a PEM certificate to serve the dashboard over HTTPS")
+	configureCmd.Flags().String("dash-tls-key", "", "Path to the PEM private key for --dash-tls-cert")
+	configureCmd.Flags().Bool("dash-tls-self-signed", false, "Serve the dashboard over HTTPS with a self-signed certificate")
+	configureCmd.Flags().String("dash-tls-client-ca", "", "Path to a PEM CA bundle used to require client certificates for the dashboard")
+	configureCmd.Flags().String("cron-dialect", "", "Crontab syntax to read and write on this host: vixie, cronie or busybox (default: auto-detect). A crontab can set its own with a '# cronitor: dialect=cronie' comment")
+	configureCmd.Flags().String("state-dir", "", "Directory where cronitor exec records running jobs")
+	configureCmd.Flags().Int("run-history-days", 0, fmt.Sprintf("Days of job runs kept in the dashboard history (default %d)", lib.DefaultRunHistoryDays))
+	configureCmd.Flags().Int("run-history-runs", 0, fmt.Sprintf("Runs of each job kept in the dashboard history (default %d)", lib.DefaultRunHistoryRuns))
+	configureCmd.Flags().String("audit-log", "", "Path to the audit log of dashboard and MCP changes (default: audit.log next to the config file)")
+	configureCmd.Flags().Bool("audit-syslog", false, "Also send audit log entries to syslog")
 
 	viper.BindPFlag(varExcludeText, configureCmd.Flags().Lookup("exclude-from-name"))
 	viper.BindPFlag(varDashUsername, configureCmd.Flags().Lookup("dash-username"))
@@ -243,4 +393,15 @@ func init() {
 	viper.BindPFlag(varEnv, configureCmd.Flags().Lookup("env"))
 	viper.BindPFlag(varUsers, configureCmd.Flags().Lookup("users"))
 	viper.BindPFlag(varMCPEnabled, configureCmd.Flags().Lookup(varMCPEnabled))
+	viper.BindPFlag(varMCPReadOnly, configureCmd.Flags().Lookup("mcp-read-only"))
+	viper.BindPFlag(varDashTLSCert, configureCmd.Flags().Lookup("dash-tls-cert"))
+	viper.BindPFlag(varDashTLSKey, configureCmd.Flags().Lookup("dash-tls-key"))
+	viper.BindPFlag(varDashTLSSelfSigned, configureCmd.Flags().Lookup("dash-tls-self-signed"))
+	viper.BindPFlag(varDashTLSClientCA, configureCmd.Flags().Lookup("dash-tls-client-ca"))
+	viper.BindPFlag(varCronDialect, configureCmd.Flags().Lookup("cron-dialect"))
+	viper.BindPFlag(varStateDir, configureCmd.Flags().Lookup("state-dir"))
+	viper.BindPFlag(varRunHistoryDays, configureCmd.Flags().Lookup("run-history-days"))
+	viper.BindPFlag(varRunHistoryRuns, configureCmd.Flags().Lookup("run-history-runs"))
+	viper.BindPFlag(varAuditLog, configureCmd.Flags().Lookup("audit-log"))
+	viper.BindPFlag(varAuditSyslog, configureCmd.Flags().Lookup("audit-syslog"))
 }
@@ -6,7 +6,6 @@ import (
 	"context"
 	"crypto/rand"
 	"embed"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -30,7 +29,6 @@ import (
 	"time"
 
 	"github.com/cronitorio/cronitor-cli/lib"
-	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/time/rate"
@@ -545,18 +543,15 @@ func getCachedProcessList() []string {
 		return cachedLines
 	}
 
-	// Execute ps command to refresh cache
-	cmd := exec.Command("ps", "-eo", "pgid,lstart,args")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
+	// Execute ps command to refresh cache, on the remote host when using --host
+	out, err := lib.ActiveTransport.Run(nil, nil, "ps", "-eo", "pgid,lstart,args")
+	if err != nil {
 		// Return empty cache on error, don't update timestamp so next call will retry
 		return []string{}
 	}
 
 	// Parse and cache the output
-	output := out.String()
+	output := string(out)
 	psCache.lines = strings.Split(output, "\n")
 	psCache.timestamp = time.Now()
 
@@ -597,6 +592,7 @@ const (
 	varMCPEnabled  = "CRONITOR_MCP_ENABLED"
 	varMCPMode     = "CRONITOR_MCP_MODE"
 	varMCPInstance = "CRONITOR_MCP_INSTANCE"
+	varMCPReadOnly = "CRONITOR_MCP_READ_ONLY"
 )
 
 var dashCmd = &cobra.Command{
@@ -608,7 +604,12 @@ The dashboard provides a web interface for managing your cron jobs and crontab f
 MCP Mode:
 When --mcp-instance flag is used, the dashboard runs in MCP (Model Context Protocol) mode
 for integration with Cursor IDE or other LLM applications. In this mode, it does
-not start a web server but instead communicates via stdio protocol.`,
+not start a web server but instead communicates via stdio protocol.
+
+Hub Mode:
+When --hub is used, the dashboard shows the jobs, crontabs and monitors of every instance
+configured under mcp_instances. Each job is tagged with the name of its host, and run,
+kill and edit actions are forwarded to that host.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get MCP instance name
 		mcpInstance, _ := cmd.Flags().GetString("mcp-instance")
@@ -744,6 +745,13 @@ not start a web server but instead communicates via stdio protocol.`,
 		safeMode, _ := cmd.Flags().GetBool("safe-mode")
 		isSafeModeEnabled = safeMode
 
+		hubMode, _ := cmd.Flags().GetBool("hub")
+		if hubMode && remoteHost != "" {
+			fatal("--hub and --host cannot be used together", 1)
+		}
+
+		connectRemoteHost()
+
 		// Load IP filtering configuration
 		if err := ipFilter.LoadAllowedIPs(); err != nil {
 			fatal(fmt.Sprintf("Failed to load IP filtering configuration: %v", err), 1)
@@ -786,8 +794,8 @@ not start a web server but instead communicates via stdio protocol.`,
 						Path:     "/",
 						HttpOnly: true,
 						SameSite: http.SameSiteStrictMode,
-						Secure:   false, // Set to true when HTTPS is implemented
-						MaxAge:   3600,  // 1 hour
+						Secure:   dashTLSEnabled,
+						MaxAge:   3600, // 1 hour
 					}
 					http.SetCookie(w, cookie)
 
@@ -819,27 +827,28 @@ not start a web server but instead communicates via stdio protocol.`,
 			})
 		}
 
+		// Accounts beyond the configured admin credentials live in the dashboard user store
+		dashUsers, err := loadDashUserStore(dashUsersFilePath())
+		if err != nil {
+			fatal(fmt.Sprintf("Failed to load dashboard users: %v", err), 1)
+		}
+
 		// Basic auth middleware with rate limiting
 		authMiddleware := func(next http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				username := viper.GetString(varDashUsername)
-				password := viper.GetString(varDashPassword)
-
 				// Get client IP for rate limiting
 				clientIP := getClientIP(r)
 
-				auth := r.Header.Get("Authorization")
-				if auth == "" {
+				// Clients that presented a certificate signed by the configured CA are already authenticated
+				if !hasVerifiedClientCertificate(r) && r.Header.Get("Authorization") == "" {
 					w.Header().Set("WWW-Authenticate", `Basic realm="Crontab Guru Dashboard"`)
 					http.Error(w, "Unauthorized", http.StatusUnauthorized)
 					return
 				}
 
-				payload, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "Basic "))
-				pair := strings.SplitN(string(payload), ":", 2)
-
 				// Check for authentication failure
-				if len(pair) != 2 || pair[0] != username || pair[1] != password {
+				dashUser := authenticateDashRequest(r, dashUsers)
+				if dashUser == nil {
 					// Get rate limiter for this IP
 					limiter := authRateLimiter.GetLimiter(clientIP)
 
@@ -860,6 +869,7 @@ not start a web server but instead communicates via stdio protocol.`,
 					http.Error(w, "Unauthorized", http.StatusUnauthorized)
 					return
 				}
+				r = withDashUser(r, dashUser)
 
 				// Authentication successful - regenerate CSRF token to prevent session fixation
 				if token, err := csrfManager.GenerateToken(); err == nil {
@@ -869,8 +879,8 @@ not start a web server but instead communicates via stdio protocol.`,
 						Path:     "/",
 						HttpOnly: true,
 						SameSite: http.SameSiteStrictMode,
-						Secure:   false, // Set to true when HTTPS is implemented
-						MaxAge:   3600,  // 1 hour
+						Secure:   dashTLSEnabled,
+						MaxAge:   3600, // 1 hour
 					}
 					http.SetCookie(w, cookie)
 					w.Header().Set("X-CSRF-Token", token)
@@ -979,16 +989,57 @@ not start a web server but instead communicates via stdio protocol.`,
 			"/api/signup":         handleSignup,
 			"/api/update/check":   handleUpdateCheck,
 			"/api/update/perform": handleUpdatePerform,
+			"/api/session":        handleSession,
+			"/api/runs":           handleRuns,
+			"/api/runs/":          handleRun,
+			"/api/events":         handleEvents,
+		}
+
+		// In hub mode the dashboard aggregates the configured instances instead of this host
+		if hubMode {
+			hub, err := newDashHub(loadHubInstances())
+			if err != nil {
+				fatal(fmt.Sprintf("Failed to start hub: %v", err), 1)
+			}
+			apiRoutes = hub.routes()
+			fmt.Printf("Hub mode: aggregating %d instances\n", len(hub.agents))
+		} else {
+			startDashEventWatcher()
 		}
 
+		// Changes are audited outside the role check so denied requests are recorded too
 		for path, handler := range apiRoutes {
-			http.Handle(path, chainMiddleware(http.HandlerFunc(handler), apiMiddleware...))
+			http.Handle(path, chainMiddleware(auditDashRoute(path, authorizeDashRoute(path, handler), !hubMode), apiMiddleware...))
+		}
+
+		// Prometheus scrapes with basic auth and never sends a CSRF token, so metrics skip the CSRF middleware
+		if !hubMode {
+			http.Handle("/metrics", chainMiddleware(authorizeDashRoute("/metrics", metricsHandler(true)), append(baseMiddleware, authMiddleware)...))
+		}
+
+		// Serve over HTTPS when a certificate is configured or self-signed mode is enabled.
+		// These keys are bound to the configure command, so apply the dash flags explicitly.
+		for flag, key := range map[string]string{
+			"tls-cert":        varDashTLSCert,
+			"tls-key":         varDashTLSKey,
+			"tls-self-signed": varDashTLSSelfSigned,
+			"tls-client-ca":   varDashTLSClientCA,
+		} {
+			if cmd.Flags().Changed(flag) {
+				viper.Set(key, cmd.Flags().Lookup(flag).Value.String())
+			}
+		}
+		tlsConfig, err := buildDashTLSConfig()
+		if err != nil {
+			fatal(fmt.Sprintf("Failed to configure TLS: %v", err), 1)
 		}
+		dashTLSEnabled = tlsConfig != nil
 
 		// Create HTTP server with proper configuration
 		server := &http.Server{
 			Addr:         fmt.Sprintf(":%d", port),
 			Handler:      nil, // Use default ServeMux
+			TLSConfig:    tlsConfig,
 			ReadTimeout:  10 * time.Second,
 			WriteTimeout: 10 * time.Second,
 			IdleTimeout:  60 * time.Second,
@@ -997,7 +1048,13 @@ not start a web server but instead communicates via stdio protocol.`,
 		// Start the server in a goroutine
 		go func() {
 			fmt.Printf("Starting Cronitor dashboard on port %d...\n", port)
-			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			var err error
+			if dashTLSEnabled {
+				err = server.ListenAndServeTLS("", "")
+			} else {
+				err = server.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
 				fatal(err.Error(), 1)
 			}
 		}()
@@ -1006,7 +1063,11 @@ not start a web server but instead communicates via stdio protocol.`,
 		time.Sleep(500 * time.Millisecond)
 
 		// Open the browser
-		url := fmt.Sprintf("http://localhost:%d", port)
+		scheme := "http"
+		if dashTLSEnabled {
+			scheme = "https"
+		}
+		url := fmt.Sprintf("%s://localhost:%d", scheme, port)
 		fmt.Printf("Opening browser to %s...\n", url)
 		openBrowser(url)
 
@@ -1032,34 +1093,6 @@ not start a web server but instead communicates via stdio protocol.`,
 	},
 }
 
-// runMCPServer starts the MCP server for Cursor integration
-func runMCPServer(instanceName string) {
-	// Create MCP server
-	s := server.NewMCPServer(
-		fmt.Sprintf("Cronitor Dashboard (%s)", instanceName),
-		Version,
-		server.WithToolCapabilities(false),
-	)
-
-	// Create and register the Cronitor MCP handler
-	handler := lib.NewCronitorMCPHandler(instanceName)
-
-	// Register tools
-	if err := handler.RegisterTools(s); err != nil {
-		fatal(fmt.Sprintf("Failed to register MCP tools: %v", err), 1)
-	}
-
-	// Register resources
-	if err := handler.RegisterResources(s); err != nil {
-		fatal(fmt.Sprintf("Failed to register MCP resources: %v", err), 1)
-	}
-
-	// Start the stdio server
-	if err := server.ServeStdio(s); err != nil {
-		fatal(fmt.Sprintf("MCP server error: %v", err), 1)
-	}
-}
-
 // Helper function to slugify a string for filenames
 func slugify(s string) string {
 	// Convert to lowercase
@@ -1104,6 +1137,12 @@ func init() {
 	dashCmd.Flags().Bool("safe-mode", false, "Limit the ability to edit jobs, crontabs, and settings")
 	dashCmd.Flags().Bool("mcp", false, "Enable MCP server mode for Cursor integration (deprecated: use --mcp-instance instead)")
 	dashCmd.Flags().String("mcp-instance", "", "MCP instance name to connect to (automatically enables MCP mode)")
+	dashCmd.Flags().String("tls-cert", "", "Path to a PEM certificate to serve the dashboard over HTTPS")
+	dashCmd.Flags().String("tls-key", "", "Path to the PEM private key for --tls-cert")
+	dashCmd.Flags().Bool("tls-self-signed", false, "Serve over HTTPS with a self-signed certificate saved next to the config file")
+	dashCmd.Flags().String("tls-client-ca", "", "Path to a PEM CA bundle; clients must present a certificate signed by it")
+	dashCmd.Flags().Bool("hub", false, "Aggregate the dashboards configured under mcp_instances into a single view")
+	addRemoteHostFlags(dashCmd)
 
 	// Bind MCP flags to viper
 	viper.BindPFlag(varMCPEnabled, dashCmd.Flags().Lookup("mcp"))
@@ -1273,6 +1312,10 @@ func handleSettings(w http.ResponseWriter, r *http.Request) {
 			response.AllowedIPs = os.Getenv(varAllowedIPs)
 		}
 
+		if !dashUserFromRequest(r).Allows(dashRoleAdmin) {
+			response.redactSecrets()
+		}
+
 		responseData, err := json.Marshal(response)
 		if err != nil {
 			http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
@@ -1348,6 +1391,16 @@ func handleSettings(w http.ResponseWriter, r *http.Request) {
 			viper.Set("mcp_instances", configData.MCPInstances)
 		}
 
+		// Profiles are managed with 'cronitor profile', so keep them when the settings page leaves them out
+		if existing, err := readConfigFile(); err == nil {
+			if configData.Profiles == nil {
+				configData.Profiles = existing.Profiles
+			}
+			if configData.Profile == "" {
+				configData.Profile = existing.Profile
+			}
+		}
+
 		// Marshal the config data
 		b, err := json.MarshalIndent(configData, "", "    ")
 		if err != nil {
@@ -1429,8 +1482,8 @@ func handleJobs(w http.ResponseWriter, r *http.Request) {
 }
 
 func handleGetJobs(w http.ResponseWriter, r *http.Request) {
-	// Parse crontabs
-	crontabs, err := lib.GetAllCrontabs(parseUsers())
+	// Parse crontabs, or reuse the last parse while the event watcher reports no changes
+	crontabs, err := cachedCrontabs()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1438,6 +1491,7 @@ func handleGetJobs(w http.ResponseWriter, r *http.Request) {
 
 	// Check if this request is from the Crontabs view (has a 'key' query parameter)
 	includeIgnoredJobs := r.URL.Query().Get("key") != ""
+	dashUser := dashUserFromRequest(r)
 
 	var jobs []Job
 
@@ -1454,6 +1508,11 @@ func handleGetJobs(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
+			// Accounts scoped to specific crontabs or run-as users only see their own jobs
+			if !dashUser.CanAccessLine(crontab, line) {
+				continue
+			}
+
 			timezone := effectiveTimezoneLocationName().Name
 			if crontab.TimezoneLocationName != nil {
 				timezone = crontab.TimezoneLocationName.Name
@@ -1522,6 +1581,7 @@ func handleGetJobs(w http.ResponseWriter, r *http.Request) {
 		}
 
 		jobsWithInstances := 0
+		registered := registeredInstances()
 
 		for i := 0; i < maxJobsForInstances; i++ {
 			job := &jobs[i]
@@ -1534,8 +1594,8 @@ func handleGetJobs(w http.ResponseWriter, r *http.Request) {
 				// Get full command history for this job (current + historical commands)
 				commands := commandHistory.GetCommands(job.Key, job.Command)
 
-				// Find instances using the full command history
-				matchingInstances := findInstances(commands)
+				// Find instances from the registry, or using the full command history for unwrapped jobs
+				matchingInstances := findJobInstances(registered, job.Code, commands)
 
 				if len(matchingInstances) > 0 {
 					job.Instances = matchingInstances
@@ -1699,6 +1759,50 @@ func handleSignup(w http.ResponseWriter, r *http.Request) {
 }
 
 // findInstances finds running instances of commands using cached ps output
+// registeredInstances returns the runs recorded by `cronitor exec`, or nil when the registry cannot be read
+// (including when managing a remote host, where the registry lives on the other machine)
+func registeredInstances() []lib.RunningInstance {
+	if lib.ActiveTransport.IsRemote() {
+		return nil
+	}
+	instances, err := instanceRegistry().List()
+	if err != nil {
+		log(fmt.Sprintf("Failed to read instance registry: %v", err))
+		return nil
+	}
+	return instances
+}
+
+// findJobInstances returns the running instances of a job. Jobs wrapped with `cronitor exec` are read from the
+// instance registry. The ps heuristic is only used for unwrapped jobs, skipping process groups the registry owns.
+func findJobInstances(registered []lib.RunningInstance, code string, commands []string) []JobInstance {
+	if registered != nil && code != "" {
+		instances := []JobInstance{}
+		for _, instance := range registered {
+			if instance.Code == code {
+				instances = append(instances, JobInstance{
+					PID:     strconv.Itoa(instance.PGID),
+					Started: instance.Started.Format(time.ANSIC),
+				})
+			}
+		}
+		return instances
+	}
+
+	registeredGroups := make(map[string]bool)
+	for _, instance := range registered {
+		registeredGroups[strconv.Itoa(instance.PGID)] = true
+	}
+
+	instances := []JobInstance{}
+	for _, instance := range findInstances(commands) {
+		if !registeredGroups[instance.PID] {
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
 func findInstances(commandStrings []string) []JobInstance {
 	if len(commandStrings) == 0 {
 		return []JobInstance{}
@@ -1747,6 +1851,11 @@ func handleRunJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if lib.ActiveTransport.IsRemote() {
+		http.Error(w, "Running jobs is not supported when managing a remote host with --host", http.StatusNotImplemented)
+		return
+	}
+
 	var request struct {
 		Command         string `json:"command"`
 		CrontabFilename string `json:"crontab_filename"`
@@ -1766,6 +1875,12 @@ func handleRunJob(w http.ResponseWriter, r *http.Request) {
 
 	shell := "/bin/sh"     // Default shell
 	var monitorCode string // For monitoring
+	var jobCode string     // Recorded in the run history even when the run is not monitored
+	var stdin string       // Input cron would feed the job from '%' in its command
+
+	// Accounts scoped to specific crontabs or run-as users can only run their own jobs, exactly as written
+	dashUser := dashUserFromRequest(r)
+	requireExactJob := isSafeModeEnabled || dashUser.IsScoped()
 
 	// If crontab filename and key are provided, use them to find the specific job
 	if request.CrontabFilename != "" && request.Key != "" {
@@ -1788,24 +1903,31 @@ func handleRunJob(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 
+			if foundLine != nil && !dashUser.CanAccessLine(crontab, foundLine) {
+				http.Error(w, "Forbidden: this job is outside the crontabs or users you can access", http.StatusForbidden)
+				return
+			}
+
 			if foundLine != nil {
 				// Validate that the command matches what's in the crontab
-				if foundLine.CommandToRun != request.Command && isSafeModeEnabled {
+				if foundLine.CommandToRun != request.Command && requireExactJob {
 					http.Error(w, "Command does not match the job in the crontab", http.StatusForbidden)
 					return
 				}
+				stdin = foundLine.Stdin
+				jobCode = foundLine.Code
 				// Get monitor code if monitoring is requested
 				if request.WithMonitoring && foundLine.Code != "" {
 					monitorCode = foundLine.Code
 				}
-			} else if isSafeModeEnabled {
+			} else if requireExactJob {
 				http.Error(w, "Job not found in crontab", http.StatusForbidden)
 				return
 			}
 		}
-	} else if isSafeModeEnabled {
-		// In safe mode, crontab filename and key are required
-		http.Error(w, "Crontab filename and key are required in safe mode", http.StatusForbidden)
+	} else if requireExactJob {
+		// In safe mode and for scoped accounts, crontab filename and key are required
+		http.Error(w, "Crontab filename and key are required to run this job", http.StatusForbidden)
 		return
 	}
 
@@ -1854,6 +1976,7 @@ func handleRunJob(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
 		cmd := exec.CommandContext(ctx, shell, "-c", request.Command)
 		cmd.Env = makeCronLikeEnv()
+		cmd.Stdin = strings.NewReader(stdin)
 		cmd.Stdout = tempFile
 		cmd.Stderr = tempFile
 
@@ -1874,10 +1997,9 @@ func handleRunJob(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "data: %s\n\n", pidData)
 		w.(http.Flusher).Flush()
 
-		// Invalidate server-side caches so new instances show up immediately
-		invalidateCrontabCache()
-		// Also invalidate process cache since a new process was started
+		// Invalidate the process cache so the new instance shows up immediately
 		invalidateProcessCache()
+		dashEvents.publish(dashJobs.runEvent(dashEventRunStarted, request.Key, jobCode, cmd.Process.Pid, nil))
 
 		err = cmd.Wait()
 		duration := time.Since(startTime)
@@ -1951,6 +2073,31 @@ func handleRunJob(w http.ResponseWriter, r *http.Request) {
 		// Give a brief moment for output to be flushed to the temp file
 		time.Sleep(200 * time.Millisecond)
 
+		if err != nil && exitCode == 0 {
+			exitCode = -1
+		}
+		// Runs requested by the MCP server are told apart the same way the audit log tells them apart
+		trigger := lib.RunTriggerDashboard
+		if r.Header.Get(lib.AuditClientHeader) == "mcp" {
+			trigger = lib.RunTriggerMCP
+		}
+		run := lib.RunRecord{
+			JobKey:    request.Key,
+			Code:      jobCode,
+			Command:   request.Command,
+			Trigger:   trigger,
+			PID:       cmd.Process.Pid,
+			StartedAt: startTime,
+			EndedAt:   startTime.Add(duration),
+			ExitCode:  exitCode,
+			Output:    readOutputTail(tempFile),
+		}
+		if dashUser != nil {
+			run.User = dashUser.Username
+		}
+		dashEvents.publish(dashJobs.runEvent(dashEventRunFinished, request.Key, jobCode, cmd.Process.Pid, &exitCode))
+		recordRun(run)
+
 		// Send completion message - retry a few times if needed
 		completionData, _ := json.Marshal(map[string]string{
 			"completion": statusMsg,
@@ -2089,15 +2236,36 @@ func streamRemainingOutput(tempFile *os.File, lastPos *int64, totalSent *int64,
 	}
 }
 
-// handleKillInstances handles POST requests to kill processes
+// Kill requests wait for jobs to exit inside the dashboard's 10 second write timeout
+const defaultKillGrace = 5 * time.Second
+const maxKillGrace = 8 * time.Second
+
+// KillResult is the outcome of stopping one instance: "terminated" when it exited after the first signal,
+// "killed" when it had to be escalated to SIGKILL, or "error"
+type KillResult struct {
+	PID     int    `json:"pid"`
+	PGID    int    `json:"pgid,omitempty"`
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// handleKillInstances handles POST requests to stop running instances. Each instance's process group is sent
+// the requested signal (TERM by default) and killed if it is still running after the grace period.
 func handleKillInstances(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	if lib.ActiveTransport.IsRemote() {
+		http.Error(w, "Killing instances is not supported when managing a remote host with --host", http.StatusNotImplemented)
+		return
+	}
+
 	var request struct {
-		PIDs []int `json:"pids"`
+		PIDs   []int  `json:"pids"`
+		Signal string `json:"signal"`
+		Grace  string `json:"grace"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -2105,80 +2273,138 @@ func handleKillInstances(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type KillError struct {
-		PID   int    `json:"pid"`
-		Error string `json:"error"`
+	signal := syscall.SIGTERM
+	if request.Signal != "" {
+		var err error
+		if signal, err = lib.ParseSignal(request.Signal); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 	}
 
-	var errors []KillError
-	var killed []int
+	grace := defaultKillGrace
+	if request.Grace != "" {
+		var err error
+		if grace, err = time.ParseDuration(request.Grace); err != nil || grace < 0 {
+			http.Error(w, "Invalid grace period; use a duration such as 5s", http.StatusBadRequest)
+			return
+		}
+		if grace > maxKillGrace {
+			grace = maxKillGrace
+		}
+	}
+
+	// Accounts scoped to specific crontabs or run-as users can only kill instances of their own jobs
+	var allowedPIDs map[string]bool
+	dashUser := dashUserFromRequest(r)
+	if dashUser.IsScoped() {
+		allowedPIDs = instancePIDsInScope(dashUser)
+	}
+
+	killedBy := "a dashboard user"
+	if dashUser != nil {
+		killedBy = dashUser.Username
+	}
 
 	// Create process validator for comprehensive PID validation
 	processValidator := lib.NewProcessValidator()
+	registry := instanceRegistry()
+
+	results := make([]KillResult, len(request.PIDs))
+	var wg sync.WaitGroup
+	for i, pid := range request.PIDs {
+		results[i] = KillResult{PID: pid, Outcome: "error"}
+
+		if allowedPIDs != nil && !allowedPIDs[strconv.Itoa(pid)] {
+			results[i].Error = "Process is not an instance of a job you can access"
+			continue
+		}
 
-	for _, pid := range request.PIDs {
 		// Perform comprehensive PID validation including ownership checks
 		if err := processValidator.ValidatePIDWithOwnership(pid); err != nil {
-			errors = append(errors, KillError{
-				PID:   pid,
-				Error: err.Error(),
-			})
+			results[i].Error = err.Error()
 			continue
 		}
 
-		// PID validation passed, proceed with killing the process
-		cmd := exec.Command("kill", "-9", fmt.Sprintf("%d", pid))
-		output, err := cmd.CombinedOutput()
-
-		if err != nil {
-			outputStr := strings.ToLower(string(output))
-			errorStr := strings.ToLower(err.Error())
-
-			// Check if the process doesn't exist (count as success)
-			if strings.Contains(outputStr, "no such process") ||
-				strings.Contains(errorStr, "no such process") ||
-				strings.Contains(outputStr, "no process found") ||
-				strings.Contains(errorStr, "no process found") {
-				// Process already gone - count as successful
-				killed = append(killed, pid)
+		// Runs recorded by `cronitor exec` and instances found by process group are stopped as a group so
+		// the job's children exit with it. Any other pid is stopped on its own. The group in a registry entry
+		// is only used when it is the group the process is actually in.
+		instance, _ := registry.Find(pid)
+		pgid := 0
+		if instance != nil {
+			if !instance.HasValidProcessGroup() {
+				results[i].Error = "Process group recorded for this instance does not match the process"
 				continue
 			}
+			pgid = instance.PGID
+		} else if lib.ProcessGroupID(pid) == pid && pid > 1 {
+			pgid = pid
+		}
+		results[i].PGID = pgid
 
-			// Check for permission errors
-			if strings.Contains(outputStr, "operation not permitted") ||
-				strings.Contains(errorStr, "operation not permitted") ||
-				strings.Contains(outputStr, "permission denied") ||
-				strings.Contains(errorStr, "permission denied") {
-				errors = append(errors, KillError{
-					PID:   pid,
-					Error: "Insufficient privileges to kill process",
-				})
-			} else {
-				// For other errors, try to provide more context
-				errorMessage := err.Error()
-				if len(output) > 0 {
-					errorMessage = fmt.Sprintf("%s: %s", errorMessage, strings.TrimSpace(string(output)))
-				}
+		wg.Add(1)
+		go func(result *KillResult, instance *lib.RunningInstance) {
+			defer wg.Done()
 
-				// Check if this might be a "process not found" case with exit status 1
-				if strings.Contains(errorMessage, "exit status 1") {
-					// Double-check by trying to see if process exists with ps
-					checkCmd := exec.Command("ps", "-p", fmt.Sprintf("%d", pid))
-					if checkErr := checkCmd.Run(); checkErr != nil {
-						// ps failed to find the process, so it doesn't exist
-						killed = append(killed, pid)
-						continue
-					}
+			// The dashboard reports the kill itself, so `cronitor exec` is told not to send a second failure
+			reportKill := instance != nil && instance.Code != ""
+			if reportKill {
+				if err := registry.MarkKilled(*instance, killedBy); err != nil {
+					log(fmt.Sprintf("Failed to mark instance %d as killed: %v", instance.PID, err))
 				}
+			}
 
-				errors = append(errors, KillError{
-					PID:   pid,
-					Error: errorMessage,
-				})
+			var forced bool
+			var err error
+			if result.PGID > 0 {
+				forced, err = lib.TerminateProcessGroup(result.PGID, signal, grace)
+			} else {
+				forced, err = lib.TerminateProcess(result.PID, signal, grace)
 			}
+
+			if err != nil && reportKill {
+				registry.ClearKilled(instance.PID)
+			}
+
+			switch {
+			case err != nil && strings.Contains(strings.ToLower(err.Error()), "operation not permitted"):
+				result.Error = "Insufficient privileges to kill process"
+				return
+			case err != nil:
+				result.Error = err.Error()
+				return
+			case forced:
+				result.Outcome = "killed"
+			default:
+				result.Outcome = "terminated"
+			}
+
+			// Record the kill against the run so it doesn't look like the job failed on its own
+			if reportKill {
+				endTime := makeStamp()
+				duration := endTime - float64(instance.Started.UnixNano())/float64(time.Second)
+				exitCode := 128 + int(signal)
+				if forced {
+					exitCode = 128 + int(syscall.SIGKILL)
+				}
+				// Sent in the background, as retries could outlast the response's write timeout
+				go sendPing("fail", instance.Code, fmt.Sprintf("Killed by %s via dashboard", killedBy), instance.Series, endTime, &duration, &exitCode, nil, "", nil)
+			}
+		}(&results[i], instance)
+	}
+	wg.Wait()
+
+	killed := []int{}
+	type KillError struct {
+		PID   int    `json:"pid"`
+		Error string `json:"error"`
+	}
+	var errors []KillError
+	for _, result := range results {
+		if result.Outcome == "error" {
+			errors = append(errors, KillError{PID: result.PID, Error: result.Error})
 		} else {
-			// Successfully killed
-			killed = append(killed, pid)
+			killed = append(killed, result.PID)
 		}
 	}
 
@@ -2195,8 +2421,9 @@ func handleKillInstances(w http.ResponseWriter, r *http.Request) {
 	if len(errors) > 0 {
 		w.WriteHeader(http.StatusPartialContent) // 206 for partial success
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"killed": killed,
-			"errors": errors,
+			"killed":  killed,
+			"errors":  errors,
+			"results": results,
 		})
 		return
 	}
@@ -2205,7 +2432,8 @@ func handleKillInstances(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"killed":  killed,
-		"message": fmt.Sprintf("Successfully killed %d process(es)", len(killed)),
+		"results": results,
+		"message": fmt.Sprintf("Successfully stopped %d process(es)", len(killed)),
 	})
 }
 
@@ -2245,6 +2473,11 @@ func handleDeleteJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !dashUserFromRequest(r).CanAccessLine(crontab, foundLine) {
+		http.Error(w, "Forbidden: this job is outside the crontabs or users you can access", http.StatusForbidden)
+		return
+	}
+
 	// If the job is monitored, pause it indefinitely
 	if job.Monitored {
 		if err := getCronitorApi().PauseMonitor(job.Code, ""); err != nil {
@@ -2304,6 +2537,15 @@ func handlePostJob(w http.ResponseWriter, r *http.Request) {
 		job.CrontabFilename = filepath.Join("/etc/cron.d", filename)
 	}
 
+	runAsUser := job.RunAsUser
+	if strings.HasPrefix(job.CrontabFilename, "user:") {
+		runAsUser = strings.TrimPrefix(job.CrontabFilename, "user:")
+	}
+	if dashUser := dashUserFromRequest(r); !dashUser.CanAccessCrontab(job.CrontabFilename) || !dashUser.CanRunAs(runAsUser) {
+		http.Error(w, "Forbidden: this job is outside the crontabs or users you can access", http.StatusForbidden)
+		return
+	}
+
 	// Get the crontab
 	crontab, err := lib.GetCrontab(job.CrontabFilename)
 	if err != nil {
@@ -2394,15 +2636,21 @@ func handleGetCrontabs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse each crontab to ensure lines are loaded
+	// Parse each crontab to ensure lines are loaded, leaving out crontabs outside the account's scope
+	dashUser := dashUserFromRequest(r)
+	visible := []*lib.Crontab{}
 	for _, crontab := range crontabs {
+		if !dashUser.CanAccessCrontab(crontab.Filename) {
+			continue
+		}
 		if len(crontab.Lines) == 0 && crontab.Exists() {
 			crontab.Parse(true)
 		}
+		visible = append(visible, crontab)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(crontabs)
+	json.NewEncoder(w).Encode(visible)
 }
 
 // handlePostCrontabs handles POST requests to create a new crontab
@@ -2440,6 +2688,12 @@ func handlePostCrontabs(w http.ResponseWriter, r *http.Request) {
 		request.Filename = filepath.Join("/etc/cron.d", request.Filename)
 	}
 
+	dashUser := dashUserFromRequest(r)
+	if !dashUser.CanAccessCrontab(request.Filename) || (strings.HasPrefix(request.Filename, "user:") && !dashUser.CanRunAs(strings.TrimPrefix(request.Filename, "user:"))) {
+		http.Error(w, "Forbidden: this crontab is outside the crontabs or users you can access", http.StatusForbidden)
+		return
+	}
+
 	// Try to load the crontab first to check if it exists
 	existingCrontab, err := lib.GetCrontab(request.Filename)
 	if err == nil {
@@ -2453,10 +2707,7 @@ func handlePostCrontabs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create a new crontab
-	username := ""
-	if u, err := user.Current(); err == nil {
-		username = u.Username
-	}
+	username := lib.CurrentUsername()
 
 	newCrontab := lib.CrontabFactory(username, request.Filename)
 
@@ -2507,19 +2758,15 @@ func handleUsers(w http.ResponseWriter, r *http.Request) {
 	var users []string
 
 	// On Unix-like systems, we can use the 'id' command to get a list of users
-	if runtime.GOOS != "windows" {
-		cmd := exec.Command("id", "-u", "-n")
-		output, err := cmd.Output()
-		if err == nil {
+	if runtime.GOOS != "windows" || lib.ActiveTransport.IsRemote() {
+		if currentUser, err := lib.ActiveTransport.CurrentUser(); err == nil {
 			// Add the current user
-			currentUser := strings.TrimSpace(string(output))
 			users = append(users, currentUser)
 		}
 
 		// Try to get additional users from /etc/passwd if available
-		if passwdFile, err := os.Open("/etc/passwd"); err == nil {
-			defer passwdFile.Close()
-			scanner := bufio.NewScanner(passwdFile)
+		if passwd, err := lib.ActiveTransport.ReadFile("/etc/passwd"); err == nil {
+			scanner := bufio.NewScanner(bytes.NewReader(passwd))
 			for scanner.Scan() {
 				line := scanner.Text()
 				fields := strings.Split(line, ":")
@@ -2595,6 +2842,12 @@ func handlePutCrontabs(w http.ResponseWriter, r *http.Request) {
 		filename = "/" + filename
 	}
 
+	dashUser := dashUserFromRequest(r)
+	if !dashUser.CanAccessCrontab(filename) {
+		http.Error(w, "Forbidden: this crontab is outside the crontabs you can access", http.StatusForbidden)
+		return
+	}
+
 	// Parse the request body
 	var request struct {
 		Lines []struct {
@@ -2627,48 +2880,33 @@ func handlePutCrontabs(w http.ResponseWriter, r *http.Request) {
 		// Set line types and parse content based on line type
 		if strings.HasPrefix(line.LineText, "#") {
 			newLine.IsComment = true
-		} else if strings.Contains(line.LineText, "=") {
+		} else if _, _, ok := crontab.Dialect.ParseEnvAssignment(line.LineText); ok {
 			// Environment variable - just use FullLine
 		} else if line.LineText != "" {
-			// This is a job line - we need to parse it properly
-			newLine.IsJob = true
-
-			// Parse the job line to extract cron expression and command
-			splitLine := strings.Fields(line.LineText)
-			if len(splitLine) >= 6 {
-				// Check if it's a 6-field expression
-				var cronExpression string
-				var command []string
-
-				// Handle special @keywords
-				if len(splitLine) > 0 && strings.HasPrefix(splitLine[0], "@") {
-					cronExpression = splitLine[0]
-					command = splitLine[1:]
-				} else {
-					// Standard 5 or 6 field cron expression
-					cronExpression = strings.Join(splitLine[0:5], " ")
-					command = splitLine[5:]
-
-					// Handle run-as user for system crontabs
-					if !crontab.IsUserCrontab && len(command) > 0 {
-						// First word after cron expression might be the user
-						if runtime.GOOS != "windows" {
-							if _, err := exec.Command("id", "-u", command[0]).CombinedOutput(); err == nil {
-								newLine.RunAs = command[0]
-								command = command[1:]
-							}
-						}
-					}
-				}
-
-				newLine.CronExpression = cronExpression
-				newLine.CommandToRun = strings.Join(command, " ")
-			}
+			// This is a job line - parse it the same way the crontab parser would
+			parsed := crontab.ParseEntry(line.LineText)
+			newLine.IsJob = parsed.IsJob
+			newLine.CronExpression = parsed.CronExpression
+			newLine.CommandToRun = parsed.CommandToRun
+			newLine.Stdin = parsed.Stdin
+			newLine.NoSyslog = parsed.NoSyslog
+			newLine.RunAs = parsed.RunAs
+			newLine.Code = parsed.Code
+			newLine.Profile = parsed.Profile
+			newLine.Mon = parsed.Mon
 		}
 
 		newLines = append(newLines, newLine)
 	}
 
+	// Accounts scoped to run-as users can only replace a crontab when every job in it, before and after the edit, is theirs
+	for _, line := range append(crontab.Lines, newLines...) {
+		if line.IsJob && !dashUser.CanAccessLine(crontab, line) {
+			http.Error(w, "Forbidden: this crontab contains jobs for users you cannot access", http.StatusForbidden)
+			return
+		}
+	}
+
 	// Update the crontab's lines
 	crontab.Lines = newLines
 
@@ -2746,6 +2984,11 @@ func handlePutJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !dashUserFromRequest(r).CanAccessLine(crontab, foundLine) {
+		http.Error(w, "Forbidden: this job is outside the crontabs or users you can access", http.StatusForbidden)
+		return
+	}
+
 	// Update the line
 	hasChanges := false
 
@@ -2903,7 +3146,7 @@ func handlePutJob(w http.ResponseWriter, r *http.Request) {
 
 // Helper function to invalidate crontab cache
 func invalidateCrontabCache() {
-	// No-op: Crontab file cache has been removed
+	dashJobs.invalidate()
 }
 
 // Helper function to invalidate process cache
@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+)
+
+// Event types pushed to the dashboard on /api/events
+const (
+	dashEventJobAdded    = "job-added"
+	dashEventJobChanged  = "job-changed"
+	dashEventJobRemoved  = "job-removed"
+	dashEventRunStarted  = "run-started"
+	dashEventRunFinished = "run-finished"
+	dashEventRunRecorded = "run-recorded"
+)
+
+const dashEventKeepalive = 15 * time.Second
+
+// dashEvent is a change to a job or run on this host
+type dashEvent struct {
+	Type     string    `json:"type"`
+	Key      string    `json:"key,omitempty"`
+	Code     string    `json:"code,omitempty"`
+	Crontab  string    `json:"crontab,omitempty"`
+	PID      int       `json:"pid,omitempty"`
+	ExitCode *int      `json:"exit_code,omitempty"`
+	Host     string    `json:"host,omitempty"`
+	Time     time.Time `json:"time"`
+	runAs    string
+}
+
+// visibleTo reports whether a scoped account may see the event
+func (e dashEvent) visibleTo(user *DashUser) bool {
+	if !user.IsScoped() {
+		return true
+	}
+	return e.Crontab != "" && user.CanAccessCrontab(e.Crontab) && user.CanRunAs(e.runAs)
+}
+
+// dashEventBroker fans events out to every connected browser
+type dashEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan dashEvent]bool
+}
+
+var dashEvents = &dashEventBroker{subscribers: make(map[chan dashEvent]bool)}
+
+func (b *dashEventBroker) subscribe() chan dashEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan dashEvent, 64)
+	b.subscribers[ch] = true
+	return ch
+}
+
+func (b *dashEventBroker) unsubscribe(ch chan dashEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers, ch)
+}
+
+// publish never blocks; a browser that falls behind misses events and catches up on its next poll
+func (b *dashEventBroker) publish(events ...dashEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, event := range events {
+		if event.Time.IsZero() {
+			event.Time = time.Now()
+		}
+		for ch := range b.subscribers {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// watchedJob is a crontab line as of the last parse
+type watchedJob struct {
+	crontab     string
+	code        string
+	runAs       string
+	fingerprint string
+}
+
+// dashJobWatcher keeps the parsed crontabs while the watcher is running, so /api/jobs only re-reads
+// crontabs after they change instead of on every request from every browser tab.
+type dashJobWatcher struct {
+	mu        sync.Mutex
+	watching  bool
+	stale     bool
+	crontabs  []*lib.Crontab
+	jobs      map[string]watchedJob
+	instances map[int]lib.RunningInstance
+}
+
+var dashJobs = &dashJobWatcher{stale: true}
+
+// cachedCrontabs returns the crontabs from the last parse, re-reading them only when they changed.
+// Without a watcher every call reads the crontabs.
+func cachedCrontabs() ([]*lib.Crontab, error) {
+	return dashJobs.get()
+}
+
+func (d *dashJobWatcher) get() ([]*lib.Crontab, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.watching {
+		return lib.GetAllCrontabs(parseUsers())
+	}
+	if d.stale {
+		if err := d.reload(); err != nil {
+			return nil, err
+		}
+	}
+	return d.crontabs, nil
+}
+
+func (d *dashJobWatcher) invalidate() {
+	d.mu.Lock()
+	d.stale = true
+	d.mu.Unlock()
+}
+
+// reload parses the crontabs and publishes the jobs that were added, changed or removed since the last parse.
+// The caller holds the lock.
+func (d *dashJobWatcher) reload() error {
+	crontabs, err := lib.GetAllCrontabs(parseUsers())
+	if err != nil {
+		return err
+	}
+
+	jobs := make(map[string]watchedJob)
+	for _, crontab := range crontabs {
+		for _, line := range crontab.Lines {
+			if !line.IsJob {
+				continue
+			}
+			jobs[line.Key(crontab.CanonicalName())] = watchedJob{
+				crontab:     crontab.Filename,
+				code:        line.Code,
+				runAs:       jobRunAsUser(crontab, line),
+				fingerprint: fmt.Sprintf("%d|%s|%t|%t|%s", line.LineNumber, line.Name, line.IsComment, line.Ignored, line.Code),
+			}
+		}
+	}
+
+	if d.jobs != nil {
+		d.publishJobChanges(d.jobs, jobs)
+	}
+
+	d.crontabs = crontabs
+	d.jobs = jobs
+	d.stale = false
+	return nil
+}
+
+func (d *dashJobWatcher) publishJobChanges(before, after map[string]watchedJob) {
+	events := []dashEvent{}
+	for key, job := range after {
+		previous, existed := before[key]
+		if !existed {
+			events = append(events, dashEvent{Type: dashEventJobAdded, Key: key, Code: job.code, Crontab: job.crontab, runAs: job.runAs})
+		} else if previous.fingerprint != job.fingerprint {
+			events = append(events, dashEvent{Type: dashEventJobChanged, Key: key, Code: job.code, Crontab: job.crontab, runAs: job.runAs})
+		}
+	}
+	for key, job := range before {
+		if _, exists := after[key]; !exists {
+			events = append(events, dashEvent{Type: dashEventJobRemoved, Key: key, Code: job.code, Crontab: job.crontab, runAs: job.runAs})
+		}
+	}
+	dashEvents.publish(events...)
+}
+
+// runEvent builds a run event, attributing it to a crontab line when the key or monitor code is known
+func (d *dashJobWatcher) runEvent(eventType string, key string, code string, pid int, exitCode *int) dashEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	event := dashEvent{Type: eventType, Key: key, Code: code, PID: pid, ExitCode: exitCode}
+	job, found := d.jobs[key]
+	if !found && code != "" {
+		for lineKey, candidate := range d.jobs {
+			if candidate.code == code {
+				job, found = candidate, true
+				event.Key = lineKey
+				break
+			}
+		}
+	}
+	if found {
+		event.Crontab = job.crontab
+		event.runAs = job.runAs
+	}
+	return event
+}
+
+// refreshInstances publishes runs that started or finished since the registry was last read
+func (d *dashJobWatcher) refreshInstances() {
+	instances, err := instanceRegistry().List()
+	if err != nil {
+		return
+	}
+
+	d.mu.Lock()
+	before := d.instances
+	current := make(map[int]lib.RunningInstance)
+	for _, instance := range instances {
+		current[instance.PID] = instance
+	}
+	d.instances = current
+	d.mu.Unlock()
+
+	invalidateProcessCache()
+	events := []dashEvent{}
+	for pid, instance := range current {
+		if _, existed := before[pid]; !existed {
+			events = append(events, d.runEvent(dashEventRunStarted, "", instance.Code, pid, nil))
+		}
+	}
+	for pid, instance := range before {
+		if _, exists := current[pid]; !exists {
+			events = append(events, d.runEvent(dashEventRunFinished, "", instance.Code, pid, nil))
+		}
+	}
+	dashEvents.publish(events...)
+}
+
+// ingestRunSpool moves runs finished by `cronitor exec` into the history and tells browsers about them
+func (d *dashJobWatcher) ingestRunSpool() {
+	history, err := runHistory()
+	if err != nil {
+		return
+	}
+	if err := history.IngestSpool(runSpoolDir()); err != nil {
+		log(fmt.Sprintf("Failed to read run spool: %v", err))
+		return
+	}
+	dashEvents.publish(dashEvent{Type: dashEventRunRecorded})
+}
+
+// startDashEventWatcher watches crontabs, the instance registry and the run spool and publishes changes
+// to /api/events. Crontabs on a remote host cannot be watched, so the dashboard keeps polling them.
+func startDashEventWatcher() {
+	if lib.ActiveTransport.IsRemote() {
+		return
+	}
+
+	registryDir := instanceRegistry().Dir
+	spoolDir := runSpoolDir()
+	for _, dir := range []string{registryDir, spoolDir, lastRunDir()} {
+		if err := lib.EnsureSharedDir(dir); err != nil {
+			log(fmt.Sprintf("Cannot watch %s: %v", dir, err))
+		}
+	}
+
+	watcher, err := lib.NewFileWatcher(append(lib.CrontabPaths(), registryDir, spoolDir), 250*time.Millisecond)
+	if err != nil {
+		log(fmt.Sprintf("Cannot watch crontabs for changes, the dashboard will poll instead: %v", err))
+		return
+	}
+
+	dashJobs.mu.Lock()
+	dashJobs.watching = true
+	dashJobs.reload()
+	dashJobs.mu.Unlock()
+	dashJobs.refreshInstances()
+
+	go func() {
+		for changed := range watcher.Changes {
+			crontabsChanged, registryChanged, spoolChanged := false, false, false
+			for _, path := range changed {
+				switch {
+				case strings.HasPrefix(path, spoolDir):
+					spoolChanged = true
+				case strings.HasPrefix(path, registryDir):
+					registryChanged = true
+				default:
+					crontabsChanged = true
+				}
+			}
+
+			if crontabsChanged {
+				dashJobs.mu.Lock()
+				if err := dashJobs.reload(); err != nil {
+					dashJobs.stale = true
+				}
+				dashJobs.mu.Unlock()
+			}
+			if registryChanged {
+				dashJobs.refreshInstances()
+			}
+			if spoolChanged {
+				dashJobs.ingestRunSpool()
+			}
+		}
+	}()
+}
+
+// writeDashEvent sends one server-sent event
+func writeDashEvent(w http.ResponseWriter, event dashEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// handleEvents streams job and run events to the browser as server-sent events
+func handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// The stream stays open far longer than the server's write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	events := dashEvents.subscribe()
+	defer dashEvents.unsubscribe(events)
+
+	dashJobs.mu.Lock()
+	watching := dashJobs.watching
+	dashJobs.mu.Unlock()
+
+	// Tell the browser whether it still needs to poll for changes made on this host
+	fmt.Fprintf(w, "retry: 3000\nevent: connected\ndata: {\"watching\":%t}\n\n", watching)
+	flusher.Flush()
+
+	dashUser := dashUserFromRequest(r)
+	keepalive := time.NewTicker(dashEventKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event := <-events:
+			if !event.visibleTo(dashUser) {
+				continue
+			}
+			if err := writeDashEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
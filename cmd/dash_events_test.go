@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublishJobChanges(t *testing.T) {
+	events := dashEvents.subscribe()
+	defer dashEvents.unsubscribe(events)
+
+	before := map[string]watchedJob{
+		"kept":    {crontab: "/etc/cron.d/app", fingerprint: "1"},
+		"edited":  {crontab: "/etc/cron.d/app", fingerprint: "2"},
+		"removed": {crontab: "/etc/cron.d/app", fingerprint: "3"},
+	}
+	after := map[string]watchedJob{
+		"kept":   {crontab: "/etc/cron.d/app", fingerprint: "1"},
+		"edited": {crontab: "/etc/cron.d/app", fingerprint: "2b"},
+		"added":  {crontab: "/etc/cron.d/app", fingerprint: "4"},
+	}
+	(&dashJobWatcher{}).publishJobChanges(before, after)
+
+	got := make(map[string]string)
+	for len(got) < 3 {
+		select {
+		case event := <-events:
+			got[event.Key] = event.Type
+		case <-time.After(time.Second):
+			t.Fatalf("expected three events, got %v", got)
+		}
+	}
+
+	expected := map[string]string{"added": dashEventJobAdded, "edited": dashEventJobChanged, "removed": dashEventJobRemoved}
+	for key, eventType := range expected {
+		if got[key] != eventType {
+			t.Errorf("expected %s for %s, got %q", eventType, key, got[key])
+		}
+	}
+}
+
+func TestEventsStreamHidesJobsOutsideScope(t *testing.T) {
+	user := &DashUser{Username: "deploy", Role: dashRoleViewer, Crontabs: []string{"/etc/cron.d/app-*"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handleEvents(w, withDashUser(r, user))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %s", resp.Header.Get("Content-Type"))
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	next := func(prefix string) string {
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					t.Fatal("stream closed")
+				}
+				if strings.HasPrefix(line, prefix) {
+					return line
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timed out waiting for %q", prefix)
+			}
+		}
+	}
+
+	if line := next("event: "); line != "event: connected" {
+		t.Fatalf("expected the stream to start with a connected event, got %s", line)
+	}
+	next("data: ")
+
+	// Wait for the handler to subscribe before publishing
+	time.Sleep(100 * time.Millisecond)
+	dashEvents.publish(
+		dashEvent{Type: dashEventJobChanged, Key: "hidden", Crontab: "/etc/crontab"},
+		dashEvent{Type: dashEventJobChanged, Key: "visible", Crontab: "/etc/cron.d/app-web"},
+	)
+
+	if line := next("data: "); !strings.Contains(line, `"key":"visible"`) {
+		t.Errorf("expected only the job in the account's crontabs to be sent, got %s", line)
+	}
+}
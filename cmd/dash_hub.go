@@ -0,0 +1,528 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+)
+
+// hubAgent is a remote `cronitor dash` instance aggregated by a dashboard running with --hub.
+// Agents are the instances configured under mcp_instances.
+type hubAgent struct {
+	Name     string
+	URL      string
+	Username string
+	Password string
+	client   *http.Client
+}
+
+// hubAgentStatus reports the health of an agent for the /api/hub/agents endpoint
+type hubAgentStatus struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+	JobCount  int    `json:"job_count"`
+}
+
+// dashHub fans dashboard API requests out to every agent and merges the results
+type dashHub struct {
+	agents []*hubAgent
+}
+
+// Agents are queried concurrently; this keeps one slow agent from stalling the whole response
+// inside the dashboard's 10 second write timeout.
+const hubAgentTimeout = 8 * time.Second
+
+// newDashHub builds a hub from the configured MCP instances
+func newDashHub(instances map[string]MCPInstanceConfig) (*dashHub, error) {
+	hub := &dashHub{}
+	for name, instance := range instances {
+		if instance.URL == "" {
+			return nil, fmt.Errorf("instance %s has no url configured", name)
+		}
+
+		jar, _ := cookiejar.New(nil)
+		hub.agents = append(hub.agents, &hubAgent{
+			Name:     name,
+			URL:      strings.TrimRight(instance.URL, "/"),
+			Username: instance.Username,
+			Password: instance.Password,
+			client:   &http.Client{Jar: jar},
+		})
+	}
+
+	if len(hub.agents) == 0 {
+		return nil, fmt.Errorf("no instances configured; add them under mcp_instances in %s", configFilePath())
+	}
+
+	sort.Slice(hub.agents, func(i, j int) bool { return hub.agents[i].Name < hub.agents[j].Name })
+	return hub, nil
+}
+
+// loadHubInstances reads the mcp_instances section of the config file
+func loadHubInstances() map[string]MCPInstanceConfig {
+	instances := make(map[string]MCPInstanceConfig)
+	for name, rawConfig := range viper.GetStringMap("mcp_instances") {
+		if configMap, ok := rawConfig.(map[string]interface{}); ok {
+			instance := MCPInstanceConfig{}
+			if url, ok := configMap["url"].(string); ok {
+				instance.URL = url
+			}
+			if username, ok := configMap["username"].(string); ok {
+				instance.Username = username
+			}
+			if password, ok := configMap["password"].(string); ok {
+				instance.Password = password
+			}
+			instances[name] = instance
+		}
+	}
+	return instances
+}
+
+func (h *dashHub) agent(name string) *hubAgent {
+	for _, agent := range h.agents {
+		if agent.Name == name {
+			return agent
+		}
+	}
+	return nil
+}
+
+// routes returns the API handlers that replace the local handlers in hub mode
+func (h *dashHub) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/api/settings":       handleSettings,
+		"/api/jobs":           h.aggregateOrForward("/api/jobs", nil),
+		"/api/crontabs":       h.aggregateOrForward("/api/crontabs", nil),
+		"/api/crontabs/":      h.forward,
+		"/api/users":          h.forward,
+		"/api/jobs/kill":      h.forward,
+		"/api/jobs/run":       h.forward,
+		"/api/monitors":       h.aggregateOrForward("/api/monitors", dedupeByKey),
+		"/api/hub/agents":     h.handleAgents,
+		"/api/signup":         handleSignup,
+		"/api/update/check":   handleUpdateCheck,
+		"/api/update/perform": handleUpdatePerform,
+		"/api/session":        handleSession,
+		"/api/runs":           h.aggregateOrForward("/api/runs", nil),
+		"/api/runs/":          h.forward,
+		"/api/events":         h.handleEvents,
+	}
+}
+
+// aggregateOrForward merges GET responses from every agent, tagging each item with its host and
+// dropping the items outside a scoped account's scope. Any other request is forwarded to the agent
+// named by the host parameter.
+func (h *dashHub) aggregateOrForward(path string, merge func([]map[string]interface{}) []map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dashUser := dashUserFromRequest(r)
+		host := r.URL.Query().Get("host")
+		if r.Method != "GET" || (host != "" && !dashUser.IsScoped()) {
+			h.forward(w, r)
+			return
+		}
+
+		agents := h.agents
+		query := r.URL.Query()
+		if host != "" {
+			agent := h.agent(host)
+			if agent == nil {
+				http.Error(w, fmt.Sprintf("Unknown host %s", host), http.StatusNotFound)
+				return
+			}
+			agents = []*hubAgent{agent}
+			query.Del("host")
+		}
+
+		items, statuses := h.collectFrom(agents, path+queryString(query))
+		for _, status := range statuses {
+			if !status.Reachable {
+				log(fmt.Sprintf("Hub: %s is unreachable: %s", status.Name, status.Error))
+			}
+		}
+		items = h.scopeFor(dashUser, agents).filter(path, items)
+		if merge != nil {
+			items = merge(items)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}
+}
+
+// collect fetches a JSON array from every agent concurrently
+func (h *dashHub) collect(path string) ([]map[string]interface{}, []hubAgentStatus) {
+	return h.collectFrom(h.agents, path)
+}
+
+func (h *dashHub) collectFrom(agents []*hubAgent, path string) ([]map[string]interface{}, []hubAgentStatus) {
+	results := make([][]map[string]interface{}, len(agents))
+	statuses := make([]hubAgentStatus, len(agents))
+
+	var wg sync.WaitGroup
+	for i, agent := range agents {
+		wg.Add(1)
+		go func(i int, agent *hubAgent) {
+			defer wg.Done()
+			statuses[i] = hubAgentStatus{Name: agent.Name, URL: agent.URL}
+
+			body, err := agent.get(path)
+			if err == nil {
+				err = json.Unmarshal(body, &results[i])
+			}
+			if err != nil {
+				statuses[i].Error = err.Error()
+				return
+			}
+
+			statuses[i].Reachable = true
+			for _, item := range results[i] {
+				item["host"] = agent.Name
+			}
+		}(i, agent)
+	}
+	wg.Wait()
+
+	items := []map[string]interface{}{}
+	for _, result := range results {
+		items = append(items, result...)
+	}
+	return items, statuses
+}
+
+// handleAgents reports which agents are reachable and how many jobs each has
+func (h *dashHub) handleAgents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jobs, statuses := h.collect("/api/jobs")
+	for _, job := range jobs {
+		for i := range statuses {
+			if statuses[i].Name == job["host"] {
+				statuses[i].JobCount++
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statuses)
+}
+
+// forward proxies a request to the agent named by the host query parameter or the "host" field of a JSON body
+func (h *dashHub) forward(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	host := r.URL.Query().Get("host")
+	if host == "" && len(body) > 0 {
+		var payload struct {
+			Host string `json:"host"`
+		}
+		json.Unmarshal(body, &payload)
+		host = payload.Host
+	}
+
+	if host == "" {
+		http.Error(w, "A host is required when the dashboard is running in hub mode", http.StatusBadRequest)
+		return
+	}
+
+	agent := h.agent(host)
+	if agent == nil {
+		http.Error(w, fmt.Sprintf("Unknown host %s", host), http.StatusNotFound)
+		return
+	}
+
+	scope := h.scopeFor(dashUserFromRequest(r), []*hubAgent{agent})
+	if reason := scope.authorize(r, host, body); reason != "" {
+		http.Error(w, reason, http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	query.Del("host")
+
+	if scope != nil && r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/runs/") {
+		h.forwardRun(w, agent, scope, r.URL.Path+queryString(query))
+		return
+	}
+
+	resp, err := agent.do(r.Context(), r.Method, r.URL.Path+queryString(query), body, r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reach %s: %v", agent.Name, err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, header := range []string{"Content-Type", "Cache-Control"} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	// Copy in chunks and flush so streamed output from /api/jobs/run reaches the browser as it arrives
+	flusher, _ := w.(http.Flusher)
+	buffer := make([]byte, 4096)
+	for {
+		n, err := resp.Body.Read(buffer)
+		if n > 0 {
+			w.Write(buffer[:n])
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// forwardRun returns a run from the agent only when it belongs to a job in the account's scope
+func (h *dashHub) forwardRun(w http.ResponseWriter, agent *hubAgent, scope *hubScope, path string) {
+	body, err := agent.get(path)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reach %s: %v", agent.Name, err), http.StatusBadGateway)
+		return
+	}
+
+	var run map[string]interface{}
+	if err := json.Unmarshal(body, &run); err != nil || !scope.allows("/api/runs", map[string]interface{}{"host": agent.Name, "job_key": run["job_key"], "code": run["code"]}) {
+		http.Error(w, "Run not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func (a *hubAgent) get(path string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), hubAgentTimeout)
+	defer cancel()
+
+	resp, err := a.do(ctx, "GET", path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// do sends an authenticated request to the agent, fetching a CSRF token first for state-changing requests
+func (a *hubAgent) do(ctx context.Context, method, path string, body []byte, contentType string) (*http.Response, error) {
+	var token string
+	if method != "GET" && method != "HEAD" {
+		var err error
+		if token, err = a.csrf(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return a.send(ctx, method, path, body, contentType, token)
+}
+
+func (a *hubAgent) send(ctx context.Context, method, path string, body []byte, contentType, token string) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.URL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if a.Username != "" && a.Password != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		req.Header.Set("X-CSRF-Token", token)
+	}
+	req.Header.Set(lib.AuditClientHeader, "hub")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		return nil, fmt.Errorf("authentication failed - check username and password for instance '%s'", a.Name)
+	}
+	return resp, nil
+}
+
+// csrf requests a CSRF token from the agent. Tokens are single use, so each state-changing request needs a new one.
+func (a *hubAgent) csrf(ctx context.Context) (string, error) {
+	resp, err := a.send(ctx, "GET", "/api/settings", nil, "", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get CSRF token: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	token := resp.Header.Get("X-CSRF-Token")
+	if token == "" {
+		if parsedURL, err := url.Parse(a.URL); err == nil {
+			for _, cookie := range a.client.Jar.Cookies(parsedURL) {
+				if cookie.Name == "csrf_token" {
+					token = cookie.Value
+					break
+				}
+			}
+		}
+	}
+
+	if token == "" {
+		return "", fmt.Errorf("failed to obtain CSRF token from %s", a.Name)
+	}
+	return token, nil
+}
+
+// dedupeByKey merges monitors reported by several agents sharing a Cronitor account, listing every host that reported each one
+func dedupeByKey(items []map[string]interface{}) []map[string]interface{} {
+	merged := []map[string]interface{}{}
+	byKey := make(map[string]map[string]interface{})
+
+	for _, item := range items {
+		key, _ := item["key"].(string)
+		host, _ := item["host"].(string)
+		if existing, ok := byKey[key]; ok && key != "" {
+			existing["hosts"] = append(existing["hosts"].([]string), host)
+			continue
+		}
+
+		item["hosts"] = []string{host}
+		delete(item, "host")
+		byKey[key] = item
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+func queryString(query url.Values) string {
+	if encoded := query.Encode(); encoded != "" {
+		return "?" + encoded
+	}
+	return ""
+}
+
+// handleEvents merges the event streams of every agent into one, tagging each event with its host.
+// Agents that cannot be reached are skipped; the browser keeps polling their jobs.
+func (h *dashHub) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// The scope of a scoped account is fixed when the stream opens; jobs added later reach the browser by polling
+	scope := h.scopeFor(dashUserFromRequest(r), h.agents)
+
+	events := make(chan dashEvent, 64)
+	for _, agent := range h.agents {
+		go agent.streamEvents(r.Context(), events)
+	}
+
+	// Agents are watched by their own dashboards, but the hub cannot promise every agent is connected
+	fmt.Fprint(w, "retry: 3000\nevent: connected\ndata: {\"watching\":false}\n\n")
+	flusher.Flush()
+
+	keepalive := time.NewTicker(dashEventKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event := <-events:
+			if !scope.eventVisible(event) {
+				continue
+			}
+			if err := writeDashEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// streamEvents reads the agent's event stream until the context is cancelled or the agent disconnects
+func (a *hubAgent) streamEvents(ctx context.Context, events chan<- dashEvent) {
+	resp, err := a.do(ctx, "GET", "/api/events", nil, "")
+	if err != nil {
+		log(fmt.Sprintf("Hub: cannot stream events from %s: %v", a.Name, err))
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data := strings.TrimPrefix(scanner.Text(), "data: ")
+		if data == scanner.Text() {
+			continue
+		}
+
+		var event dashEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil || event.Type == "" {
+			continue
+		}
+		event.Host = a.Name
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hubScope limits a scoped account to its own jobs across the agents. The hub reaches every agent with the
+// credentials configured under mcp_instances, so the agents cannot apply the account's scope themselves.
+// A nil scope is an unscoped account and allows everything.
+type hubScope struct {
+	user *DashUser
+	jobs map[string][]map[string]interface{} // jobs the account can access, by host
+}
+
+// scopeFor builds the scope of a signed in account from the job lists of the given agents.
+// Jobs on an agent that cannot be reached are out of scope until it is back.
+func (h *dashHub) scopeFor(user *DashUser, agents []*hubAgent) *hubScope {
+	if !user.IsScoped() {
+		return nil
+	}
+
+	scope := &hubScope{user: user, jobs: make(map[string][]map[string]interface{})}
+	jobs, _ := h.collectFrom(agents, "/api/jobs")
+	for _, job := range jobs {
+		if scope.canAccessJob(job) {
+			host := stringField(job, "host")
+			scope.jobs[host] = append(scope.jobs[host], job)
+		}
+	}
+	return scope
+}
+
+// canAccessJob applies the same check as DashUser.CanAccessLine to a job reported by an agent
+func (s *hubScope) canAccessJob(job map[string]interface{}) bool {
+	return s.user.CanAccessCrontab(stringField(job, "crontab_filename")) && s.user.CanRunAs(stringField(job, "run_as_user"))
+}
+
+// job returns the in-scope job on host with the given key or monitor code
+func (s *hubScope) job(host, key, code string) map[string]interface{} {
+	for _, job := range s.jobs[host] {
+		if (key != "" && stringField(job, "key") == key) || (code != "" && stringField(job, "code") == code) {
+			return job
+		}
+	}
+	return nil
+}
+
+// hasInstance reports whether pid is a running instance of an in-scope job on host
+func (s *hubScope) hasInstance(host string, pid int) bool {
+	for _, job := range s.jobs[host] {
+		instances, _ := job["instances"].([]interface{})
+		for _, instance := range instances {
+			if instance, ok := instance.(map[string]interface{}); ok && stringField(instance, "pid") == strconv.Itoa(pid) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filter drops the items of an aggregated list that are outside the scope. Monitors are not scoped,
+// as on the agents' own dashboards.
+func (s *hubScope) filter(path string, items []map[string]interface{}) []map[string]interface{} {
+	if s == nil {
+		return items
+	}
+
+	filtered := []map[string]interface{}{}
+	for _, item := range items {
+		if s.allows(path, item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func (s *hubScope) allows(path string, item map[string]interface{}) bool {
+	if s == nil {
+		return true
+	}
+
+	host := stringField(item, "host")
+	switch path {
+	case "/api/jobs":
+		return s.job(host, stringField(item, "key"), "") != nil
+	case "/api/crontabs":
+		return s.user.CanAccessCrontab(stringField(item, "filename"))
+	case "/api/runs":
+		return s.job(host, stringField(item, "job_key"), stringField(item, "code")) != nil
+	}
+	return true
+}
+
+// eventVisible reports whether an event from an agent belongs to an in-scope job
+func (s *hubScope) eventVisible(event dashEvent) bool {
+	return s == nil || s.job(event.Host, event.Key, event.Code) != nil
+}
+
+// authorize checks a request forwarded to host against the scope the way the agent would check it
+// for the account, returning a reason when it is refused
+func (s *hubScope) authorize(r *http.Request, host string, body []byte) string {
+	if s == nil {
+		return ""
+	}
+
+	const outOfScope = "Forbidden: this job is outside the crontabs or users you can access"
+	switch path := r.URL.Path; {
+	case path == "/api/jobs/run":
+		var request struct {
+			Command         string `json:"command"`
+			CrontabFilename string `json:"crontab_filename"`
+			Key             string `json:"key"`
+		}
+		json.Unmarshal(body, &request)
+
+		// Scoped accounts can only run their own jobs, exactly as written
+		job := s.job(host, request.Key, "")
+		if request.Key == "" || job == nil || stringField(job, "crontab_filename") != request.CrontabFilename || stringField(job, "command") != request.Command {
+			return outOfScope
+		}
+
+	case path == "/api/jobs/kill":
+		var request struct {
+			PIDs []int `json:"pids"`
+		}
+		json.Unmarshal(body, &request)
+		for _, pid := range request.PIDs {
+			if !s.hasInstance(host, pid) {
+				return "Forbidden: this process is not an instance of a job you can access"
+			}
+		}
+
+	case path == "/api/jobs" && r.Method == "POST":
+		var job struct {
+			Name            string `json:"name"`
+			CrontabFilename string `json:"crontab_filename"`
+			RunAsUser       string `json:"run_as_user"`
+		}
+		json.Unmarshal(body, &job)
+
+		if job.CrontabFilename == "/etc/cron.d" {
+			job.CrontabFilename = filepath.Join("/etc/cron.d", slugify(job.Name)+".cron")
+		}
+		runAsUser := job.RunAsUser
+		if strings.HasPrefix(job.CrontabFilename, "user:") {
+			runAsUser = strings.TrimPrefix(job.CrontabFilename, "user:")
+		}
+		if !s.user.CanAccessCrontab(job.CrontabFilename) || !s.user.CanRunAs(runAsUser) {
+			return outOfScope
+		}
+
+	case path == "/api/jobs":
+		var request struct {
+			Key             string `json:"key"`
+			Code            string `json:"code"`
+			CrontabFilename string `json:"crontab_filename"`
+		}
+		json.Unmarshal(body, &request)
+
+		job := s.job(host, request.Key, request.Code)
+		if job == nil || (request.CrontabFilename != "" && stringField(job, "crontab_filename") != request.CrontabFilename) {
+			return outOfScope
+		}
+
+	case path == "/api/crontabs" && r.Method != "GET":
+		var request struct {
+			Filename string `json:"filename"`
+		}
+		json.Unmarshal(body, &request)
+
+		filename := request.Filename
+		if !strings.Contains(filename, "/") && filename != "/etc/crontab" && !strings.HasPrefix(filename, "user:") {
+			filename = filepath.Join("/etc/cron.d", filename)
+		}
+		if !s.user.CanAccessCrontab(filename) || (strings.HasPrefix(filename, "user:") && !s.user.CanRunAs(strings.TrimPrefix(filename, "user:"))) {
+			return "Forbidden: this crontab is outside the crontabs or users you can access"
+		}
+
+	case strings.HasPrefix(path, "/api/crontabs/"):
+		filename := strings.TrimPrefix(path, "/api/crontabs/")
+		if !strings.HasPrefix(filename, "user:") {
+			filename = "/" + filename
+		}
+		if !s.user.CanAccessCrontab(filename) {
+			return "Forbidden: this crontab is outside the crontabs you can access"
+		}
+
+		// The hub cannot tell who the jobs in a shared crontab run as, so accounts scoped to run-as users
+		// can only replace the crontabs of those users
+		if len(s.user.RunAs) > 0 && !(strings.HasPrefix(filename, "user:") && s.user.CanRunAs(strings.TrimPrefix(filename, "user:"))) {
+			return "Forbidden: this crontab contains jobs for users you cannot access"
+		}
+	}
+	return ""
+}
+
+func stringField(item map[string]interface{}, name string) string {
+	value, _ := item[name].(string)
+	return value
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestAgent starts a fake dashboard that serves a fixed job list and records mutating requests
+func newTestAgent(t *testing.T, jobs string, received *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method == "GET" {
+			w.Header().Set("X-CSRF-Token", "token-"+r.URL.Path)
+		} else if r.Header.Get("X-CSRF-Token") != "token-/api/settings" {
+			http.Error(w, "CSRF token validation failed", http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/jobs" && r.Method == "GET":
+			w.Write([]byte(jobs))
+		case r.URL.Path == "/api/settings":
+			w.Write([]byte("{}"))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			*received = append(*received, r.Method+" "+r.URL.String()+" "+string(body))
+			w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDashHubAggregatesJobs(t *testing.T) {
+	var received []string
+	web := newTestAgent(t, `[{"key":"a","command":"backup"}]`, &received)
+	db := newTestAgent(t, `[{"key":"b","command":"vacuum"},{"key":"c","command":"dump"}]`, &received)
+
+	hub, err := newDashHub(map[string]MCPInstanceConfig{
+		"web1": {URL: web.URL, Username: "admin", Password: "secret"},
+		"db1":  {URL: db.URL + "/", Username: "admin", Password: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	hub.routes()["/api/jobs"](recorder, httptest.NewRequest("GET", "/api/jobs", nil))
+
+	var jobs []map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &jobs); err != nil {
+		t.Fatalf("invalid response %q: %v", recorder.Body.String(), err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+
+	hosts := map[string]string{}
+	for _, job := range jobs {
+		hosts[job["key"].(string)] = job["host"].(string)
+	}
+	if hosts["a"] != "web1" || hosts["b"] != "db1" || hosts["c"] != "db1" {
+		t.Errorf("jobs were not tagged with their host: %v", hosts)
+	}
+}
+
+func TestDashHubForwardsActionsToHost(t *testing.T) {
+	var webReceived, dbReceived []string
+	web := newTestAgent(t, `[]`, &webReceived)
+	db := newTestAgent(t, `[]`, &dbReceived)
+
+	hub, _ := newDashHub(map[string]MCPInstanceConfig{
+		"web1": {URL: web.URL, Username: "admin", Password: "secret"},
+		"db1":  {URL: db.URL, Username: "admin", Password: "secret"},
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/api/jobs/kill", strings.NewReader(`{"host":"db1","pids":[42]}`))
+	request.Header.Set("Content-Type", "application/json")
+	hub.routes()["/api/jobs/kill"](recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(webReceived) != 0 || len(dbReceived) != 1 || !strings.Contains(dbReceived[0], `"pids":[42]`) {
+		t.Errorf("expected kill to reach only db1, got web1=%v db1=%v", webReceived, dbReceived)
+	}
+
+	recorder = httptest.NewRecorder()
+	hub.routes()["/api/crontabs/"](recorder, httptest.NewRequest("PUT", "/api/crontabs/etc/crontab?host=web1", strings.NewReader(`{"lines":[]}`)))
+	if recorder.Code != http.StatusOK || len(webReceived) != 1 || !strings.HasPrefix(webReceived[0], "PUT /api/crontabs/etc/crontab ") {
+		t.Errorf("expected crontab edit to be forwarded to web1 without the host param, got %d %v", recorder.Code, webReceived)
+	}
+
+	recorder = httptest.NewRecorder()
+	hub.routes()["/api/jobs/run"](recorder, httptest.NewRequest("POST", "/api/jobs/run", strings.NewReader(`{"command":"true"}`)))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 when no host is given, got %d", recorder.Code)
+	}
+}
+
+func TestDashHubAppliesAccountScope(t *testing.T) {
+	var received []string
+	web := newTestAgent(t, `[
+		{"key":"a","command":"backup","crontab_filename":"user:deploy","run_as_user":"deploy","instances":[{"pid":"42"}]},
+		{"key":"b","command":"rotate","crontab_filename":"/etc/crontab","run_as_user":"root","instances":[{"pid":"43"}]}
+	]`, &received)
+
+	hub, _ := newDashHub(map[string]MCPInstanceConfig{
+		"web1": {URL: web.URL, Username: "admin", Password: "secret"},
+	})
+	deploy := &DashUser{Username: "deploy", Role: dashRoleOperator, RunAs: []string{"deploy"}}
+
+	recorder := httptest.NewRecorder()
+	hub.routes()["/api/jobs"](recorder, withDashUser(httptest.NewRequest("GET", "/api/jobs", nil), deploy))
+
+	var jobs []map[string]interface{}
+	json.Unmarshal(recorder.Body.Bytes(), &jobs)
+	if len(jobs) != 1 || jobs[0]["key"] != "a" {
+		t.Errorf("expected only the deploy job, got %v", jobs)
+	}
+
+	recorder = httptest.NewRecorder()
+	hub.routes()["/api/jobs"](recorder, withDashUser(httptest.NewRequest("GET", "/api/jobs?host=web1", nil), deploy))
+	jobs = nil
+	json.Unmarshal(recorder.Body.Bytes(), &jobs)
+	if len(jobs) != 1 || jobs[0]["key"] != "a" {
+		t.Errorf("expected only the deploy job from a single host, got %v", jobs)
+	}
+
+	for _, body := range []string{
+		`{"host":"web1","pids":[43]}`,
+		`{"host":"web1","pids":[42,43]}`,
+	} {
+		recorder = httptest.NewRecorder()
+		hub.routes()["/api/jobs/kill"](recorder, withDashUser(httptest.NewRequest("POST", "/api/jobs/kill", strings.NewReader(body)), deploy))
+		if recorder.Code != http.StatusForbidden {
+			t.Errorf("expected killing a root job to be refused for %s, got %d", body, recorder.Code)
+		}
+	}
+
+	recorder = httptest.NewRecorder()
+	hub.routes()["/api/jobs/run"](recorder, withDashUser(httptest.NewRequest("POST", "/api/jobs/run", strings.NewReader(`{"host":"web1","key":"a","crontab_filename":"user:deploy","command":"backup; id"}`)), deploy))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected running a changed command to be refused, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	hub.routes()["/api/crontabs/"](recorder, withDashUser(httptest.NewRequest("PUT", "/api/crontabs/etc/crontab?host=web1", strings.NewReader(`{"lines":[]}`)), deploy))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected replacing /etc/crontab to be refused, got %d", recorder.Code)
+	}
+
+	if len(received) != 0 {
+		t.Fatalf("expected no refused request to reach the agent, got %v", received)
+	}
+
+	recorder = httptest.NewRecorder()
+	hub.routes()["/api/jobs/kill"](recorder, withDashUser(httptest.NewRequest("POST", "/api/jobs/kill", strings.NewReader(`{"host":"web1","pids":[42]}`)), deploy))
+	if recorder.Code != http.StatusOK || len(received) != 1 {
+		t.Errorf("expected killing the deploy job to be forwarded, got %d %v", recorder.Code, received)
+	}
+}
+
+func TestDashHubRequiresInstances(t *testing.T) {
+	if _, err := newDashHub(map[string]MCPInstanceConfig{}); err == nil {
+		t.Error("expected an error with no instances configured")
+	}
+	if _, err := newDashHub(map[string]MCPInstanceConfig{"web1": {}}); err == nil {
+		t.Error("expected an error for an instance without a url")
+	}
+}
+
+func TestDedupeMonitorsByKey(t *testing.T) {
+	merged := dedupeByKey([]map[string]interface{}{
+		{"key": "backup", "host": "web1"},
+		{"key": "backup", "host": "web2"},
+		{"key": "vacuum", "host": "db1"},
+	})
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 monitors, got %d", len(merged))
+	}
+	if hosts := merged[0]["hosts"].([]string); len(hosts) != 2 || hosts[1] != "web2" {
+		t.Errorf("expected backup to list both hosts, got %v", hosts)
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+)
+
+const runHistoryFilename = "runs.db"
+
+var (
+	dashRunHistory   *lib.RunHistory
+	dashRunHistoryMu sync.Mutex
+
+	// dashRunsSpooled is set in processes other than the dashboard, which leave finished runs in the spool
+	// for the dashboard rather than holding the history open themselves
+	dashRunsSpooled bool
+)
+
+func runHistoryDays() int {
+	if days := viper.GetInt(varRunHistoryDays); days > 0 {
+		return days
+	}
+	return lib.DefaultRunHistoryDays
+}
+
+func runHistoryRuns() int {
+	if runs := viper.GetInt(varRunHistoryRuns); runs > 0 {
+		return runs
+	}
+	return lib.DefaultRunHistoryRuns
+}
+
+// runSpoolDir is where `cronitor exec` leaves finished runs for the dashboard, inside the state directory
+func runSpoolDir() string {
+	return filepath.Join(stateDir(), "runs")
+}
+
+// runHistory opens the run history next to the config file the first time it is needed. The dashboard
+// keeps it open for as long as it runs.
+func runHistory() (*lib.RunHistory, error) {
+	dashRunHistoryMu.Lock()
+	defer dashRunHistoryMu.Unlock()
+
+	if dashRunHistory == nil {
+		history, err := lib.OpenRunHistory(filepath.Join(filepath.Dir(configFilePath()), runHistoryFilename), runHistoryDays(), runHistoryRuns())
+		if err != nil {
+			return nil, err
+		}
+		dashRunHistory = history
+	}
+	return dashRunHistory, nil
+}
+
+// closeRunHistory releases the run history so another process can open it
+func closeRunHistory() {
+	dashRunHistoryMu.Lock()
+	defer dashRunHistoryMu.Unlock()
+
+	if dashRunHistory != nil {
+		dashRunHistory.Close()
+		dashRunHistory = nil
+	}
+}
+
+// recordRun adds a finished run to the history, logging rather than failing when it cannot be saved
+func recordRun(record lib.RunRecord) {
+	if dashRunsSpooled {
+		spoolRun(record)
+		return
+	}
+
+	history, err := runHistory()
+	if err == nil {
+		record, err = history.Add(record)
+	}
+	if err != nil {
+		log(fmt.Sprintf("Failed to record run in history: %v", err))
+		return
+	}
+	dashEvents.publish(dashJobs.runEvent(dashEventRunRecorded, record.JobKey, record.Code, record.PID, &record.ExitCode))
+}
+
+// spoolRun hands a run finished by `cronitor exec` to the dashboard
+func spoolRun(record lib.RunRecord) {
+	if err := lib.SpoolRun(runSpoolDir(), record); err != nil {
+		log(err.Error())
+	}
+}
+
+// readOutputTail returns up to the last lib.MaxRunOutput bytes written to a job's output file
+func readOutputTail(file *os.File) string {
+	if file == nil {
+		return ""
+	}
+
+	size, err := getFileSize(file)
+	if err != nil {
+		return ""
+	}
+
+	offset := int64(0)
+	if size > lib.MaxRunOutput {
+		offset = size - lib.MaxRunOutput
+	}
+	output := make([]byte, size-offset)
+	n, _ := file.ReadAt(output, offset)
+	return string(output[:n])
+}
+
+// runScopeFilter limits runs to the jobs a scoped account can access. Unscoped accounts see every run.
+func runScopeFilter(user *DashUser) func(lib.RunRecord) bool {
+	if !user.IsScoped() {
+		return nil
+	}
+
+	keys := make(map[string]bool)
+	codes := make(map[string]bool)
+	if crontabs, err := lib.GetAllCrontabs(parseUsers()); err == nil {
+		for _, crontab := range crontabs {
+			for _, line := range crontab.Lines {
+				if !line.IsJob || !user.CanAccessLine(crontab, line) {
+					continue
+				}
+				keys[line.Key(crontab.CanonicalName())] = true
+				if line.Code != "" {
+					codes[line.Code] = true
+				}
+			}
+		}
+	}
+
+	return func(record lib.RunRecord) bool {
+		return (record.JobKey != "" && keys[record.JobKey]) || (record.Code != "" && codes[record.Code])
+	}
+}
+
+// handleRuns lists recorded runs, newest first, optionally for a single job by key or monitor code
+func handleRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	history, err := runHistory()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err := history.IngestSpool(runSpoolDir()); err != nil {
+		log(fmt.Sprintf("Failed to read run spool: %v", err))
+	}
+
+	limit := 50
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	runs, err := history.List(lib.RunHistoryFilter{
+		JobKey: r.URL.Query().Get("key"),
+		Code:   r.URL.Query().Get("code"),
+		Limit:  limit,
+		Allow:  runScopeFilter(dashUserFromRequest(r)),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(runs)
+}
+
+// handleRun returns a single recorded run with its output
+func handleRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/runs/")
+	if id == "" {
+		http.Error(w, "Run ID is required", http.StatusBadRequest)
+		return
+	}
+
+	history, err := runHistory()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	run, err := history.Get(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if allow := runScopeFilter(dashUserFromRequest(r)); run == nil || (allow != nil && !allow(*run)) {
+		http.Error(w, "Run not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(run)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+)
+
+func TestKillInstancesEscalatesAfterGracePeriod(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are not supported on windows")
+	}
+	defer viper.Set(varStateDir, "")
+	viper.Set(varStateDir, t.TempDir())
+
+	// A job that ignores TERM, in its own process group like the jobs cron starts
+	job := exec.Command("sh", "-c", `trap "" TERM; sleep 30 & wait`)
+	job.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := job.Start(); err != nil {
+		t.Fatal(err)
+	}
+	go job.Wait()
+	time.Sleep(100 * time.Millisecond)
+
+	body := `{"pids":[` + strconv.Itoa(job.Process.Pid) + `],"grace":"300ms"}`
+	recorder := httptest.NewRecorder()
+	handleKillInstances(recorder, httptest.NewRequest("POST", "/api/jobs/kill", strings.NewReader(body)))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Results []KillResult `json:"results"`
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+	if len(response.Results) != 1 || response.Results[0].Outcome != "killed" || response.Results[0].PGID != job.Process.Pid {
+		t.Errorf("expected the process group to be killed after the grace period, got %+v", response.Results)
+	}
+}
+
+func TestKillInstancesRejectsUnknownSignal(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	handleKillInstances(recorder, httptest.NewRequest("POST", "/api/jobs/kill", strings.NewReader(`{"pids":[12345],"signal":"USR9"}`)))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown signal, got %d", recorder.Code)
+	}
+}
+
+func TestRunsIncludeSpooledExecRuns(t *testing.T) {
+	defer viper.Set(varConfig, "")
+	defer viper.Set(varStateDir, "")
+	viper.Set(varConfig, filepath.Join(t.TempDir(), "cronitor.json"))
+	viper.Set(varStateDir, t.TempDir())
+	defer func() {
+		dashRunHistory.Close()
+		dashRunHistory = nil
+	}()
+
+	recordRun(lib.RunRecord{JobKey: "key-1", Command: "backup.sh", Trigger: lib.RunTriggerDashboard, StartedAt: time.Now().Add(-time.Minute)})
+	spoolRun(lib.RunRecord{Code: "abc123", Command: "report.sh", Trigger: lib.RunTriggerExec, User: lib.CurrentUsername(), PID: 7, StartedAt: time.Now()})
+
+	get := func(user *DashUser, query string) []lib.RunRecord {
+		recorder := httptest.NewRecorder()
+		handleRuns(recorder, withDashUser(httptest.NewRequest("GET", "/api/runs"+query, nil), user))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var runs []lib.RunRecord
+		json.Unmarshal(recorder.Body.Bytes(), &runs)
+		return runs
+	}
+
+	admin := &DashUser{Username: "admin", Role: dashRoleAdmin}
+	if runs := get(admin, ""); len(runs) != 2 || runs[0].Trigger != lib.RunTriggerExec {
+		t.Errorf("expected the exec run from the spool to be listed first, got %+v", runs)
+	}
+	if runs := get(admin, "?key=key-1"); len(runs) != 1 || runs[0].Command != "backup.sh" {
+		t.Errorf("expected runs filtered by job key, got %+v", runs)
+	}
+
+	scoped := &DashUser{Username: "deploy", Role: dashRoleViewer, Crontabs: []string{"/nonexistent/*"}}
+	if runs := get(scoped, ""); len(runs) != 0 {
+		t.Errorf("expected runs outside the account's crontabs to be hidden, got %+v", runs)
+	}
+}
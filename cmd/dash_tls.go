@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var varDashTLSCert = "CRONITOR_DASH_TLS_CERT"
+var varDashTLSKey = "CRONITOR_DASH_TLS_KEY"
+var varDashTLSSelfSigned = "CRONITOR_DASH_TLS_SELF_SIGNED"
+var varDashTLSClientCA = "CRONITOR_DASH_TLS_CLIENT_CA"
+
+// dashTLSEnabled is set when the dashboard is served over HTTPS so cookies can be marked Secure
+var dashTLSEnabled bool
+
+const selfSignedCertFilename = "dash-tls-cert.pem"
+const selfSignedKeyFilename = "dash-tls-key.pem"
+
+// Self-signed certificates are renewed when they are within this window of expiring
+const selfSignedRenewBefore = 30 * 24 * time.Hour
+
+// buildDashTLSConfig returns the TLS configuration for the dashboard, or nil to serve plain HTTP.
+// A certificate and key take precedence over self-signed mode. A client CA bundle enables mTLS.
+func buildDashTLSConfig() (*tls.Config, error) {
+	certFile := viper.GetString(varDashTLSCert)
+	keyFile := viper.GetString(varDashTLSKey)
+	clientCAFile := viper.GetString(varDashTLSClientCA)
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("both --tls-cert and --tls-key are required")
+	}
+
+	if certFile == "" && viper.GetBool(varDashTLSSelfSigned) {
+		var err error
+		if certFile, keyFile, err = ensureSelfSignedCertificate(filepath.Dir(configFilePath())); err != nil {
+			return nil, err
+		}
+	}
+
+	if certFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("client certificate authentication requires TLS; set --tls-cert and --tls-key or use --tls-self-signed")
+		}
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load TLS certificate: %v", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		bundle, err := ioutil.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client CA bundle: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in client CA bundle %s", clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// hasVerifiedClientCertificate reports whether the request came with a client certificate signed by the configured CA
+func hasVerifiedClientCertificate(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
+
+// ensureSelfSignedCertificate returns the paths of a self-signed certificate and key in dir,
+// generating them the first time and again when the certificate is about to expire.
+func ensureSelfSignedCertificate(dir string) (string, string, error) {
+	certFile := filepath.Join(dir, selfSignedCertFilename)
+	keyFile := filepath.Join(dir, selfSignedKeyFilename)
+
+	if certPEM, err := ioutil.ReadFile(certFile); err == nil {
+		if block, _ := pem.Decode(certPEM); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil && time.Until(cert.NotAfter) > selfSignedRenewBefore {
+				if _, err := os.Stat(keyFile); err == nil {
+					return certFile, keyFile, nil
+				}
+			}
+		}
+	}
+
+	certPEM, keyPEM, err := generateSelfSignedCertificate(time.Now())
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("cannot create %s: %v", dir, err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return "", "", fmt.Errorf("cannot write %s; check permissions and try again", keyFile)
+	}
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		return "", "", fmt.Errorf("cannot write %s; check permissions and try again", certFile)
+	}
+
+	return certFile, keyFile, nil
+}
+
+// generateSelfSignedCertificate creates a one year ECDSA certificate for localhost and this machine's hostname
+func generateSelfSignedCertificate(now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Cronitor CLI"}, CommonName: dnsNames[len(dnsNames)-1]},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func resetDashTLSConfig() {
+	for _, key := range []string{varDashTLSCert, varDashTLSKey, varDashTLSSelfSigned, varDashTLSClientCA, varConfig} {
+		viper.Set(key, "")
+	}
+}
+
+func TestSelfSignedCertificateIsPersisted(t *testing.T) {
+	dir := t.TempDir()
+
+	certFile, keyFile, err := ensureSelfSignedCertificate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := ioutil.ReadFile(certFile)
+
+	if info, err := os.Stat(keyFile); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected private key to be written with 0600 permissions")
+	}
+
+	if _, _, err := ensureSelfSignedCertificate(dir); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := ioutil.ReadFile(certFile)
+	if !bytes.Equal(first, second) {
+		t.Error("expected the existing certificate to be reused")
+	}
+
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		t.Errorf("generated certificate and key do not match: %v", err)
+	}
+}
+
+func TestExpiringSelfSignedCertificateIsRenewed(t *testing.T) {
+	dir := t.TempDir()
+	certPEM, keyPEM, err := generateSelfSignedCertificate(time.Now().Add(-360 * 24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(filepath.Join(dir, selfSignedCertFilename), certPEM, 0644)
+	ioutil.WriteFile(filepath.Join(dir, selfSignedKeyFilename), keyPEM, 0600)
+
+	certFile, _, err := ensureSelfSignedCertificate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewed, _ := ioutil.ReadFile(certFile)
+	if bytes.Equal(certPEM, renewed) {
+		t.Error("expected a certificate close to expiry to be replaced")
+	}
+}
+
+func TestBuildDashTLSConfig(t *testing.T) {
+	defer resetDashTLSConfig()
+
+	resetDashTLSConfig()
+	if tlsConfig, err := buildDashTLSConfig(); err != nil || tlsConfig != nil {
+		t.Errorf("expected plain HTTP by default, got %v %v", tlsConfig, err)
+	}
+
+	viper.Set(varDashTLSCert, "/tmp/cert.pem")
+	if _, err := buildDashTLSConfig(); err == nil {
+		t.Error("expected an error when only a certificate is given")
+	}
+
+	resetDashTLSConfig()
+	viper.Set(varDashTLSClientCA, "/tmp/ca.pem")
+	if _, err := buildDashTLSConfig(); err == nil {
+		t.Error("expected an error when mTLS is requested without TLS")
+	}
+}
+
+func TestClientCertificateAuthentication(t *testing.T) {
+	defer resetDashTLSConfig()
+	dir := t.TempDir()
+
+	// A CA that signs the client certificate
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, _ := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	caCert, _ := x509.ParseCertificate(caDER)
+	caFile := filepath.Join(dir, "ca.pem")
+	ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0644)
+
+	clientKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	clientDER, _ := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "operator"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caCert, &clientKey.PublicKey, caKey)
+
+	viper.Set(varConfig, filepath.Join(dir, "cronitor.json"))
+	viper.Set(varDashTLSSelfSigned, true)
+	viper.Set(varDashTLSClientCA, caFile)
+
+	tlsConfig, err := buildDashTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Fatal("expected client certificates to be required")
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasVerifiedClientCertificate(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{clientDER}, PrivateKey: clientKey}},
+	}}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected verified client certificate to be accepted, got %d", resp.StatusCode)
+	}
+
+	anonymous := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	if resp, err := anonymous.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected connection without a client certificate to be rejected")
+	}
+}
@@ -0,0 +1,547 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var varDashUsersFile = "CRONITOR_DASH_USERS_FILE"
+
+const dashUsersFilename = "dash-users.json"
+
+// Dashboard roles, from least to most privileged. Each role includes the permissions of the roles before it.
+const (
+	dashRoleViewer   = "viewer"   // read-only access to jobs, crontabs and monitors
+	dashRoleOperator = "operator" // can also run and kill jobs
+	dashRoleEditor   = "editor"   // can also create, edit and delete jobs and crontabs
+	dashRoleAdmin    = "admin"    // can also change settings, sign up and update the CLI
+)
+
+var dashRoles = []string{dashRoleViewer, dashRoleOperator, dashRoleEditor, dashRoleAdmin}
+
+// DashUser is a dashboard account. Crontabs and RunAs optionally restrict the account to
+// crontab files matching one of the glob patterns and jobs running as one of the listed users.
+type DashUser struct {
+	Username     string   `json:"username"`
+	PasswordHash string   `json:"password_hash,omitempty"`
+	Role         string   `json:"role"`
+	Crontabs     []string `json:"crontabs,omitempty"`
+	RunAs        []string `json:"run_as,omitempty"`
+}
+
+// dashRoleRank returns the privilege level of a role, or -1 for an unknown role
+func dashRoleRank(role string) int {
+	for i, r := range dashRoles {
+		if r == role {
+			return i
+		}
+	}
+	return -1
+}
+
+// Allows reports whether the user's role includes the permissions of the given role.
+// A nil user is an internal caller and is not restricted.
+func (u *DashUser) Allows(role string) bool {
+	if u == nil {
+		return true
+	}
+	return dashRoleRank(u.Role) >= dashRoleRank(role) && dashRoleRank(u.Role) >= 0
+}
+
+// IsScoped reports whether the user is limited to a subset of crontabs or run-as users
+func (u *DashUser) IsScoped() bool {
+	return u != nil && (len(u.Crontabs) > 0 || len(u.RunAs) > 0)
+}
+
+// CanAccessCrontab reports whether the crontab file, e.g. /etc/cron.d/backup or user:deploy, is in scope
+func (u *DashUser) CanAccessCrontab(filename string) bool {
+	if u == nil || len(u.Crontabs) == 0 {
+		return true
+	}
+	for _, pattern := range u.Crontabs {
+		if matched, err := filepath.Match(pattern, filename); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRunAs reports whether jobs running as the given system user are in scope
+func (u *DashUser) CanRunAs(runAsUser string) bool {
+	if u == nil || len(u.RunAs) == 0 {
+		return true
+	}
+	return contains(u.RunAs, runAsUser)
+}
+
+// CanAccessLine reports whether a job in the given crontab is in scope
+func (u *DashUser) CanAccessLine(crontab *lib.Crontab, line *lib.Line) bool {
+	return u.CanAccessCrontab(crontab.Filename) && u.CanRunAs(jobRunAsUser(crontab, line))
+}
+
+// jobRunAsUser is the system user a job runs as: the user field in system crontabs, otherwise the crontab owner
+func jobRunAsUser(crontab *lib.Crontab, line *lib.Line) string {
+	if line.RunAs != "" {
+		return line.RunAs
+	}
+	if crontab.User == "" && strings.HasPrefix(crontab.Filename, "user:") {
+		return strings.TrimPrefix(crontab.Filename, "user:")
+	}
+	return crontab.User
+}
+
+// dashUserStore holds dashboard accounts in a JSON file next to the config file.
+// The file is reloaded when it changes so accounts can be managed while the dashboard is running.
+type dashUserStore struct {
+	path     string
+	mu       sync.Mutex
+	modTime  time.Time
+	Users    []*DashUser `json:"users"`
+	verified map[[32]byte]bool
+}
+
+// dashUsersFilePath returns the location of the user store
+func dashUsersFilePath() string {
+	if path := viper.GetString(varDashUsersFile); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(configFilePath()), dashUsersFilename)
+}
+
+// loadDashUserStore reads the user store at path. A missing file is an empty store.
+func loadDashUserStore(path string) (*dashUserStore, error) {
+	store := &dashUserStore{path: path}
+	if err := store.reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *dashUserStore) reload() error {
+	info, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		s.Users, s.modTime, s.verified = nil, time.Time{}, nil
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	var contents struct {
+		Users []*DashUser `json:"users"`
+	}
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return fmt.Errorf("cannot parse %s: %v", s.path, err)
+	}
+
+	s.Users, s.modTime, s.verified = contents.Users, info.ModTime(), nil
+	return nil
+}
+
+// Save writes the store with owner-only permissions since it contains password hashes
+func (s *dashUserStore) Save() error {
+	sort.Slice(s.Users, func(i, j int) bool { return s.Users[i].Username < s.Users[j].Username })
+
+	data, err := json.MarshalIndent(struct {
+		Users []*DashUser `json:"users"`
+	}{s.Users}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("cannot create %s: %v", filepath.Dir(s.path), err)
+	}
+	if err := ioutil.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("cannot write %s; check permissions and try again", s.path)
+	}
+	return nil
+}
+
+// Find returns the account with the given username, or nil
+func (s *dashUserStore) Find(username string) *DashUser {
+	for _, u := range s.Users {
+		if u.Username == username {
+			return u
+		}
+	}
+	return nil
+}
+
+// Put adds the account or replaces an existing account with the same username
+func (s *dashUserStore) Put(user *DashUser) {
+	for i, u := range s.Users {
+		if u.Username == user.Username {
+			s.Users[i] = user
+			return
+		}
+	}
+	s.Users = append(s.Users, user)
+}
+
+// Remove deletes the account and reports whether it existed
+func (s *dashUserStore) Remove(username string) bool {
+	for i, u := range s.Users {
+		if u.Username == username {
+			s.Users = append(s.Users[:i], s.Users[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate checks a username and password against the store. Successful checks are remembered
+// so the bcrypt comparison is not repeated on every API request.
+func (s *dashUserStore) Authenticate(username, password string) *DashUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		log(fmt.Sprintf("Failed to load dashboard users: %v", err))
+	}
+
+	user := s.Find(username)
+	if user == nil || user.PasswordHash == "" {
+		return nil
+	}
+
+	fingerprint := sha256.Sum256([]byte(user.Username + "\x00" + user.PasswordHash + "\x00" + password))
+	if s.verified[fingerprint] {
+		return user
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil
+	}
+
+	if s.verified == nil {
+		s.verified = make(map[[32]byte]bool)
+	}
+	s.verified[fingerprint] = true
+	return user
+}
+
+// Lookup returns the account for a username without checking a password, used for client certificates.
+// It also reports whether the store has any accounts at all.
+func (s *dashUserStore) Lookup(username string) (*DashUser, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		log(fmt.Sprintf("Failed to load dashboard users: %v", err))
+	}
+	return s.Find(username), len(s.Users) > 0
+}
+
+// authenticateDashRequest resolves the account making a request. The credentials from CRONITOR_DASH_USER and
+// CRONITOR_DASH_PASS are always an admin. A verified client certificate maps to the account named by its common
+// name. It falls back to admin only when there are no accounts, so mTLS-only deployments keep working without a
+// user store; once accounts exist, a certificate for any other name is refused.
+func authenticateDashRequest(r *http.Request, store *dashUserStore) *DashUser {
+	if hasVerifiedClientCertificate(r) {
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		user, hasUsers := store.Lookup(commonName)
+		if user != nil {
+			return user
+		}
+		if hasUsers {
+			return nil
+		}
+		return &DashUser{Username: commonName, Role: dashRoleAdmin}
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil
+	}
+
+	if adminUsername := viper.GetString(varDashUsername); adminUsername != "" && username == adminUsername && password == viper.GetString(varDashPassword) {
+		return &DashUser{Username: username, Role: dashRoleAdmin}
+	}
+
+	return store.Authenticate(username, password)
+}
+
+type dashUserContextKey struct{}
+
+func withDashUser(r *http.Request, user *DashUser) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), dashUserContextKey{}, user))
+}
+
+// dashUserFromRequest returns the authenticated account, or nil for requests that did not pass through authMiddleware
+func dashUserFromRequest(r *http.Request) *DashUser {
+	user, _ := r.Context().Value(dashUserContextKey{}).(*DashUser)
+	return user
+}
+
+// requiredDashRole returns the minimum role for a request to an API route
+func requiredDashRole(path, method string) string {
+	switch path {
+	case "/api/settings":
+		if method == "GET" {
+			return dashRoleViewer
+		}
+		return dashRoleAdmin
+	case "/api/jobs", "/api/crontabs", "/api/crontabs/":
+		if method == "GET" {
+			return dashRoleViewer
+		}
+		return dashRoleEditor
+	case "/api/jobs/run", "/api/jobs/kill":
+		return dashRoleOperator
+	case "/api/signup", "/api/update/perform":
+		return dashRoleAdmin
+	default:
+		return dashRoleViewer
+	}
+}
+
+// authorizeDashRoute rejects requests from accounts whose role is below what the route requires
+func authorizeDashRoute(path string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := dashUserFromRequest(r)
+		if user == nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if role := requiredDashRole(path, r.Method); !user.Allows(role) {
+			http.Error(w, fmt.Sprintf("Forbidden: this action requires the %s role", role), http.StatusForbidden)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// handleSession returns the account the dashboard is signed in as so the UI can hide actions it cannot perform
+func handleSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := dashUserFromRequest(r)
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DashUser{
+		Username: user.Username,
+		Role:     user.Role,
+		Crontabs: user.Crontabs,
+		RunAs:    user.RunAs,
+	})
+}
+
+// redactSecrets removes API keys and passwords from settings shown to non-admin accounts
+func (s *SettingsResponse) redactSecrets() {
+	s.ApiKey = ""
+	s.PingApiAuthKey = ""
+	s.DashPassword = ""
+	redacted := make(map[string]MCPInstanceConfig, len(s.MCPInstances))
+	for name, instance := range s.MCPInstances {
+		instance.Password = ""
+		redacted[name] = instance
+	}
+	s.MCPInstances = redacted
+	s.ConfigFile.MCPInstances = nil
+}
+
+var (
+	dashUserRole     string
+	dashUserCrontabs []string
+	dashUserRunAs    []string
+	dashUserPassword string
+)
+
+var dashUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage dashboard accounts and roles",
+	Long: `Manage the accounts that can sign in to the dashboard.
+
+Roles, from least to most privileged:
+  viewer    View jobs, crontabs and monitors
+  operator  Also run and kill jobs
+  editor    Also create, edit and delete jobs and crontabs
+  admin     Also change settings and update the CLI
+
+Accounts can be limited to crontab files matching a glob pattern with --crontab and to
+jobs that run as specific system users with --run-as. The credentials configured with
+CRONITOR_DASH_USER and CRONITOR_DASH_PASS are always an admin.
+
+Accounts are stored with bcrypt-hashed passwords in dash-users.json next to your config file,
+or the path set in CRONITOR_DASH_USERS_FILE.
+
+Examples:
+  cronitor dash users add alice --role viewer
+  cronitor dash users add deploy --role operator --crontab '/etc/cron.d/app-*' --run-as deploy
+  cronitor dash users list
+  cronitor dash users remove alice`,
+}
+
+var dashUsersAddCmd = &cobra.Command{
+	Use:   "add <username>",
+	Short: "Add an account or update an existing one",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if dashRoleRank(dashUserRole) < 0 {
+			fatal(fmt.Sprintf("Invalid role %q; expected one of %s", dashUserRole, strings.Join(dashRoles, ", ")), 1)
+		}
+		for _, pattern := range dashUserCrontabs {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				fatal(fmt.Sprintf("Invalid crontab pattern %q: %v", pattern, err), 1)
+			}
+		}
+
+		store, err := loadDashUserStore(dashUsersFilePath())
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		password := dashUserPassword
+		if password == "" {
+			prompt := promptui.Prompt{
+				Label: fmt.Sprintf("Password for %s", args[0]),
+				Mask:  '*',
+				Validate: func(input string) error {
+					if len(input) < 8 {
+						return errors.New("password must be at least 8 characters")
+					}
+					return nil
+				},
+			}
+			if password, err = prompt.Run(); err != nil {
+				fatal("Password is required", 1)
+			}
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			fatal(fmt.Sprintf("Failed to hash password: %v", err), 1)
+		}
+
+		store.Put(&DashUser{
+			Username:     args[0],
+			PasswordHash: string(hash),
+			Role:         dashUserRole,
+			Crontabs:     dashUserCrontabs,
+			RunAs:        dashUserRunAs,
+		})
+		if err := store.Save(); err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		Success(fmt.Sprintf("Saved %s with the %s role to %s", args[0], dashUserRole, store.path))
+	},
+}
+
+var dashUsersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List dashboard accounts",
+	Run: func(cmd *cobra.Command, args []string) {
+		store, err := loadDashUserStore(dashUsersFilePath())
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		if len(store.Users) == 0 {
+			fmt.Printf("No accounts in %s\n", store.path)
+			return
+		}
+
+		table := &UITable{Headers: []string{"USERNAME", "ROLE", "CRONTABS", "RUN AS"}}
+		for _, u := range store.Users {
+			table.Rows = append(table.Rows, []string{u.Username, u.Role, scopeText(u.Crontabs), scopeText(u.RunAs)})
+		}
+		fmt.Print(table.Render())
+	},
+}
+
+var dashUsersRemoveCmd = &cobra.Command{
+	Use:   "remove <username>",
+	Short: "Remove a dashboard account",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, err := loadDashUserStore(dashUsersFilePath())
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		if !store.Remove(args[0]) {
+			fatal(fmt.Sprintf("No account named %s", args[0]), 1)
+		}
+		if err := store.Save(); err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		Success(fmt.Sprintf("Removed %s", args[0]))
+	},
+}
+
+func scopeText(values []string) string {
+	if len(values) == 0 {
+		return "all"
+	}
+	return strings.Join(values, ", ")
+}
+
+func init() {
+	dashCmd.AddCommand(dashUsersCmd)
+	dashUsersCmd.AddCommand(dashUsersAddCmd)
+	dashUsersCmd.AddCommand(dashUsersListCmd)
+	dashUsersCmd.AddCommand(dashUsersRemoveCmd)
+
+	dashUsersAddCmd.Flags().StringVar(&dashUserRole, "role", dashRoleViewer, "Role: viewer, operator, editor or admin")
+	dashUsersAddCmd.Flags().StringArrayVar(&dashUserCrontabs, "crontab", nil, "Limit the account to crontabs matching this glob, e.g. '/etc/cron.d/*' or 'user:deploy' (repeatable)")
+	dashUsersAddCmd.Flags().StringArrayVar(&dashUserRunAs, "run-as", nil, "Limit the account to jobs that run as this system user (repeatable)")
+	dashUsersAddCmd.Flags().StringVar(&dashUserPassword, "password", "", "Password for the account; prompted for when omitted")
+}
+
+// instancePIDsInScope returns the PIDs of running instances of jobs the account can access
+func instancePIDsInScope(user *DashUser) map[string]bool {
+	pids := make(map[string]bool)
+	crontabs, err := lib.GetAllCrontabs(parseUsers())
+	if err != nil {
+		return pids
+	}
+
+	registered := registeredInstances()
+	for _, crontab := range crontabs {
+		for _, line := range crontab.Lines {
+			if !line.IsJob || !user.CanAccessLine(crontab, line) {
+				continue
+			}
+			key := line.Key(crontab.CanonicalName())
+			for _, instance := range findJobInstances(registered, line.Code, commandHistory.GetCommands(key, line.CommandToRun)) {
+				pids[instance.PID] = true
+			}
+		}
+	}
+	return pids
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDashRoleHierarchy(t *testing.T) {
+	operator := &DashUser{Username: "ops", Role: dashRoleOperator}
+	if !operator.Allows(dashRoleViewer) || !operator.Allows(dashRoleOperator) || operator.Allows(dashRoleEditor) {
+		t.Error("expected operator to include viewer permissions and nothing above operator")
+	}
+
+	unknown := &DashUser{Username: "typo", Role: "superuser"}
+	if unknown.Allows(dashRoleViewer) {
+		t.Error("expected an unknown role to have no permissions")
+	}
+}
+
+func TestAuthorizeDashRoute(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	tests := []struct {
+		role, method, path string
+		status             int
+	}{
+		{dashRoleViewer, "GET", "/api/jobs", http.StatusOK},
+		{dashRoleViewer, "PUT", "/api/jobs", http.StatusForbidden},
+		{dashRoleViewer, "POST", "/api/jobs/run", http.StatusForbidden},
+		{dashRoleOperator, "POST", "/api/jobs/kill", http.StatusOK},
+		{dashRoleOperator, "PUT", "/api/crontabs/", http.StatusForbidden},
+		{dashRoleEditor, "PUT", "/api/crontabs/", http.StatusOK},
+		{dashRoleEditor, "POST", "/api/settings", http.StatusForbidden},
+		{dashRoleEditor, "GET", "/api/settings", http.StatusOK},
+		{dashRoleEditor, "POST", "/api/update/perform", http.StatusForbidden},
+		{dashRoleAdmin, "POST", "/api/update/perform", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		request := withDashUser(httptest.NewRequest(test.method, test.path, nil), &DashUser{Username: "u", Role: test.role})
+		authorizeDashRoute(test.path, ok)(recorder, request)
+		if recorder.Code != test.status {
+			t.Errorf("%s %s %s: expected %d, got %d", test.role, test.method, test.path, test.status, recorder.Code)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	authorizeDashRoute("/api/jobs", ok)(recorder, httptest.NewRequest("GET", "/api/jobs", nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected requests without an account to be rejected, got %d", recorder.Code)
+	}
+}
+
+func TestDashUserStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), dashUsersFilename)
+	store, err := loadDashUserStore(path)
+	if err != nil || len(store.Users) != 0 {
+		t.Fatalf("expected a missing file to be an empty store, got %v %v", store, err)
+	}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	store.Put(&DashUser{Username: "alice", PasswordHash: string(hash), Role: dashRoleViewer})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Error("expected the user store to be written with 0600 permissions")
+	}
+
+	store, _ = loadDashUserStore(path)
+	if user := store.Authenticate("alice", "correct horse"); user == nil || user.Role != dashRoleViewer {
+		t.Errorf("expected alice to authenticate as a viewer, got %+v", user)
+	}
+	if store.Authenticate("alice", "wrong") != nil || store.Authenticate("bob", "correct horse") != nil {
+		t.Error("expected wrong credentials to be rejected")
+	}
+
+	// Changes made by `cronitor dash users` are picked up by a running dashboard
+	other, _ := loadDashUserStore(path)
+	other.Remove("alice")
+	other.Save()
+	os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if store.Authenticate("alice", "correct horse") != nil {
+		t.Error("expected a removed account to be rejected after the file changed")
+	}
+}
+
+func TestAuthenticateDashRequestWithConfiguredCredentials(t *testing.T) {
+	defer viper.Set(varDashUsername, "")
+	defer viper.Set(varDashPassword, "")
+	viper.Set(varDashUsername, "admin")
+	viper.Set(varDashPassword, "secret")
+
+	store, _ := loadDashUserStore(filepath.Join(t.TempDir(), dashUsersFilename))
+
+	request := httptest.NewRequest("GET", "/api/jobs", nil)
+	request.SetBasicAuth("admin", "secret")
+	if user := authenticateDashRequest(request, store); user == nil || user.Role != dashRoleAdmin {
+		t.Errorf("expected the configured credentials to be an admin, got %+v", user)
+	}
+
+	request.SetBasicAuth("admin", "guess")
+	if user := authenticateDashRequest(request, store); user != nil {
+		t.Errorf("expected a wrong password to be rejected, got %+v", user)
+	}
+}
+
+func TestAuthenticateDashRequestWithClientCertificate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), dashUsersFilename)
+	store, _ := loadDashUserStore(path)
+
+	request := httptest.NewRequest("GET", "/api/jobs", nil)
+	request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "build-agent"}}}}}
+	if user := authenticateDashRequest(request, store); user == nil || user.Role != dashRoleAdmin {
+		t.Errorf("expected a verified certificate to be an admin without a user store, got %+v", user)
+	}
+
+	store.Put(&DashUser{Username: "deploy", Role: dashRoleViewer})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if user := authenticateDashRequest(request, store); user != nil {
+		t.Errorf("expected a certificate for an unknown account to be refused once accounts exist, got %+v", user)
+	}
+
+	request.TLS.VerifiedChains[0][0].Subject.CommonName = "deploy"
+	if user := authenticateDashRequest(request, store); user == nil || user.Role != dashRoleViewer {
+		t.Errorf("expected the certificate to sign in as its account, got %+v", user)
+	}
+}
+
+func TestDashUserScopes(t *testing.T) {
+	user := &DashUser{Username: "deploy", Role: dashRoleOperator, Crontabs: []string{"/etc/cron.d/app-*", "user:deploy"}, RunAs: []string{"deploy"}}
+
+	appCrontab := &lib.Crontab{Filename: "/etc/cron.d/app-web", User: "root"}
+	userCrontab := &lib.Crontab{Filename: "user:deploy", User: "deploy", IsUserCrontab: true}
+	systemCrontab := &lib.Crontab{Filename: "/etc/crontab", User: "root"}
+
+	tests := []struct {
+		crontab *lib.Crontab
+		line    *lib.Line
+		allowed bool
+	}{
+		{appCrontab, &lib.Line{IsJob: true, RunAs: "deploy"}, true},
+		{appCrontab, &lib.Line{IsJob: true, RunAs: "root"}, false},
+		{userCrontab, &lib.Line{IsJob: true}, true},
+		{systemCrontab, &lib.Line{IsJob: true, RunAs: "deploy"}, false},
+	}
+
+	for _, test := range tests {
+		if allowed := user.CanAccessLine(test.crontab, test.line); allowed != test.allowed {
+			t.Errorf("CanAccessLine(%s, run as %q) = %v; want %v", test.crontab.Filename, test.line.RunAs, allowed, test.allowed)
+		}
+	}
+
+	var unrestricted *DashUser
+	if !unrestricted.CanAccessLine(systemCrontab, &lib.Line{IsJob: true, RunAs: "root"}) || (&DashUser{Role: dashRoleAdmin}).IsScoped() {
+		t.Error("expected accounts without scopes to access every job")
+	}
+}
+
+func TestSettingsRedactedForNonAdmins(t *testing.T) {
+	response := SettingsResponse{
+		ConfigFile: ConfigFile{ApiKey: "api-key", PingApiAuthKey: "ping-key", DashPassword: "secret"},
+		MCPInstances: map[string]MCPInstanceConfig{
+			"web1": {URL: "https://web1:9000", Username: "admin", Password: "secret"},
+		},
+	}
+	response.redactSecrets()
+
+	if response.ApiKey != "" || response.PingApiAuthKey != "" || response.DashPassword != "" || response.MCPInstances["web1"].Password != "" {
+		t.Errorf("expected secrets to be removed, got %+v", response)
+	}
+	if response.MCPInstances["web1"].URL == "" {
+		t.Error("expected non-secret instance details to be kept")
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devServerListen string
+	devServerState  string
+	devServerAPIKey string
+)
+
+var devServerCmd = &cobra.Command{
+	Use:   "devserver",
+	Short: "Run a local stand-in for Cronitor to develop and test against",
+	Long: `Run a local server that receives what the CLI sends to Cronitor, so crontab integrations and
+'cronitor exec' can be tested without a Cronitor account, for example in CI.
+
+The server implements:
+  /ping/<api key>/<key>?state=...   authenticated telemetry pings
+  /<code>/<state>                   unauthenticated telemetry pings
+  /api/monitors                     list, create, update and delete monitors, as JSON or YAML
+  /api/logs/presign                 log uploads from 'cronitor exec', stored instead of sent to S3
+
+Pings for monitors that do not exist yet create them. Point the CLI at the server by setting CRONITOR_URL,
+which applies to pings, API requests and log uploads alike. Any API key is accepted unless --api-key is set.
+
+What was received is shown at the server's address, and is served as JSON from /_devserver/state.
+Send DELETE to /_devserver/state to clear it. With --state it is also saved to a file and survives restarts.
+
+Examples:
+  cronitor devserver
+  CRONITOR_URL=http://localhost:8000 CRONITOR_API_KEY=test cronitor exec backup ./backup.sh
+  cronitor devserver --listen 127.0.0.1:9100 --state ./cronitor-state.json
+  curl -s http://localhost:8000/_devserver/state`,
+	Run: func(cmd *cobra.Command, args []string) {
+		server, err := lib.NewDevServer(devServerState)
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+		server.APIKey = devServerAPIKey
+		server.Logger = func(line string) {
+			fmt.Printf("%s %s\n", time.Now().Format("15:04:05"), line)
+		}
+
+		listener, err := net.Listen("tcp", devServerListen)
+		if err != nil {
+			Error(fmt.Sprintf("Cannot listen on %s: %v", devServerListen, err))
+			os.Exit(1)
+		}
+
+		url := "http://" + listener.Addr().String()
+		httpServer := &http.Server{Handler: server, ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second}
+		go func() {
+			if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+				fatal(err.Error(), 1)
+			}
+		}()
+
+		fmt.Printf("Cronitor dev server running on %s (Press Ctrl+C to stop)\n\n", url)
+		fmt.Printf("Send the CLI's pings, API requests and logs here with:\n  export CRONITOR_URL=%s\n", url)
+		if devServerAPIKey == "" {
+			fmt.Printf("  export CRONITOR_API_KEY=test\n")
+		}
+		fmt.Println()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		<-ctx.Done()
+
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdown)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(devServerCmd)
+	devServerCmd.Flags().StringVar(&devServerListen, "listen", "localhost:8000", "Address to listen on")
+	devServerCmd.Flags().StringVar(&devServerState, "state", "", "Save state to this JSON file and load it on start")
+	devServerCmd.Flags().StringVar(&devServerAPIKey, "api-key", "", "Only accept this API key")
+}
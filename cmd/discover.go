@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/user"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -24,7 +23,7 @@ import (
 // getJobLabel returns the appropriate term for a job based on the platform
 // Returns "scheduled task" on Windows, "cron job" on other platforms
 func getJobLabel() string {
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" && remoteHost == "" {
 		return "scheduled task"
 	}
 	return "cron job"
@@ -135,6 +134,9 @@ Example:
   $ cronitor sync /path/to/crontab
       > Instead of the user crontab, provide a crontab file (or directory of crontabs) to use
 
+  $ cronitor sync --host deploy@web1
+      > Sync the crontabs on another server over SSH, using your ssh-agent and known_hosts
+
 Example that does not use an interactive shell:
   $ cronitor sync --auto
       > The only output to stdout will be your updated crontab file, suitable for piplines or writing to another crontab.
@@ -183,17 +185,15 @@ Example where you perform a dry-run without any crontab modifications:
 			return
 		}
 
-		var username string
-		if u, err := user.Current(); err == nil {
-			username = u.Username
-		}
+		connectRemoteHost()
+		username := lib.CurrentUsername()
 
 		printSuccessText(fmt.Sprintf("Scanning for %ss...", getJobLabel()), false)
 
 		// Fetch list of existing monitor names for easy unique name validation and prompt prefill later on
 		existingMonitors.Monitors, _ = getCronitorApi().GetMonitors()
 
-		if runtime.GOOS == "windows" {
+		if runtime.GOOS == "windows" && remoteHost == "" {
 			if processWindowsTaskScheduler() {
 				importedCrontabs++
 			}
@@ -538,6 +538,8 @@ func processCrontab(crontab *lib.Crontab) bool {
 		if updatedMonitor, exists := monitors[key]; exists {
 			line.Mon = *updatedMonitor
 			line.Code = updatedMonitor.Attributes.Code
+			// Pin the profile so the job reports to the account it was discovered with
+			line.Profile = activeProfile()
 			// Ensure the line name is set so it gets written as a comment
 			if updatedMonitor.Name != "" {
 				line.Name = updatedMonitor.Name
@@ -871,6 +873,7 @@ func init() {
 	discoverCmd.Flags().StringVar(&notificationList, "notification-list", notificationList, "Use the provided notification list when creating or updating monitors, or \"default\" list if omitted.")
 	discoverCmd.Flags().BoolVar(&isAutoDiscover, "auto", isAutoDiscover, "Do not use an interactive shell. Write updated crontab to stdout.")
 	discoverCmd.Flags().StringVar(&syncFile, "file", "", "Path to YAML or JSON file containing monitor definitions for bulk import")
+	addRemoteHostFlags(discoverCmd)
 
 	discoverCmd.Flags().BoolVar(&isSilent, "silent", isSilent, "")
 	discoverCmd.Flags().MarkHidden("silent")
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -52,8 +53,9 @@ var (
 func init() {
 	RootCmd.AddCommand(environmentCmd)
 	environmentCmd.PersistentFlags().IntVar(&environmentPage, "page", 1, "Page number")
-	environmentCmd.PersistentFlags().StringVar(&environmentFormat, "format", "", "Output format: json, table")
+	environmentCmd.PersistentFlags().StringVar(&environmentFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	environmentCmd.PersistentFlags().StringVarP(&environmentOutput, "output", "o", "", "Write output to file")
+	addListAllFlags(environmentListCmd)
 }
 
 // --- LIST ---
@@ -66,65 +68,49 @@ Examples:
   cronitor environment list
   cronitor environment list --format json`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-		if environmentPage > 1 {
-			params["page"] = fmt.Sprintf("%d", environmentPage)
-		}
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.ListOptions{Page: environmentPage, Concurrency: listConcurrency}
 
-		resp, err := client.GET("/environments", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to list environments: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		var result struct {
-			Environments []struct {
-				Key            string `json:"key"`
-				Name           string `json:"name"`
-				WithAlerts     bool   `json:"with_alerts"`
-				Default        bool   `json:"default"`
-				ActiveMonitors int    `json:"active_monitors"`
-			} `json:"environments"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
-
-		format := environmentFormat
-		if format == "" {
-			format = "table"
-		}
+		format := outputFormat(environmentFormat, "table")
 
-		if format == "json" {
-			environmentOutputToTarget(FormatJSON(resp.Body))
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.Environments.List, client.Environments.All), format, environmentOutput, environmentColumns)
 			return
 		}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "ALERTS", "MONITORS", "DEFAULT"},
+		page, err := client.Environments.List(cmd.Context(), opts)
+		if err != nil {
+			exitOnAPIError(err, "list environments", "")
 		}
 
-		for _, e := range result.Environments {
-			alerts := mutedStyle.Render("off")
-			if e.WithAlerts {
-				alerts = successStyle.Render("on")
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: environmentColumns.headers,
 			}
-			isDefault := ""
-			if e.Default {
-				isDefault = "yes"
+
+			for _, e := range page.Items {
+				table.Rows = append(table.Rows, environmentColumns.row(e))
 			}
-			monitors := fmt.Sprintf("%d", e.ActiveMonitors)
-			table.Rows = append(table.Rows, []string{e.Name, e.Key, alerts, monitors, isDefault})
-		}
 
-		environmentOutputToTarget(table.Render())
+			return table.Render()
+		}).print(format, environmentOutput)
+	},
+}
+
+var environmentColumns = listColumns[api.Environment]{
+	noun:    "environments",
+	headers: []string{"NAME", "KEY", "ALERTS", "MONITORS", "DEFAULT"},
+	row: func(e api.Environment) []string {
+		alerts := mutedStyle.Render("off")
+		if e.WithAlerts {
+			alerts = successStyle.Render("on")
+		}
+		isDefault := ""
+		if e.Default {
+			isDefault = "yes"
+		}
+		monitors := fmt.Sprintf("%d", e.ActiveMonitors)
+		return []string{e.Name, e.Key, alerts, monitors, isDefault}
 	},
 }
 
@@ -135,25 +121,14 @@ var environmentGetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.GET(fmt.Sprintf("/environments/%s", key), nil)
+		environment, err := client.Environments.Get(cmd.Context(), key)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get environment: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Environment '%s' not found", key))
-			os.Exit(1)
+			exitOnAPIError(err, "get environment", fmt.Sprintf("Environment '%s' not found", key))
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		environmentOutputToTarget(FormatJSON(resp.Body))
+		output{raw: environment.Raw()}.print(outputFormat(environmentFormat, "json"), environmentOutput)
 	},
 }
 
@@ -172,40 +147,27 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(environmentData), &js); err != nil {
+		var environment api.Environment
+		if err := json.Unmarshal([]byte(environmentData), &environment); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/environments", []byte(environmentData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.Environments.Create(cmd.Context(), &environment)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create environment: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "create environment", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Created environment: %s (key: %s)", created.Name, created.Key))
 
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created environment: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Environment created")
-		}
-
-		if environmentFormat == "json" {
-			environmentOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(environmentFormat, ""); format != "" {
+			output{raw: created.Raw()}.print(format, environmentOutput)
 		}
 	},
 }
 
+
 // --- UPDATE ---
 var environmentUpdateCmd = &cobra.Command{
 	Use:   "update <key>",
@@ -225,29 +187,21 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(environmentData), &bodyMap); err != nil {
+		var environment api.Environment
+		if err := json.Unmarshal([]byte(environmentData), &environment); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/environments/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Environments.Update(cmd.Context(), key, &environment)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update environment: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update environment", "")
 		}
 
 		Success(fmt.Sprintf("Environment '%s' updated", key))
-		if environmentFormat == "json" {
-			environmentOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(environmentFormat, ""); format != "" {
+			output{raw: updated.Raw()}.print(format, environmentOutput)
 		}
 	},
 }
@@ -259,25 +213,13 @@ var environmentDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		resp, err := client.DELETE(fmt.Sprintf("/environments/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete environment: %s", err))
-			os.Exit(1)
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Environment '%s' not found", key))
-			os.Exit(1)
+		if err := client.Environments.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete environment", fmt.Sprintf("Environment '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Environment '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Environment '%s' deleted", key))
 	},
 }
 
@@ -294,15 +236,3 @@ func init() {
 	// Update flags
 	environmentUpdateCmd.Flags().StringVarP(&environmentData, "data", "d", "", "JSON payload")
 }
-
-func environmentOutputToTarget(content string) {
-	if environmentOutput != "" {
-		if err := os.WriteFile(environmentOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", environmentOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", environmentOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
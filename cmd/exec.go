@@ -160,6 +160,7 @@ func RunCommand(subcommand string, withEnvironment bool, withMonitoring bool) in
 
 	// Invoke subcommand and send a message when it's done
 	waitCh := make(chan error, 16)
+	var registration *lib.InstanceRegistration
 	go func() {
 		defer close(waitCh)
 
@@ -169,6 +170,9 @@ func RunCommand(subcommand string, withEnvironment bool, withMonitoring bool) in
 		if err := execCmd.Start(); err != nil {
 			waitCh <- err
 		} else {
+			if withMonitoring {
+				registration = registerInstance(execCmd.Process.Pid, subcommand, series)
+			}
 			waitCh <- execCmd.Wait()
 		}
 	}()
@@ -197,6 +201,13 @@ func RunCommand(subcommand string, withEnvironment bool, withMonitoring bool) in
 			signal.Stop(sigChan)
 			close(sigChan)
 
+			// A run killed from the dashboard has already been reported as failed by the dashboard
+			killed := false
+			if registration != nil {
+				killed = registration.WasKilled()
+				registration.Remove()
+			}
+
 			// Send output to Cronitor and clean up after the temp file
 			outputForPing := gatherOutput(tempFile, true)
 			var metrics map[string]int = nil
@@ -222,8 +233,10 @@ func RunCommand(subcommand string, withEnvironment bool, withMonitoring bool) in
 
 			if err == nil {
 				if withMonitoring {
-					monitoringWaitGroup.Add(1)
-					go sendPing("complete", monitorCode, string(outputForPing), series, endTime, &duration, &exitCode, metrics, schedule, &monitoringWaitGroup)
+					if !killed {
+						monitoringWaitGroup.Add(1)
+						go sendPing("complete", monitorCode, string(outputForPing), series, endTime, &duration, &exitCode, metrics, schedule, &monitoringWaitGroup)
+					}
 					if tempFile != nil {
 						monitoringWaitGroup.Add(1)
 						go shipLogData(tempFile, series, &monitoringWaitGroup)
@@ -246,8 +259,10 @@ func RunCommand(subcommand string, withEnvironment bool, withMonitoring bool) in
 				}
 
 				if withMonitoring {
-					monitoringWaitGroup.Add(1)
-					go sendPing("fail", monitorCode, message, series, endTime, &duration, &exitCode, metrics, schedule, &monitoringWaitGroup)
+					if !killed {
+						monitoringWaitGroup.Add(1)
+						go sendPing("fail", monitorCode, message, series, endTime, &duration, &exitCode, metrics, schedule, &monitoringWaitGroup)
+					}
 					if tempFile != nil {
 						monitoringWaitGroup.Add(1)
 						go shipLogData(tempFile, series, &monitoringWaitGroup)
@@ -255,6 +270,10 @@ func RunCommand(subcommand string, withEnvironment bool, withMonitoring bool) in
 				}
 			}
 
+			if withMonitoring {
+				spoolExecRun(registration, subcommand, startTime, endTime, exitCode, tempFile)
+			}
+
 			monitoringWaitGroup.Wait()
 			return exitCode
 		}
@@ -267,6 +286,64 @@ func init() {
 	execCmd.Flags().BoolVar(&noStdoutPassthru, "no-stdout", noStdoutPassthru, "Do not send cron job output to Cronitor when your job completes")
 }
 
+// stateDir returns the configured state directory, where running jobs, finished runs and last runs are kept
+func stateDir() string {
+	if dir := viper.GetString(varStateDir); dir != "" {
+		return dir
+	}
+	return lib.DefaultStateDir()
+}
+
+// instanceRegistry returns the registry of running jobs in the state directory
+func instanceRegistry() *lib.InstanceRegistry {
+	return lib.NewInstanceRegistry(filepath.Join(stateDir(), "instances"))
+}
+
+// registerInstance records the run so the dashboard and `cronitor ps` can find it. Instances are matched to
+// crontab lines by monitor code when they are read, so a run never has to parse crontabs.
+func registerInstance(pid int, subcommand string, series string) *lib.InstanceRegistration {
+	registration, err := instanceRegistry().Register(lib.RunningInstance{
+		PID:     pid,
+		PGID:    lib.ProcessGroupID(pid),
+		ExecPID: os.Getpid(),
+		Code:    monitorCode,
+		Series:  series,
+		Command: subcommand,
+		User:    lib.CurrentUsername(),
+		Started: time.Now(),
+	})
+	if err != nil {
+		log(err.Error())
+		return nil
+	}
+
+	return registration
+}
+
+// spoolExecRun hands the finished run to the dashboard's run history
+func spoolExecRun(registration *lib.InstanceRegistration, subcommand string, startTime float64, endTime float64, exitCode int, tempFile *os.File) {
+	run := lib.RunRecord{
+		Code:      monitorCode,
+		Command:   subcommand,
+		Trigger:   lib.RunTriggerExec,
+		User:      lib.CurrentUsername(),
+		StartedAt: stampToTime(startTime),
+		EndedAt:   stampToTime(endTime),
+		ExitCode:  exitCode,
+	}
+	if registration != nil {
+		run.PID = registration.Instance().PID
+	}
+	if !noStdoutPassthru {
+		run.Output = readOutputTail(tempFile)
+	}
+	spoolRun(run)
+
+	if err := lib.RecordLastRun(lastRunDir(), run); err != nil {
+		log(err.Error())
+	}
+}
+
 func makeCronLikeEnv() []string {
 	env := []string{"SHELL=/bin/sh"}
 	if homeValue, hasHome := os.LookupEnv("HOME"); hasHome {
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+)
+
+func TestRegisteredRunsAreFoundByMonitorCode(t *testing.T) {
+	defer viper.Set(varStateDir, "")
+	viper.Set(varStateDir, t.TempDir())
+
+	previousCode := monitorCode
+	defer func() { monitorCode = previousCode }()
+	monitorCode = "abc123"
+
+	registration := registerInstance(os.Getpid(), "/usr/bin/backup", "1700000000.000")
+	if registration == nil {
+		t.Fatal("expected the run to be registered")
+	}
+	defer registration.Remove()
+
+	registered := registeredInstances()
+	instances := findJobInstances(registered, "abc123", []string{"/usr/bin/backup"})
+	if len(instances) != 1 || instances[0].PID != strconv.Itoa(lib.ProcessGroupID(os.Getpid())) {
+		t.Fatalf("expected the registered run to be reported by process group, got %+v", instances)
+	}
+
+	if instances := findJobInstances(registered, "zzz999", nil); len(instances) != 0 {
+		t.Errorf("expected no instances for a different monitor, got %+v", instances)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 )
 
@@ -45,7 +47,7 @@ func init() {
 	// Persistent flags for all group subcommands
 	groupCmd.PersistentFlags().IntVar(&groupPage, "page", 1, "Page number for paginated results")
 	groupCmd.PersistentFlags().StringVar(&groupEnv, "env", "", "Filter by environment")
-	groupCmd.PersistentFlags().StringVar(&groupFormat, "format", "", "Output format: json, table")
+	groupCmd.PersistentFlags().StringVar(&groupFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	groupCmd.PersistentFlags().StringVarP(&groupOutput, "output", "o", "", "Write output to file")
 }
 
@@ -62,79 +64,55 @@ Examples:
   cronitor group list --with-status
   cronitor group list --env production`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if groupPage > 1 {
-			params["page"] = fmt.Sprintf("%d", groupPage)
-		}
-		if groupPageSize > 0 {
-			params["pageSize"] = fmt.Sprintf("%d", groupPageSize)
-		}
-		if groupEnv != "" {
-			params["env"] = groupEnv
-		}
-		if groupWithStatus {
-			params["withStatus"] = "true"
-		}
-
-		resp, err := client.GET("/groups", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to list groups: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.GroupListOptions{
+			ListOptions: api.ListOptions{Page: groupPage, PageSize: groupPageSize, Concurrency: listConcurrency},
+			Env:         groupEnv,
+			WithStatus:  groupWithStatus,
 		}
 
-		if groupFormat == "json" || groupFormat == "" {
-			outputGroupToTarget(FormatJSON(resp.Body))
+		format := outputFormat(groupFormat, "json")
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.Groups.List, client.Groups.All), format, groupOutput, groupColumns)
 			return
 		}
 
-		// Parse for table output
-		var result struct {
-			Groups []struct {
-				Key      string   `json:"key"`
-				Name     string   `json:"name"`
-				Monitors []string `json:"monitors"`
-				Created  string   `json:"created"`
-			} `json:"groups"`
-			PageSize   int `json:"page_size"`
-			Page       int `json:"page"`
-			TotalCount int `json:"total_count"`
+		page, err := client.Groups.List(cmd.Context(), opts)
+		if err != nil {
+			exitOnAPIError(err, "list groups", "")
 		}
 
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
+		pageOutput(page, func() string {
+			if len(page.Items) == 0 {
+				return mutedStyle.Render("No groups found")
+			}
 
-		if len(result.Groups) == 0 {
-			outputGroupToTarget(mutedStyle.Render("No groups found"))
-			return
-		}
+			table := &UITable{
+				Headers: groupColumns.headers,
+			}
+			for _, g := range page.Items {
+				table.Rows = append(table.Rows, groupColumns.row(g))
+			}
+			output := table.Render()
 
-		// Table output
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "MONITORS", "CREATED"},
-		}
-		for _, g := range result.Groups {
-			monitorCount := fmt.Sprintf("%d", len(g.Monitors))
-			created := ""
-			if g.Created != "" {
-				created = g.Created[:10] // Just the date part
+			if page.Total > page.PageSize {
+				output += fmt.Sprintf("\n\nPage %d of %d (total: %d groups)",
+					page.Page, (page.Total+page.PageSize-1)/page.PageSize, page.Total)
 			}
-			table.Rows = append(table.Rows, []string{g.Name, g.Key, monitorCount, created})
-		}
-		outputGroupToTarget(table.Render())
+			return output
+		}).print(format, groupOutput)
+	},
+}
 
-		if result.TotalCount > result.PageSize {
-			fmt.Printf("\nPage %d of %d (total: %d groups)\n",
-				result.Page, (result.TotalCount+result.PageSize-1)/result.PageSize, result.TotalCount)
+var groupColumns = listColumns[api.Group]{
+	noun:    "groups",
+	headers: []string{"NAME", "KEY", "MONITORS", "CREATED"},
+	row: func(g api.Group) []string {
+		created := ""
+		if g.Created != "" {
+			created = g.Created[:10] // Just the date part
 		}
+		return []string{g.Name, g.Key, fmt.Sprintf("%d", len(g.Monitors)), created}
 	},
 }
 
@@ -151,64 +129,28 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if groupEnv != "" {
-			params["env"] = groupEnv
-		}
-		if groupWithStatus {
-			params["withStatus"] = "true"
-		}
-		if groupSort != "" {
-			params["sort"] = groupSort
-		}
-
-		resp, err := client.GET(fmt.Sprintf("/groups/%s", key), params)
+		client := lib.NewCronitorClient(dev, log)
+		group, err := client.Groups.Get(cmd.Context(), key, &api.GroupGetOptions{
+			Env:        groupEnv,
+			WithStatus: groupWithStatus,
+			Sort:       groupSort,
+		})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get group: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Group '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "get group", fmt.Sprintf("Group '%s' not found", key))
 		}
 
-		if groupFormat == "json" || groupFormat == "" {
-			outputGroupToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(groupFormat, "json"); format != "table" || outputFiltered() {
+			output{raw: group.Raw()}.print(format, groupOutput)
 			return
 		}
 
-		// Parse for detailed output
-		var group struct {
-			Key         string   `json:"key"`
-			Name        string   `json:"name"`
-			Monitors    []string `json:"monitors"`
-			Created     string   `json:"created"`
-			LatestEvent struct {
-				Stamp string `json:"stamp"`
-				State string `json:"state"`
-			} `json:"latest_event"`
-		}
-
-		if err := json.Unmarshal(resp.Body, &group); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
-
 		// Table output for single group
 		fmt.Printf("Group: %s\n", boldStyle.Render(group.Name))
 		fmt.Printf("Key: %s\n", group.Key)
 		if group.Created != "" {
 			fmt.Printf("Created: %s\n", group.Created)
 		}
-		if group.LatestEvent.Stamp != "" {
+		if group.LatestEvent != nil && group.LatestEvent.Stamp != "" {
 			fmt.Printf("Latest Event: %s (%s)\n", group.LatestEvent.Stamp, group.LatestEvent.State)
 		}
 		if len(group.Monitors) > 0 {
@@ -235,36 +177,22 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(groupData), &js); err != nil {
+		var group api.Group
+		if err := json.Unmarshal([]byte(groupData), &group); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/groups", []byte(groupData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.Groups.Create(cmd.Context(), &group)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create group: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "create group", "")
 		}
 
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created group: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Group created successfully")
-		}
+		Success(fmt.Sprintf("Created group: %s (key: %s)", created.Name, created.Key))
 
-		if groupFormat == "json" {
-			outputGroupToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(groupFormat, ""); format != "" {
+			output{raw: created.Raw()}.print(format, groupOutput)
 		}
 	},
 }
@@ -287,30 +215,22 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(groupData), &bodyMap); err != nil {
+		var group api.Group
+		if err := json.Unmarshal([]byte(groupData), &group); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/groups/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Groups.Update(cmd.Context(), key, &group)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update group: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update group", "")
 		}
 
 		Success(fmt.Sprintf("Updated group: %s", key))
 
-		if groupFormat == "json" {
-			outputGroupToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(groupFormat, ""); format != "" {
+			output{raw: updated.Raw()}.print(format, groupOutput)
 		}
 	},
 }
@@ -326,22 +246,10 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.DELETE(fmt.Sprintf("/groups/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete group: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Group '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		if err := client.Groups.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete group", fmt.Sprintf("Group '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Deleted group: %s", key))
@@ -361,21 +269,18 @@ Examples:
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		hours := args[1]
-		client := lib.NewAPIClient(dev, log)
-
-		resp, err := client.GET(fmt.Sprintf("/groups/%s/pause/%s", key, hours), nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to pause group: %s", err))
+		hours, err := strconv.Atoi(args[1])
+		if err != nil || hours < 1 {
+			Error(fmt.Sprintf("Invalid hours '%s': expected a whole number of hours", args[1]))
 			os.Exit(1)
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		client := lib.NewCronitorClient(dev, log)
+		if err := client.Groups.Pause(cmd.Context(), key, hours); err != nil {
+			exitOnAPIError(err, "pause group", "")
 		}
 
-		Success(fmt.Sprintf("Paused all monitors in group '%s' for %s hours", key, hours))
+		Success(fmt.Sprintf("Paused all monitors in group '%s' for %d hours", key, hours))
 	},
 }
 
@@ -390,18 +295,10 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		// Resume is just pause with 0 hours
-		resp, err := client.GET(fmt.Sprintf("/groups/%s/pause/0", key), nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to resume group: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		if err := client.Groups.Resume(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "resume group", "")
 		}
 
 		Success(fmt.Sprintf("Resumed all monitors in group '%s'", key))
@@ -412,6 +309,7 @@ func init() {
 	// List command flags
 	groupListCmd.Flags().IntVar(&groupPageSize, "page-size", 0, "Number of results per page")
 	groupListCmd.Flags().BoolVar(&groupWithStatus, "with-status", false, "Include status information")
+	addListAllFlags(groupListCmd)
 	// Get command flags
 	groupGetCmd.Flags().BoolVar(&groupWithStatus, "with-status", false, "Include status information")
 	groupGetCmd.Flags().StringVar(&groupSort, "sort", "", "Sort order for monitors")
@@ -422,15 +320,3 @@ func init() {
 	// Update command flags
 	groupUpdateCmd.Flags().StringVarP(&groupData, "data", "d", "", "JSON payload")
 }
-
-func outputGroupToTarget(content string) {
-	if groupOutput != "" {
-		if err := os.WriteFile(groupOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to file: %s", err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", groupOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
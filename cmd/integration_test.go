@@ -350,3 +350,138 @@ func TestIntegration_MonitorExport(t *testing.T) {
 		t.Error("expected export output to contain 'mon-2'")
 	}
 }
+
+// --- Step 13: Fetch every page ---
+
+func TestIntegration_MonitorList_AllAsJSONLines(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"monitors":[{"key":"mon-1","name":"Monitor 1"},{"key":"mon-2","name":"Monitor 2"}],"page_info":{"page":1,"pageSize":2,"totalMonitorCount":3}}`,
+		"2": `{"monitors":[{"key":"mon-3","name":"Monitor 3"}],"page_info":{"page":2,"pageSize":2,"totalMonitorCount":3}}`,
+	}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, pages[page])
+	}))
+	defer server.Close()
+
+	cleanup := setupIntegrationTest(server.URL)
+	defer cleanup()
+	defer func() { listAll = false }()
+
+	monitorFormat = ""
+	monitorOutput = ""
+	monitorPage = 1
+
+	output, err := executeCmd("monitor", "list", "--all", "--format", "jsonl")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected one line per monitor, got %d:\n%s", len(lines), output)
+	}
+	for i, line := range lines {
+		var monitor map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &monitor); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i+1, line)
+		}
+		if monitor["key"] != fmt.Sprintf("mon-%d", i+1) {
+			t.Errorf("line %d: expected mon-%d, got %v", i+1, i+1, monitor["key"])
+		}
+	}
+	if strings.Join(requested, ",") != "1,2" {
+		t.Errorf("expected pages 1 and 2 to be requested, got %v", requested)
+	}
+}
+
+func TestIntegration_DevServer(t *testing.T) {
+	server, err := lib.NewDevServer("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	oldAPIKey := viper.GetString(varApiKey)
+	viper.Set(varURL, ts.URL)
+	viper.Set(varApiKey, "test-api-key")
+	defer func() {
+		viper.Set(varURL, "")
+		viper.Set(varApiKey, oldAPIKey)
+		fail, run = false, false
+	}()
+
+	if _, err := executeCmd("ping", "nightly-backup", "--run"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	run = false
+	if _, err := executeCmd("ping", "nightly-backup", "--fail", "--msg", "disk full"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pings := server.State().Pings
+	if len(pings) != 2 || pings[1].Monitor != "nightly-backup" || pings[1].State != "fail" || pings[1].Params["msg"] != "disk full" {
+		t.Fatalf("expected the pings to reach the dev server, got %+v", pings)
+	}
+
+	// Reset flag state
+	monitorFormat = ""
+	monitorOutput = ""
+	monitorPage = 1
+
+	output, err := executeCmd("monitor", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "nightly-backup") || !strings.Contains(output, "failing") {
+		t.Errorf("expected the monitor created by the pings to be listed as failing, got:\n%s", output)
+	}
+}
+
+func TestCassette_MonitorLifecycle(t *testing.T) {
+	cassette := testutil.NewCassette(t)
+
+	cleanup := setupIntegrationTest(cassette.Server.URL)
+	defer cleanup()
+
+	// Reset flag state
+	monitorFormat = ""
+	monitorOutput = ""
+	monitorPage = 1
+	monitorPauseHours = ""
+	monitorTag = nil
+	defer func() { monitorData = "" }()
+
+	output, err := executeCmd("monitor", "create", "--data", `{"key":"cassette-backup","type":"job","name":"Cassette backup","tags":["cassette"]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "cassette-backup") {
+		t.Errorf("expected the created monitor, got:\n%s", output)
+	}
+
+	if _, err := executeCmd("monitor", "pause", "cassette-backup"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, err = executeCmd("monitor", "list", "--tag", "cassette")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Cassette backup") || !strings.Contains(output, "paused") {
+		t.Errorf("expected the paused monitor to be listed, got:\n%s", output)
+	}
+
+	output, err = executeCmd("monitor", "delete", "cassette-backup")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Monitor 'cassette-backup' deleted") {
+		t.Errorf("expected the monitor to be deleted, got:\n%s", output)
+	}
+	monitorTag = nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -75,7 +76,7 @@ func init() {
 	RootCmd.AddCommand(issueCmd)
 	issueCmd.PersistentFlags().IntVar(&issuePage, "page", 1, "Page number")
 	issueCmd.PersistentFlags().IntVar(&issuePageSize, "page-size", 0, "Results per page (max 1000)")
-	issueCmd.PersistentFlags().StringVar(&issueFormat, "format", "", "Output format: json, table")
+	issueCmd.PersistentFlags().StringVar(&issueFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	issueCmd.PersistentFlags().StringVarP(&issueOutput, "output", "o", "", "Write output to file")
 }
 
@@ -95,130 +96,84 @@ Examples:
   cronitor issue list --search "database"
   cronitor issue list --order-by -started`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-		if issuePage > 1 {
-			params["page"] = fmt.Sprintf("%d", issuePage)
-		}
-		if issuePageSize > 0 {
-			params["pageSize"] = fmt.Sprintf("%d", issuePageSize)
-		}
-		if issueState != "" {
-			params["state"] = issueState
-		}
-		if issueSeverity != "" {
-			params["severity"] = issueSeverity
-		}
-		if issueMonitor != "" {
-			params["job"] = issueMonitor
-		}
-		if issueGroup != "" {
-			params["group"] = issueGroup
-		}
-		if issueTag != "" {
-			params["tag"] = issueTag
-		}
-		if issueEnv != "" {
-			params["env"] = issueEnv
-		}
-		if issueSearch != "" {
-			params["search"] = issueSearch
-		}
-		if issueTime != "" {
-			params["time"] = issueTime
-		}
-		if issueOrderBy != "" {
-			params["orderBy"] = issueOrderBy
-		}
-		if issueWithStatuspageDetails {
-			params["withStatusPageDetails"] = "true"
-		}
-		if issueWithMonitorDetails {
-			params["withMonitorDetails"] = "true"
-		}
-		if issueWithAlertDetails {
-			params["withAlertDetails"] = "true"
-		}
-		if issueWithComponentDetails {
-			params["withComponentDetails"] = "true"
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.IssueListOptions{
+			ListOptions:     api.ListOptions{Page: issuePage, PageSize: issuePageSize, Concurrency: listConcurrency},
+			IssueExpansions: issueExpansions(),
+			State:           issueState,
+			Severity:        issueSeverity,
+			Monitor:         issueMonitor,
+			Group:           issueGroup,
+			Tag:             issueTag,
+			Env:             issueEnv,
+			Search:          issueSearch,
+			Time:            issueTime,
+			OrderBy:         issueOrderBy,
+		}
+
+		format := outputFormat(issueFormat, "table")
+
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.Issues.List, client.Issues.All), format, issueOutput, issueColumns)
+			return
 		}
 
-		resp, err := client.GET("/issues", params)
+		page, err := client.Issues.List(cmd.Context(), opts)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to list issues: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "list issues", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: issueColumns.headers,
+			}
 
-		var result struct {
-			Issues []struct {
-				Key      string `json:"key"`
-				Name     string `json:"name"`
-				State    string `json:"state"`
-				Severity string `json:"severity"`
-				Started  string `json:"started"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
+			for _, issue := range page.Items {
+				table.Rows = append(table.Rows, issueColumns.row(issue))
+			}
 
-		format := issueFormat
-		if format == "" {
-			format = "table"
-		}
+			return table.Render()
+		}).print(format, issueOutput)
+	},
+}
 
-		if format == "json" {
-			issueOutputToTarget(FormatJSON(resp.Body))
-			return
+var issueColumns = listColumns[api.Issue]{
+	noun:    "issues",
+	headers: []string{"NAME", "KEY", "STATE", "SEVERITY", "STARTED"},
+	row: func(issue api.Issue) []string {
+		state := issue.State
+		switch state {
+		case "unresolved":
+			state = errorStyle.Render("unresolved")
+		case "investigating", "identified":
+			state = warningStyle.Render(state)
+		case "monitoring":
+			state = mutedStyle.Render(state)
+		case "resolved":
+			state = successStyle.Render("resolved")
 		}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "STATE", "SEVERITY", "STARTED"},
+		severity := issue.Severity
+		switch severity {
+		case "outage", "minor_outage":
+			severity = errorStyle.Render(severity)
+		case "degraded_performance":
+			severity = warningStyle.Render(severity)
+		case "maintenance":
+			severity = mutedStyle.Render(severity)
 		}
 
-		for _, issue := range result.Issues {
-			state := issue.State
-			switch state {
-			case "unresolved":
-				state = errorStyle.Render("unresolved")
-			case "investigating", "identified":
-				state = warningStyle.Render(state)
-			case "monitoring":
-				state = mutedStyle.Render(state)
-			case "resolved":
-				state = successStyle.Render("resolved")
-			}
-
-			severity := issue.Severity
-			switch severity {
-			case "outage", "minor_outage":
-				severity = errorStyle.Render(severity)
-			case "degraded_performance":
-				severity = warningStyle.Render(severity)
-			case "maintenance":
-				severity = mutedStyle.Render(severity)
-			}
-
-			name := issue.Name
-			if len(name) > 40 {
-				name = name[:37] + "..."
-			}
-
-			started := ""
-			if issue.Started != "" && len(issue.Started) >= 10 {
-				started = issue.Started[:10]
-			}
+		name := issue.Name
+		if len(name) > 40 {
+			name = name[:37] + "..."
+		}
 
-			table.Rows = append(table.Rows, []string{name, issue.Key, state, severity, started})
+		started := ""
+		if issue.Started != "" && len(issue.Started) >= 10 {
+			started = issue.Started[:10]
 		}
 
-		issueOutputToTarget(table.Render())
+		return []string{name, issue.Key, state, severity, started}
 	},
 }
 
@@ -229,39 +184,15 @@ var issueGetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		params := make(map[string]string)
-		if issueWithStatuspageDetails {
-			params["withStatusPageDetails"] = "true"
-		}
-		if issueWithMonitorDetails {
-			params["withMonitorDetails"] = "true"
-		}
-		if issueWithAlertDetails {
-			params["withAlertDetails"] = "true"
-		}
-		if issueWithComponentDetails {
-			params["withComponentDetails"] = "true"
-		}
-
-		resp, err := client.GET(fmt.Sprintf("/issues/%s", key), params)
+		expansions := issueExpansions()
+		issue, err := client.Issues.Get(cmd.Context(), key, &expansions)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get issue: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "get issue", fmt.Sprintf("Issue '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Issue '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		issueOutputToTarget(FormatJSON(resp.Body))
+		output{raw: issue.Raw()}.print(outputFormat(issueFormat, "json"), issueOutput)
 	},
 }
 
@@ -282,36 +213,22 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(issueData), &js); err != nil {
+		var issue api.Issue
+		if err := json.Unmarshal([]byte(issueData), &issue); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/issues", []byte(issueData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.Issues.Create(cmd.Context(), &issue)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create issue: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "create issue", "")
 		}
 
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created issue: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Issue created")
-		}
+		Success(fmt.Sprintf("Created issue: %s (key: %s)", created.Name, created.Key))
 
-		if issueFormat == "json" {
-			issueOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(issueFormat, ""); format != "" {
+			output{raw: created.Raw()}.print(format, issueOutput)
 		}
 	},
 }
@@ -334,33 +251,20 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(issueData), &bodyMap); err != nil {
+		var issue api.Issue
+		if err := json.Unmarshal([]byte(issueData), &issue); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/issues/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Issues.Update(cmd.Context(), key, &issue)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update issue: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Issue '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update issue", fmt.Sprintf("Issue '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Issue '%s' updated", key))
-		issueOutputToTarget(FormatJSON(resp.Body))
+		output{raw: updated.Raw()}.print(outputFormat(issueFormat, "json"), issueOutput)
 	},
 }
 
@@ -371,40 +275,13 @@ var issueResolveCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		// Fetch current issue to get required fields
-		getResp, err := client.GET(fmt.Sprintf("/issues/%s", key), nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to resolve issue: %s", err))
-			os.Exit(1)
-		}
-		if getResp.IsNotFound() {
-			Error(fmt.Sprintf("Issue '%s' not found", key))
-			os.Exit(1)
+		if _, err := client.Issues.Resolve(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "resolve issue", fmt.Sprintf("Issue '%s' not found", key))
 		}
-		if !getResp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", getResp.StatusCode, getResp.ParseError()))
-			os.Exit(1)
-		}
-
-		var current map[string]interface{}
-		json.Unmarshal(getResp.Body, &current)
-		current["state"] = "resolved"
-		body, _ := json.Marshal(current)
 
-		resp, err := client.PUT(fmt.Sprintf("/issues/%s", key), body, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to resolve issue: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Issue '%s' resolved", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Issue '%s' resolved", key))
 	},
 }
 
@@ -415,25 +292,13 @@ var issueDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.DELETE(fmt.Sprintf("/issues/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete issue: %s", err))
-			os.Exit(1)
+		if err := client.Issues.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete issue", fmt.Sprintf("Issue '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Issue '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Issue '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Issue '%s' deleted", key))
 	},
 }
 
@@ -464,10 +329,7 @@ Examples:
 			issues[i] = strings.TrimSpace(issues[i])
 		}
 
-		body := map[string]interface{}{
-			"action": issueBulkAction,
-			"issues": issues,
-		}
+		req := &api.IssueBulkRequest{Action: issueBulkAction, Issues: issues}
 
 		switch issueBulkAction {
 		case "change_state":
@@ -475,13 +337,13 @@ Examples:
 				Error("State required for change_state action. Use --state")
 				os.Exit(1)
 			}
-			body["state"] = issueBulkState
+			req.State = issueBulkState
 		case "assign_to":
 			if issueBulkAssignTo == "" {
 				Error("Assignee required for assign_to action. Use --assign-to")
 				os.Exit(1)
 			}
-			body["assign_to"] = issueBulkAssignTo
+			req.AssignTo = issueBulkAssignTo
 		case "delete":
 			// No extra fields needed
 		default:
@@ -489,27 +351,15 @@ Examples:
 			os.Exit(1)
 		}
 
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to encode request: %s", err))
-			os.Exit(1)
-		}
-
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/issues/bulk", jsonBody, nil)
+		client := lib.NewCronitorClient(dev, log)
+		result, err := client.Issues.Bulk(cmd.Context(), req)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to perform bulk action: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "perform bulk action", "")
 		}
 
 		Success(fmt.Sprintf("Bulk %s completed for %d issues", issueBulkAction, len(issues)))
-		if issueFormat == "json" {
-			issueOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(issueFormat, ""); format != "" {
+			output{raw: result}.print(format, issueOutput)
 		}
 	},
 }
@@ -539,6 +389,7 @@ func init() {
 	issueListCmd.Flags().BoolVar(&issueWithMonitorDetails, "with-monitor-details", false, "Include monitor details")
 	issueListCmd.Flags().BoolVar(&issueWithAlertDetails, "with-alert-details", false, "Include alert details")
 	issueListCmd.Flags().BoolVar(&issueWithComponentDetails, "with-component-details", false, "Include component details")
+	addListAllFlags(issueListCmd)
 
 	// Get expansion flags
 	issueGetCmd.Flags().BoolVar(&issueWithStatuspageDetails, "with-statuspage-details", false, "Include status page details")
@@ -559,14 +410,12 @@ func init() {
 	issueBulkCmd.Flags().StringVar(&issueBulkAssignTo, "assign-to", "", "Assignee (for assign_to action)")
 }
 
-func issueOutputToTarget(content string) {
-	if issueOutput != "" {
-		if err := os.WriteFile(issueOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", issueOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", issueOutput))
-	} else {
-		fmt.Println(content)
+// issueExpansions collects the --with-*-details flags shared by list and get
+func issueExpansions() api.IssueExpansions {
+	return api.IssueExpansions{
+		WithStatusPageDetails: issueWithStatuspageDetails,
+		WithMonitorDetails:    issueWithMonitorDetails,
+		WithAlertDetails:      issueWithAlertDetails,
+		WithComponentDetails:  issueWithComponentDetails,
 	}
 }
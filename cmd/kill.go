@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/cobra"
+)
+
+var killSignal string
+var killGrace time.Duration
+
+var killCmd = &cobra.Command{
+	Use:   "kill <key|pid>",
+	Short: "Stop a running cron job",
+	Long: `
+Cronitor kill stops a running cron job and every process it started.
+
+The job is given a signal (TERM by default) and, if it is still running after the grace period, it is killed.
+A job whose recorded process group is not the group its process is in is stopped on its own.
+Jobs can be selected by monitor key, crontab line key, pid or process group id, as shown by 'cronitor ps'.
+The same safety checks as the dashboard apply: only processes you own or that were started by cron or
+Cronitor can be stopped.
+
+Example:
+  $ cronitor kill d3x0c1
+      > Send TERM to every running instance of monitor d3x0c1 and kill any still running after 10 seconds
+
+  $ cronitor kill 4321 --signal INT --grace 30s
+      > Send INT to the job with pid or process group 4321 and wait up to 30 seconds before killing it
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		signal, err := lib.ParseSignal(killSignal)
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		jobs, err := findRunningJobs()
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		targets := selectRunningJobs(jobs, args[0])
+		if len(targets) == 0 {
+			fatal(fmt.Sprintf("No running job matches %s; run 'cronitor ps' to see running jobs", args[0]), 1)
+		}
+
+		validator := lib.NewProcessValidator()
+		failed := false
+		for _, job := range targets {
+			target := fmt.Sprintf("process %d", job.PID)
+			pgid := killGroup(job)
+			if pgid > 0 {
+				target = fmt.Sprintf("process group %d", pgid)
+			}
+
+			if err := validator.ValidatePIDWithOwnership(job.PID); err != nil {
+				printErrorText(fmt.Sprintf("Cannot stop %s: %v", target, err), false)
+				failed = true
+				continue
+			}
+
+			var forced bool
+			if pgid > 0 {
+				forced, err = lib.TerminateProcessGroup(pgid, signal, killGrace)
+			} else {
+				forced, err = lib.TerminateProcess(job.PID, signal, killGrace)
+			}
+			switch {
+			case err != nil:
+				printErrorText(fmt.Sprintf("Failed to stop %s: %v", target, err), false)
+				failed = true
+			case forced:
+				printWarningText(fmt.Sprintf("The %s did not exit within %s after %s and was killed", target, killGrace, lib.SignalName(signal)), false)
+			default:
+				printSuccessText(fmt.Sprintf("Stopped %s (%s)", target, truncateString(job.Command, 60)), false)
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// killGroup returns the process group to stop for a job, or 0 to stop only its process. As in the dashboard,
+// a group is only used when it is the group the process is actually in, so a missing, init's or another
+// process's group recorded for the job never signals unrelated processes.
+func killGroup(job runningJob) int {
+	if (lib.RunningInstance{PID: job.PID, PGID: job.PGID}).HasValidProcessGroup() {
+		return job.PGID
+	}
+	return 0
+}
+
+// selectRunningJobs returns the jobs matching a monitor key, crontab line key, pid or process group id
+func selectRunningJobs(jobs []runningJob, target string) []runningJob {
+	var selected []runningJob
+	pid, err := strconv.Atoi(target)
+	isPID := err == nil
+
+	for _, job := range jobs {
+		if isPID && (job.PID == pid || job.PGID == pid) {
+			selected = append(selected, job)
+		} else if !isPID && ((job.Code != "" && job.Code == target) || (job.LineKey != "" && job.LineKey == target)) {
+			selected = append(selected, job)
+		}
+	}
+	return selected
+}
+
+func init() {
+	RootCmd.AddCommand(killCmd)
+	killCmd.Flags().StringVar(&killSignal, "signal", "TERM", "Signal to send first: TERM, INT, HUP, QUIT or KILL")
+	killCmd.Flags().DurationVar(&killGrace, "grace", 10*time.Second, "How long to wait for the job to exit before killing it")
+}
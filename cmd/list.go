@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/user"
 
 	"github.com/cronitorio/cronitor-cli/lib"
 	"github.com/olekukonko/tablewriter"
@@ -29,12 +28,16 @@ Example:
 
   $ cronitor list --json
       > Output all discovered cron jobs as JSON
+
+  $ cronitor list --host deploy@web1
+      > List the cron jobs on another server over SSH
 	`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		return nil
 	},
 
 	Run: func(cmd *cobra.Command, args []string) {
+		connectRemoteHost()
 		crontabs := gatherCrontabs(args)
 		if len(crontabs) == 0 {
 			printWarningText("No crontab files found", false)
@@ -55,14 +58,12 @@ Example:
 func init() {
 	RootCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVarP(&printJSON, "json", "j", false, "Output as JSON")
+	addRemoteHostFlags(listCmd)
 }
 
 // gatherCrontabs collects crontabs from the specified args or the default locations.
 func gatherCrontabs(args []string) []*lib.Crontab {
-	var username string
-	if u, err := user.Current(); err == nil {
-		username = u.Username
-	}
+	username := lib.CurrentUsername()
 
 	crontabs := []*lib.Crontab{}
 
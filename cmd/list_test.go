@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/cobra"
 )
 
 func makeCrontab(filename string, isUser bool, lines []*lib.Line) *lib.Crontab {
@@ -282,3 +283,13 @@ func TestPrintListAsTableIncludesCommands(t *testing.T) {
 		t.Error("table output missing schedule '0 * * * *'")
 	}
 }
+
+func TestRemoteHostFlags(t *testing.T) {
+	for _, command := range []*cobra.Command{listCmd, discoverCmd, dashCmd} {
+		for _, flag := range []string{"host", "ssh-key"} {
+			if command.Flags().Lookup(flag) == nil {
+				t.Errorf("Expected flag '--%s' not found in %s command", flag, command.Name())
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 )
 
@@ -62,7 +63,7 @@ For full API documentation:
 func init() {
 	RootCmd.AddCommand(maintenanceCmd)
 	maintenanceCmd.PersistentFlags().IntVar(&maintenancePage, "page", 1, "Page number")
-	maintenanceCmd.PersistentFlags().StringVar(&maintenanceFormat, "format", "", "Output format: json, table")
+	maintenanceCmd.PersistentFlags().StringVar(&maintenanceFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	maintenanceCmd.PersistentFlags().StringVarP(&maintenanceOutput, "output", "o", "", "Write output to file")
 }
 
@@ -80,95 +81,70 @@ Examples:
   cronitor maintenance list --statuspage my-page
   cronitor maintenance list --env production`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if maintenancePage > 1 {
-			params["page"] = fmt.Sprintf("%d", maintenancePage)
-		}
-		if maintenancePast {
-			params["past"] = "true"
-		}
-		if maintenanceOngoing {
-			params["ongoing"] = "true"
-		}
-		if maintenanceUpcoming {
-			params["upcoming"] = "true"
-		}
-		if maintenanceStatuspage != "" {
-			params["statuspage"] = maintenanceStatuspage
-		}
-		if maintenanceEnv != "" {
-			params["env"] = maintenanceEnv
-		}
-		if maintenanceWithMonitors {
-			params["withAllAffectedMonitors"] = "true"
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.MaintenanceWindowListOptions{
+			ListOptions:             api.ListOptions{Page: maintenancePage, Concurrency: listConcurrency},
+			Past:                    maintenancePast,
+			Ongoing:                 maintenanceOngoing,
+			Upcoming:                maintenanceUpcoming,
+			StatusPage:              maintenanceStatuspage,
+			Env:                     maintenanceEnv,
+			WithAllAffectedMonitors: maintenanceWithMonitors,
+		}
+
+		format := outputFormat(maintenanceFormat, "table")
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.MaintenanceWindows.List, client.MaintenanceWindows.All), format, maintenanceOutput, maintenanceColumns)
+			return
 		}
 
-		resp, err := client.GET("/maintenance_windows", params)
+		page, err := client.MaintenanceWindows.List(cmd.Context(), opts)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to list maintenance windows: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "list maintenance windows", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		pageOutput(page, func() string {
+			if len(page.Items) == 0 {
+				return mutedStyle.Render("No maintenance windows found")
+			}
 
-		if maintenanceFormat == "json" {
-			maintenanceOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
+			table := &UITable{
+				Headers: maintenanceColumns.headers,
+			}
 
-		var result struct {
-			Windows []struct {
-				Key      string `json:"key"`
-				Name     string `json:"name"`
-				Start    string `json:"start"`
-				End      string `json:"end"`
-				State    string `json:"state"`
-				Duration int    `json:"duration"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
+			for _, w := range page.Items {
+				table.Rows = append(table.Rows, maintenanceColumns.row(w))
+			}
 
-		if len(result.Windows) == 0 {
-			maintenanceOutputToTarget(mutedStyle.Render("No maintenance windows found"))
-			return
-		}
+			return table.Render()
+		}).print(format, maintenanceOutput)
+	},
+}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "START", "END", "STATE"},
+var maintenanceColumns = listColumns[api.MaintenanceWindow]{
+	noun:    "maintenance windows",
+	headers: []string{"NAME", "KEY", "START", "END", "STATE"},
+	row: func(w api.MaintenanceWindow) []string {
+		state := w.State
+		switch state {
+		case "ongoing":
+			state = warningStyle.Render("ongoing")
+		case "upcoming":
+			state = mutedStyle.Render("upcoming")
+		case "past":
+			state = successStyle.Render("completed")
 		}
 
-		for _, w := range result.Windows {
-			state := w.State
-			switch state {
-			case "ongoing":
-				state = warningStyle.Render("ongoing")
-			case "upcoming":
-				state = mutedStyle.Render("upcoming")
-			case "past":
-				state = successStyle.Render("completed")
-			}
-
-			start := ""
-			if len(w.Start) >= 16 {
-				start = w.Start[:16]
-			}
-			end := ""
-			if len(w.End) >= 16 {
-				end = w.End[:16]
-			}
-
-			table.Rows = append(table.Rows, []string{w.Name, w.Key, start, end, state})
+		start := ""
+		if len(w.Start) >= 16 {
+			start = w.Start[:16]
+		}
+		end := ""
+		if len(w.End) >= 16 {
+			end = w.End[:16]
 		}
 
-		maintenanceOutputToTarget(table.Render())
+		return []string{w.Name, w.Key, start, end, state}
 	},
 }
 
@@ -184,30 +160,14 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if maintenanceWithMonitors {
-			params["withAllAffectedMonitors"] = "true"
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.GET(fmt.Sprintf("/maintenance_windows/%s", key), params)
+		window, err := client.MaintenanceWindows.Get(cmd.Context(), key, &api.MaintenanceWindowGetOptions{WithAllAffectedMonitors: maintenanceWithMonitors})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get maintenance window: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "get maintenance window", fmt.Sprintf("Maintenance window '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Maintenance window '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		maintenanceOutputToTarget(FormatJSON(resp.Body))
+		output{raw: window.Raw()}.print(outputFormat(maintenanceFormat, "json"), maintenanceOutput)
 	},
 }
 
@@ -228,36 +188,22 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(maintenanceData), &js); err != nil {
+		var window api.MaintenanceWindow
+		if err := json.Unmarshal([]byte(maintenanceData), &window); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/maintenance_windows", []byte(maintenanceData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.MaintenanceWindows.Create(cmd.Context(), &window)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create maintenance window: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "create maintenance window", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Created maintenance window: %s (key: %s)", created.Name, created.Key))
 
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created maintenance window: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Maintenance window created")
-		}
-
-		if maintenanceFormat == "json" {
-			maintenanceOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(maintenanceFormat, ""); format != "" {
+			output{raw: created.Raw()}.print(format, maintenanceOutput)
 		}
 	},
 }
@@ -289,33 +235,21 @@ Examples:
 			os.Exit(1)
 		}
 
-		// Inject key into body
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal(body, &bodyMap); err == nil {
-			bodyMap["key"] = key
-			body, _ = json.Marshal(bodyMap)
-		}
-
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/maintenance_windows/%s", key), body, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to update maintenance window: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Maintenance window '%s' not found", key))
+		var window api.MaintenanceWindow
+		if err := json.Unmarshal(body, &window); err != nil {
+			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.MaintenanceWindows.Update(cmd.Context(), key, &window)
+		if err != nil {
+			exitOnAPIError(err, "update maintenance window", fmt.Sprintf("Maintenance window '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Maintenance window '%s' updated", key))
-		if maintenanceFormat == "json" {
-			maintenanceOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(maintenanceFormat, ""); format != "" {
+			output{raw: updated.Raw()}.print(format, maintenanceOutput)
 		}
 	},
 }
@@ -327,25 +261,13 @@ var maintenanceDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		resp, err := client.DELETE(fmt.Sprintf("/maintenance_windows/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete maintenance window: %s", err))
-			os.Exit(1)
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Maintenance window '%s' not found", key))
-			os.Exit(1)
+		if err := client.MaintenanceWindows.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete maintenance window", fmt.Sprintf("Maintenance window '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Maintenance window '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Maintenance window '%s' deleted", key))
 	},
 }
 
@@ -363,6 +285,7 @@ func init() {
 	maintenanceListCmd.Flags().StringVar(&maintenanceStatuspage, "statuspage", "", "Filter by status page key")
 	maintenanceListCmd.Flags().StringVar(&maintenanceEnv, "env", "", "Filter by environment")
 	maintenanceListCmd.Flags().BoolVar(&maintenanceWithMonitors, "with-monitors", false, "Include affected monitor details")
+	addListAllFlags(maintenanceListCmd)
 
 	// Get flags
 	maintenanceGetCmd.Flags().BoolVar(&maintenanceWithMonitors, "with-monitors", false, "Include affected monitor details")
@@ -396,15 +319,3 @@ func getMaintenanceRequestBody() ([]byte, error) {
 	}
 	return nil, nil
 }
-
-func maintenanceOutputToTarget(content string) {
-	if maintenanceOutput != "" {
-		if err := os.WriteFile(maintenanceOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", maintenanceOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", maintenanceOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	mcpInstanceName string
+	mcpHTTPAddress  string
+	mcpReadOnly     bool
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve the Cronitor MCP server for AI assistants",
+	Long: `
+Cronitor mcp serves the Model Context Protocol so AI assistants can manage the cron jobs on this host and work
+with your Cronitor monitors, issues and metrics.
+
+By default it serves the crontabs on this host directly over stdio, the way most MCP clients spawn servers, and no
+dashboard needs to be running. Changes are made with the permissions of the user running it and are recorded in
+the audit log. Use --host to manage the crontabs on another server over SSH instead.
+
+With --instance, it connects to a dashboard configured under mcp_instances instead, for servers you reach over HTTP.
+
+With --http, it serves MCP over HTTP instead of stdio: streamable HTTP at /mcp, and server-sent events at /sse for
+older clients. Clients sign in with dashboard credentials and act with the role of their account: viewers can only
+use the tools that read, and pausing monitors or changing issues requires the operator role.
+
+Example:
+  $ cronitor mcp
+      > Serve this host's crontabs over stdio
+
+  $ cronitor mcp --read-only
+      > Only offer tools that read, not ones that change jobs, monitors or issues
+
+  $ cronitor mcp --instance production
+      > Serve the dashboard configured as "production" under mcp_instances
+
+  $ cronitor mcp --http 127.0.0.1:8765
+      > Serve MCP over HTTP for clients that connect to a URL
+	`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if mcpInstanceName != "" && remoteHost != "" {
+			fatal("Use either --instance or --host, not both", 1)
+		}
+
+		handler := newMCPHandler(mcpInstanceName)
+		if mcpReadOnly {
+			handler.RestrictToReadOnly()
+		}
+
+		name := mcpInstanceName
+		if name == "" {
+			name = effectiveHostname()
+		}
+		s := newMCPServer(name, handler)
+
+		if mcpHTTPAddress != "" {
+			serveMCPHTTP(s, mcpHTTPAddress)
+			return
+		}
+
+		if err := server.ServeStdio(s); err != nil {
+			fatal(fmt.Sprintf("MCP server error: %v", err), 1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(mcpCmd)
+	mcpCmd.Flags().StringVar(&mcpInstanceName, "instance", "", "Connect to a dashboard configured under mcp_instances instead of serving this host")
+	mcpCmd.Flags().StringVar(&mcpHTTPAddress, "http", "", "Serve MCP over HTTP on this address instead of stdio, e.g. 127.0.0.1:8765")
+	mcpCmd.Flags().BoolVar(&mcpReadOnly, "read-only", false, "Only offer tools that read")
+	addRemoteHostFlags(mcpCmd)
+}
+
+// runMCPServer serves an MCP instance over stdio for `cronitor dash --mcp-instance`
+func runMCPServer(instanceName string) {
+	s := newMCPServer(fmt.Sprintf("Cronitor Dashboard (%s)", instanceName), newMCPHandler(instanceName))
+	if err := server.ServeStdio(s); err != nil {
+		fatal(fmt.Sprintf("MCP server error: %v", err), 1)
+	}
+}
+
+// newMCPHandler connects to a dashboard over HTTP for instances configured under mcp_instances, and otherwise
+// serves the crontabs on this host in-process
+func newMCPHandler(instanceName string) *lib.CronitorMCPHandler {
+	readOnly := viper.GetBool(varMCPReadOnly)
+	if instanceName != "" && lib.IsRemoteMCPInstance(instanceName) {
+		handler := lib.NewCronitorMCPHandler(instanceName)
+		handler.SetAuditLog(dashAuditLog())
+		handler.SetCaller(mcpCaller(localMCPUser(readOnly)))
+		return handler
+	}
+
+	connectRemoteHost()
+
+	// A dashboard on this host owns the run history, so runs started here are handed to it
+	dashRunsSpooled = true
+
+	handler := lib.NewLocalMCPHandler(newLocalDashTransport(localMCPUser(readOnly)), readOnly)
+	handler.SetAuditLog(dashAuditLog())
+	handler.SetCaller(mcpCaller(localMCPUser(readOnly)))
+	return handler
+}
+
+// mcpCaller returns who a tool call acts for: the account signed in over HTTP, otherwise defaultUser. Accounts
+// below the operator role, which may not run or kill jobs, may not pause monitors or change issues either.
+func mcpCaller(defaultUser *DashUser) func(ctx context.Context) lib.MCPCaller {
+	return func(ctx context.Context) lib.MCPCaller {
+		user, _ := ctx.Value(dashUserContextKey{}).(*DashUser)
+		if user == nil {
+			user = defaultUser
+		}
+		clientIP, _ := ctx.Value(mcpClientIPKey{}).(string)
+		return lib.MCPCaller{Username: user.Username, ClientIP: clientIP, ReadOnly: !user.Allows(dashRoleOperator)}
+	}
+}
+
+func newMCPServer(name string, handler *lib.CronitorMCPHandler) *server.MCPServer {
+	options := append([]server.ServerOption{server.WithToolCapabilities(false)}, handler.ServerOptions()...)
+	s := server.NewMCPServer(name, Version, options...)
+
+	if err := handler.RegisterTools(s); err != nil {
+		fatal(fmt.Sprintf("Failed to register MCP tools: %v", err), 1)
+	}
+	if err := handler.RegisterResources(s); err != nil {
+		fatal(fmt.Sprintf("Failed to register MCP resources: %v", err), 1)
+	}
+	return s
+}
+
+// localMCPUser is the account stdio requests act as: the user running the server, who can already edit the
+// crontabs it serves
+func localMCPUser(readOnly bool) *DashUser {
+	username := "local"
+	if current, err := user.Current(); err == nil {
+		username = current.Username
+	}
+
+	role := dashRoleAdmin
+	if readOnly {
+		role = dashRoleViewer
+	}
+	return &DashUser{Username: username, Role: role}
+}
+
+// localMCPRoutes are the dashboard API routes the MCP tools and resources use
+var localMCPRoutes = map[string]http.HandlerFunc{
+	"/api/jobs":      handleJobs,
+	"/api/jobs/run":  handleRunJob,
+	"/api/jobs/kill": handleKillInstances,
+	"/api/crontabs":  handleCrontabs,
+	"/api/crontabs/": handleCrontab,
+	"/api/runs":      withLocalRunHistory(handleRuns),
+	"/api/runs/":     withLocalRunHistory(handleRun),
+}
+
+var localRunHistoryMu sync.Mutex
+
+// withLocalRunHistory serves the run history for MCP resources. The database is only held for the request, so a
+// dashboard on this host can still open it.
+func withLocalRunHistory(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		localRunHistoryMu.Lock()
+		defer localRunHistoryMu.Unlock()
+
+		if _, err := runHistory(); err != nil {
+			http.Error(w, "The run history is in use by the dashboard on this host. Use --instance to connect to the dashboard instead.", http.StatusServiceUnavailable)
+			return
+		}
+		defer closeRunHistory()
+		handler(w, r)
+	}
+}
+
+// localDashTransport serves MCP requests for jobs and crontabs with the dashboard's API handlers, in-process.
+// Requests keep the role checks and audit log of the dashboard, without its login and CSRF middleware.
+type localDashTransport struct {
+	handler http.Handler
+	user    *DashUser
+}
+
+func newLocalDashTransport(defaultUser *DashUser) *localDashTransport {
+	mux := http.NewServeMux()
+	for path, handler := range localMCPRoutes {
+		mux.Handle(path, auditDashRoute(path, authorizeDashRoute(path, handler), true))
+	}
+	return &localDashTransport{handler: mux, user: defaultUser}
+}
+
+type mcpClientIPKey struct{}
+
+func (t *localDashTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	// Over HTTP, requests act as the account that signed in. Over stdio, as the local user.
+	if dashUserFromRequest(req) == nil {
+		req = withDashUser(req, t.user)
+	}
+	if clientIP, ok := req.Context().Value(mcpClientIPKey{}).(string); ok {
+		req.RemoteAddr = clientIP
+	} else {
+		req.RemoteAddr = "127.0.0.1"
+	}
+
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+// serveMCPHTTP serves MCP over streamable HTTP at /mcp and server-sent events at /sse, behind the dashboard login
+func serveMCPHTTP(s *server.MCPServer, address string) {
+	users, err := loadDashUserStore(dashUsersFilePath())
+	if err != nil {
+		fatal(fmt.Sprintf("Failed to load dashboard users: %v", err), 1)
+	}
+	if viper.GetString(varDashUsername) == "" && len(users.Users) == 0 {
+		fatal("Serving MCP over HTTP requires dashboard credentials. Set them with 'cronitor configure --dash-username USER --dash-password PASS'", 1)
+	}
+
+	sse := server.NewSSEServer(s)
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", server.NewStreamableHTTPServer(s))
+	mux.Handle("/sse", sse.SSEHandler())
+	mux.Handle("/message", sse.MessageHandler())
+
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           mcpAuthMiddleware(users, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	printSuccessText(fmt.Sprintf("Serving MCP at http://%s/mcp (SSE at /sse)", displayListenAddress(address)), false)
+	if err := httpServer.ListenAndServe(); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
+
+// mcpAuthMiddleware signs requests in with dashboard credentials, so tools act with the account's role
+func mcpAuthMiddleware(users *dashUserStore, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP := getClientIP(r)
+		dashUser := authenticateDashRequest(r, users)
+		if dashUser == nil {
+			if !authRateLimiter.GetLimiter(clientIP).Allow() {
+				http.Error(w, "Too Many Requests - Rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="Cronitor MCP"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		r = withDashUser(r, dashUser)
+		r = r.WithContext(context.WithValue(r.Context(), mcpClientIPKey{}, clientIP))
+		next.ServeHTTP(w, r)
+	})
+}
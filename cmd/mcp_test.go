@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/viper"
+)
+
+func callMCPTool(t *testing.T, handler *lib.CronitorMCPHandler, tool string, args map[string]interface{}) (string, bool) {
+	s := newMCPServer("test", handler)
+	message, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]interface{}{"name": tool, "arguments": args},
+	})
+
+	data, _ := json.Marshal(s.HandleMessage(context.Background(), message))
+	var response struct {
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			IsError bool `json:"isError"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Error != nil {
+		return response.Error.Message, true
+	}
+
+	var text strings.Builder
+	for _, content := range response.Result.Content {
+		text.WriteString(content.Text)
+	}
+	return text.String(), response.Result.IsError
+}
+
+func TestLocalMCPHandlerEditsCrontabsInProcess(t *testing.T) {
+	dir := t.TempDir()
+	defer viper.Set(varAuditLog, "")
+	viper.Set(varAuditLog, filepath.Join(dir, "audit.log"))
+
+	crontabFile := filepath.Join(dir, "crontab")
+	os.WriteFile(crontabFile, []byte("0 * * * * root backup.sh\n"), 0644)
+
+	handler := lib.NewLocalMCPHandler(newLocalDashTransport(&DashUser{Username: "alice", Role: dashRoleAdmin}), false)
+	text, isError := callMCPTool(t, handler, "create_cronjob", map[string]interface{}{
+		"name":         "Nightly report",
+		"command":      "/usr/local/bin/report.sh",
+		"schedule":     "30 2 * * *",
+		"crontab_file": crontabFile,
+		"run_as_user":  "root",
+	})
+	if isError {
+		t.Fatalf("expected the job to be created, got %s", text)
+	}
+
+	content, _ := os.ReadFile(crontabFile)
+	if !strings.Contains(string(content), "30 2 * * * root /usr/local/bin/report.sh") {
+		t.Errorf("expected the job to be written to the crontab, got %q", content)
+	}
+
+	entries, _ := dashAuditLog().Read(lib.AuditFilter{})
+	if len(entries) != 1 || entries[0].Action != "job.create" || entries[0].Source != "mcp" || entries[0].User != "alice" {
+		t.Errorf("expected the change to be audited as an MCP request by alice, got %+v", entries)
+	}
+}
+
+func TestLocalMCPHandlerActsWithTheAccountRole(t *testing.T) {
+	dir := t.TempDir()
+	defer viper.Set(varAuditLog, "")
+	viper.Set(varAuditLog, filepath.Join(dir, "audit.log"))
+
+	crontabFile := filepath.Join(dir, "crontab")
+	os.WriteFile(crontabFile, []byte(""), 0644)
+
+	// A viewer signed in over HTTP is refused even if the tool is offered
+	handler := lib.NewLocalMCPHandler(newLocalDashTransport(&DashUser{Username: "bob", Role: dashRoleViewer}), false)
+	text, isError := callMCPTool(t, handler, "create_cronjob", map[string]interface{}{
+		"name":         "Nightly report",
+		"command":      "report.sh",
+		"schedule":     "30 2 * * *",
+		"crontab_file": crontabFile,
+		"run_as_user":  "root",
+	})
+	if !isError || !strings.Contains(text, "403") {
+		t.Errorf("expected the viewer to be forbidden, got %q", text)
+	}
+
+	readOnly := lib.NewLocalMCPHandler(newLocalDashTransport(localMCPUser(true)), true)
+	if _, isError := callMCPTool(t, readOnly, "delete_cronjob", map[string]interface{}{"key": "abc"}); !isError {
+		t.Error("expected delete_cronjob to be unavailable on a read-only server")
+	}
+}
+
+func TestMCPAuthMiddleware(t *testing.T) {
+	defer viper.Set(varDashUsername, "")
+	defer viper.Set(varDashPassword, "")
+	viper.Set(varDashUsername, "admin")
+	viper.Set(varDashPassword, "secret")
+
+	users, _ := loadDashUserStore(filepath.Join(t.TempDir(), "users.json"))
+	var signedIn *DashUser
+	handler := mcpAuthMiddleware(users, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedIn = dashUserFromRequest(r)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/mcp", nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", recorder.Code)
+	}
+
+	request := httptest.NewRequest("POST", "/mcp", nil)
+	request.SetBasicAuth("admin", "secret")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if signedIn == nil || signedIn.Username != "admin" || signedIn.Role != dashRoleAdmin {
+		t.Errorf("expected requests to act as the signed in account, got %+v", signedIn)
+	}
+}
+
+func TestMCPCallerFollowsSignedInRole(t *testing.T) {
+	caller := mcpCaller(localMCPUser(false))
+
+	if local := caller(context.Background()); local.ReadOnly {
+		t.Errorf("expected the local user to make changes over stdio, got %+v", local)
+	}
+	viewer := withDashUser(httptest.NewRequest("POST", "/mcp", nil), &DashUser{Username: "bob", Role: dashRoleViewer})
+	if signedIn := caller(viewer.Context()); !signedIn.ReadOnly || signedIn.Username != "bob" {
+		t.Errorf("expected a viewer signed in over HTTP to only read, got %+v", signedIn)
+	}
+	operator := withDashUser(httptest.NewRequest("POST", "/mcp", nil), &DashUser{Username: "carol", Role: dashRoleOperator})
+	if signedIn := caller(operator.Context()); signedIn.ReadOnly {
+		t.Errorf("expected an operator to pause monitors, got %+v", signedIn)
+	}
+}
+
+func TestLocalMCPJobLastOutputReleasesRunHistory(t *testing.T) {
+	defer viper.Set(varConfig, "")
+	defer viper.Set(varStateDir, "")
+	viper.Set(varConfig, filepath.Join(t.TempDir(), "cronitor.json"))
+	viper.Set(varStateDir, t.TempDir())
+
+	spoolRun(lib.RunRecord{JobKey: "key-1", Command: "backup.sh", Trigger: lib.RunTriggerExec, User: lib.CurrentUsername(), ExitCode: 2, Output: "disk full\n", StartedAt: time.Now().Add(-time.Second), EndedAt: time.Now()})
+
+	handler := lib.NewLocalMCPHandler(newLocalDashTransport(&DashUser{Username: "alice", Role: dashRoleViewer}), true)
+	s := newMCPServer("test", handler)
+	data, _ := json.Marshal(s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"cronitor://job/key-1/last-output"}}`)))
+	if !strings.Contains(string(data), "Exit code: 2") || !strings.Contains(string(data), "disk full") {
+		t.Errorf("expected the output of the spooled run, got %s", data)
+	}
+
+	// A dashboard started afterwards can still open the run history
+	if dashRunHistory != nil {
+		t.Errorf("expected the run history to be closed after the request")
+	}
+}
@@ -1,12 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 )
 
@@ -58,7 +58,7 @@ For full API documentation:
 
 func init() {
 	RootCmd.AddCommand(metricCmd)
-	metricCmd.PersistentFlags().StringVar(&metricFormat, "format", "", "Output format: json, table")
+	metricCmd.PersistentFlags().StringVar(&metricFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	metricCmd.PersistentFlags().StringVarP(&metricOutput, "output", "o", "", "Write output to file")
 }
 
@@ -87,73 +87,52 @@ Examples:
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		params := buildMetricParams()
+		opts := metricOptions()
+		opts.Fields = splitAndTrimMetric(metricFields)
 
-		// Add fields
-		params["field"] = metricFields
-
-		resp, err := client.GET("/metrics", params)
+		client := lib.NewCronitorClient(dev, log)
+		result, err := client.Metrics.Get(cmd.Context(), opts)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get metrics: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		if metricFormat == "json" || metricFormat == "" {
-			metricOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
-
-		// Parse and display as table
-		var result struct {
-			Monitors map[string]map[string][]map[string]interface{} `json:"monitors"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "get metrics", "")
 		}
 
-		if len(result.Monitors) == 0 {
-			metricOutputToTarget(mutedStyle.Render("No metrics found"))
-			return
-		}
+		output{raw: result.Raw(), table: func() string {
+			if len(result.Monitors) == 0 {
+				return mutedStyle.Render("No metrics found")
+			}
 
-		// Build table with dynamic columns based on fields
-		fields := splitAndTrimMetric(metricFields)
-		headers := []string{"MONITOR", "ENV", "TIMESTAMP"}
-		headers = append(headers, fields...)
+			// Build table with dynamic columns based on fields
+			fields := opts.Fields
+			headers := []string{"MONITOR", "ENV", "TIMESTAMP"}
+			headers = append(headers, fields...)
 
-		table := &UITable{
-			Headers: headers,
-		}
+			table := &UITable{
+				Headers: headers,
+			}
 
-		for monitorKey, envData := range result.Monitors {
-			for envKey, dataPoints := range envData {
-				for _, dp := range dataPoints {
-					row := []string{monitorKey, envKey}
-					if stamp, ok := dp["stamp"].(float64); ok {
-						row = append(row, fmt.Sprintf("%.0f", stamp))
-					} else {
-						row = append(row, "-")
-					}
-					for _, f := range fields {
-						if val, ok := dp[f]; ok {
-							row = append(row, formatMetricValue(val))
+			for monitorKey, envData := range result.Monitors {
+				for envKey, dataPoints := range envData {
+					for _, dp := range dataPoints {
+						row := []string{monitorKey, envKey}
+						if stamp := dp.Stamp(); stamp > 0 {
+							row = append(row, fmt.Sprintf("%d", stamp))
 						} else {
 							row = append(row, "-")
 						}
+						for _, f := range fields {
+							if val, ok := dp[f]; ok {
+								row = append(row, formatMetricValue(val))
+							} else {
+								row = append(row, "-")
+							}
+						}
+						table.Rows = append(table.Rows, row)
 					}
-					table.Rows = append(table.Rows, row)
 				}
 			}
-		}
 
-		metricOutputToTarget(table.Render())
+			return table.Render()
+		}}.print(outputFormat(metricFormat, "json"), metricOutput)
 	},
 }
 
@@ -177,83 +156,62 @@ Examples:
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		params := buildMetricParams()
-
-		resp, err := client.GET("/aggregates", params)
+		client := lib.NewCronitorClient(dev, log)
+		result, err := client.Metrics.Aggregates(cmd.Context(), metricOptions())
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get aggregates: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "get aggregates", "")
 		}
 
-		if metricFormat == "json" || metricFormat == "" {
-			metricOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
-
-		// Parse and display as table
-		var result struct {
-			Monitors map[string]map[string]map[string]interface{} `json:"monitors"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
+		output{raw: result.Raw(), table: func() string {
+			if len(result.Monitors) == 0 {
+				return mutedStyle.Render("No aggregates found")
+			}
 
-		if len(result.Monitors) == 0 {
-			metricOutputToTarget(mutedStyle.Render("No aggregates found"))
-			return
-		}
+			table := &UITable{
+				Headers: []string{"MONITOR", "ENV", "SUCCESS RATE", "MEAN DURATION", "P50", "P90", "RUNS", "FAILURES"},
+			}
 
-		table := &UITable{
-			Headers: []string{"MONITOR", "ENV", "SUCCESS RATE", "MEAN DURATION", "P50", "P90", "RUNS", "FAILURES"},
-		}
+			for monitorKey, envData := range result.Monitors {
+				for envKey, agg := range envData {
+					row := []string{monitorKey, envKey}
 
-		for monitorKey, envData := range result.Monitors {
-			for envKey, agg := range envData {
-				row := []string{monitorKey, envKey}
+					if sr, ok := agg["success_rate"]; ok {
+						row = append(row, formatMetricValue(sr)+"%")
+					} else {
+						row = append(row, "-")
+					}
+					if dm, ok := agg["duration_mean"]; ok {
+						row = append(row, formatMetricValue(dm)+"ms")
+					} else {
+						row = append(row, "-")
+					}
+					if p50, ok := agg["duration_p50"]; ok {
+						row = append(row, formatMetricValue(p50)+"ms")
+					} else {
+						row = append(row, "-")
+					}
+					if p90, ok := agg["duration_p90"]; ok {
+						row = append(row, formatMetricValue(p90)+"ms")
+					} else {
+						row = append(row, "-")
+					}
+					if runs, ok := agg["total_runs"]; ok {
+						row = append(row, formatMetricValue(runs))
+					} else {
+						row = append(row, "-")
+					}
+					if fails, ok := agg["total_failures"]; ok {
+						row = append(row, formatMetricValue(fails))
+					} else {
+						row = append(row, "-")
+					}
 
-				if sr, ok := agg["success_rate"]; ok {
-					row = append(row, formatMetricValue(sr)+"%")
-				} else {
-					row = append(row, "-")
-				}
-				if dm, ok := agg["duration_mean"]; ok {
-					row = append(row, formatMetricValue(dm)+"ms")
-				} else {
-					row = append(row, "-")
-				}
-				if p50, ok := agg["duration_p50"]; ok {
-					row = append(row, formatMetricValue(p50)+"ms")
-				} else {
-					row = append(row, "-")
-				}
-				if p90, ok := agg["duration_p90"]; ok {
-					row = append(row, formatMetricValue(p90)+"ms")
-				} else {
-					row = append(row, "-")
-				}
-				if runs, ok := agg["total_runs"]; ok {
-					row = append(row, formatMetricValue(runs))
-				} else {
-					row = append(row, "-")
-				}
-				if fails, ok := agg["total_failures"]; ok {
-					row = append(row, formatMetricValue(fails))
-				} else {
-					row = append(row, "-")
+					table.Rows = append(table.Rows, row)
 				}
-
-				table.Rows = append(table.Rows, row)
 			}
-		}
 
-		metricOutputToTarget(table.Render())
+			return table.Render()
+		}}.print(outputFormat(metricFormat, "json"), metricOutput)
 	},
 }
 
@@ -279,44 +237,21 @@ func init() {
 	metricGetCmd.Flags().StringVar(&metricFields, "field", "", "Metric fields to return (comma-separated, required)")
 }
 
-func buildMetricParams() map[string]string {
-	params := make(map[string]string)
-
-	// Note: For multiple values, pass comma-separated to the CLI
-	// The API accepts repeated params but our client uses map[string]string
-	// so we pass the first value for each. Use comma-separated for multiple.
-	if metricMonitors != "" {
-		params["monitor"] = metricMonitors
-	}
-	if metricGroups != "" {
-		params["group"] = metricGroups
-	}
-	if metricTags != "" {
-		params["tag"] = metricTags
-	}
-	if metricTypes != "" {
-		params["type"] = metricTypes
-	}
-	if metricStart > 0 {
-		params["start"] = fmt.Sprintf("%d", metricStart)
-	}
-	if metricEnd > 0 {
-		params["end"] = fmt.Sprintf("%d", metricEnd)
-	}
-	if metricStart == 0 && metricEnd == 0 && metricTime != "" {
-		params["time"] = metricTime
+// metricOptions builds the selection shared by metric get and aggregate from the command flags.
+// Multiple values are passed to the CLI comma-separated.
+func metricOptions() *api.MetricOptions {
+	return &api.MetricOptions{
+		Monitors:  splitAndTrimMetric(metricMonitors),
+		Groups:    splitAndTrimMetric(metricGroups),
+		Tags:      splitAndTrimMetric(metricTags),
+		Types:     splitAndTrimMetric(metricTypes),
+		Time:      metricTime,
+		Start:     metricStart,
+		End:       metricEnd,
+		Env:       metricEnv,
+		Regions:   splitAndTrimMetric(metricRegions),
+		WithNulls: metricWithNulls,
 	}
-	if metricEnv != "" {
-		params["env"] = metricEnv
-	}
-	if metricRegions != "" {
-		params["region"] = metricRegions
-	}
-	if metricWithNulls {
-		params["withNulls"] = "true"
-	}
-
-	return params
 }
 
 func splitAndTrimMetric(s string) []string {
@@ -346,15 +281,3 @@ func formatMetricValue(v interface{}) string {
 		return fmt.Sprintf("%v", val)
 	}
 }
-
-func metricOutputToTarget(content string) {
-	if metricOutput != "" {
-		if err := os.WriteFile(metricOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", metricOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", metricOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/cobra"
+)
+
+var exporterListen string
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve Prometheus metrics for the cron jobs on this host",
+	Long: `
+Cronitor exporter serves the state of the cron jobs on this host in the Prometheus text format at /metrics.
+
+Metrics are read from the crontabs and from the outcome of each run that 'cronitor exec' records in the state
+directory, so they are available even when this host cannot reach Cronitor. The dashboard serves the same
+metrics at /metrics, behind its login, where cronitor_job_info also has the monitor code of each job. The
+exporter has no login, so it leaves codes out: a monitor code is enough to send pings to its monitor.
+
+Metrics:
+  cronitor_job_info                              Schedule and user of each job
+  cronitor_job_monitored                         1 when the job is wrapped with cronitor exec
+  cronitor_job_ignored                           1 when the job is ignored by cronitor sync
+  cronitor_job_suspended                         1 when the job is commented out
+  cronitor_job_running_instances                 Instances of the job running now
+  cronitor_job_next_run_timestamp_seconds        When the job is next scheduled to run
+  cronitor_job_last_exit_code                    Exit code of the last run
+  cronitor_job_last_duration_seconds             Duration of the last run
+  cronitor_job_last_run_timestamp_seconds        When the last run finished
+  cronitor_job_last_success_timestamp_seconds    When the last successful run finished
+
+Example:
+  $ cronitor exporter
+      > Serve metrics on port 9469
+
+  $ cronitor exporter --listen 127.0.0.1:9100
+      > Serve metrics on another address
+	`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/metrics", metricsHandler(false))
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, "<html><body><a href=\"/metrics\">Metrics</a></body></html>\n")
+		})
+
+		server := &http.Server{
+			Addr:         exporterListen,
+			Handler:      mux,
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 30 * time.Second,
+		}
+
+		printSuccessText(fmt.Sprintf("Serving metrics at http://%s/metrics", displayListenAddress(exporterListen)), false)
+		if err := server.ListenAndServe(); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(exporterCmd)
+	exporterCmd.Flags().StringVar(&exporterListen, "listen", ":9469", "Address to serve metrics on")
+}
+
+// displayListenAddress turns a listen address like :9469 into one that can be opened in a browser
+func displayListenAddress(address string) string {
+	if strings.HasPrefix(address, ":") {
+		return "localhost" + address
+	}
+	return address
+}
+
+// lastRunDir is where `cronitor exec` keeps the outcome of the last run of each job, inside the state directory
+func lastRunDir() string {
+	return filepath.Join(stateDir(), "last-runs")
+}
+
+// metricsHandler serves job metrics in the Prometheus text format. Monitor codes are only included
+// when the metrics are served behind a login.
+func metricsHandler(withCodes bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		families, err := collectJobMetrics(time.Now(), withCodes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, families)
+	}
+}
+
+type metricSample struct {
+	labels []string // name, value pairs
+	value  float64
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+func (f *metricFamily) add(value float64, labels ...string) {
+	f.samples = append(f.samples, metricSample{labels: labels, value: value})
+}
+
+// collectJobMetrics builds a metric family per job metric from the crontabs, running instances and last runs
+func collectJobMetrics(now time.Time, withCodes bool) ([]*metricFamily, error) {
+	crontabs, err := lib.GetAllCrontabs(parseUsers())
+	if err != nil {
+		return nil, err
+	}
+
+	lastRuns, err := lib.ReadLastRuns(lastRunDir())
+	if err != nil {
+		log(fmt.Sprintf("Failed to read last runs: %v", err))
+	}
+	registered := registeredInstances()
+
+	info := &metricFamily{name: "cronitor_job_info", help: "Schedule and user of each cron job"}
+	if withCodes {
+		info.help = "Schedule, user and monitor code of each cron job"
+	}
+	monitored := &metricFamily{name: "cronitor_job_monitored", help: "Whether the job is wrapped with cronitor exec"}
+	ignored := &metricFamily{name: "cronitor_job_ignored", help: "Whether the job is ignored by cronitor sync"}
+	suspended := &metricFamily{name: "cronitor_job_suspended", help: "Whether the job is commented out"}
+	running := &metricFamily{name: "cronitor_job_running_instances", help: "Instances of the job running now"}
+	nextRun := &metricFamily{name: "cronitor_job_next_run_timestamp_seconds", help: "When the job is next scheduled to run"}
+	lastExitCode := &metricFamily{name: "cronitor_job_last_exit_code", help: "Exit code of the last run"}
+	lastDuration := &metricFamily{name: "cronitor_job_last_duration_seconds", help: "Duration of the last run"}
+	lastRunAt := &metricFamily{name: "cronitor_job_last_run_timestamp_seconds", help: "When the last run finished"}
+	lastSuccessAt := &metricFamily{name: "cronitor_job_last_success_timestamp_seconds", help: "When the last successful run finished"}
+
+	for _, crontab := range crontabs {
+		location := time.Local
+		if crontab.TimezoneLocationName != nil {
+			if loaded, err := time.LoadLocation(crontab.TimezoneLocationName.Name); err == nil {
+				location = loaded
+			}
+		}
+
+		for _, line := range crontab.Lines {
+			if !line.IsJob {
+				continue
+			}
+
+			key := line.Key(crontab.CanonicalName())
+			labels := []string{"key", key, "crontab", crontab.DisplayName(), "name", line.Name}
+
+			info.add(1, jobInfoLabels(labels, crontab, line, withCodes)...)
+			monitored.add(boolMetric(line.Code != ""), labels...)
+			ignored.add(boolMetric(line.Ignored), labels...)
+			suspended.add(boolMetric(line.IsComment), labels...)
+
+			instances := 0
+			if !line.IsComment {
+				instances = len(findJobInstances(registered, line.Code, []string{line.CommandToRun}))
+			}
+			running.add(float64(instances), labels...)
+
+			if !line.IsComment {
+				if next, err := lib.NextRun(line.CronExpression, crontab.UsesSixFieldExpressions, location, now); err == nil {
+					nextRun.add(float64(next.Unix()), labels...)
+				}
+			}
+
+			if lastRun, ok := jobLastRun(lastRuns, crontab, line); ok {
+				lastExitCode.add(float64(lastRun.ExitCode), labels...)
+				lastDuration.add(lastRun.Duration().Seconds(), labels...)
+				lastRunAt.add(float64(lastRun.EndedAt.Unix()), labels...)
+				if !lastRun.LastSuccessAt.IsZero() {
+					lastSuccessAt.add(float64(lastRun.LastSuccessAt.Unix()), labels...)
+				}
+			}
+		}
+	}
+
+	return []*metricFamily{info, monitored, ignored, suspended, running, nextRun, lastExitCode, lastDuration, lastRunAt, lastSuccessAt}, nil
+}
+
+// jobInfoLabels returns the labels of cronitor_job_info for a job
+func jobInfoLabels(labels []string, crontab *lib.Crontab, line *lib.Line, withCodes bool) []string {
+	labels = append(labels, "schedule", line.CronExpression, "run_as", jobRunAsUser(crontab, line))
+	if withCodes {
+		labels = append(labels, "code", line.Code)
+	}
+	return labels
+}
+
+// jobLastRun returns the last run of a job, unless it was recorded by a user other than the one the job runs as
+func jobLastRun(lastRuns map[string]lib.LastRun, crontab *lib.Crontab, line *lib.Line) (lib.LastRun, bool) {
+	lastRun, ok := lastRuns[line.Code]
+	if !ok || line.Code == "" {
+		return lastRun, false
+	}
+	if runAs := jobRunAsUser(crontab, line); runAs != "" && lastRun.User != "" && lastRun.User != runAs {
+		return lastRun, false
+	}
+	return lastRun, true
+}
+
+func boolMetric(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// writeMetrics writes metric families in the Prometheus text exposition format
+func writeMetrics(w io.Writer, families []*metricFamily) {
+	for _, family := range families {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", family.name, family.help, family.name)
+
+		lines := make([]string, 0, len(family.samples))
+		for _, sample := range family.samples {
+			pairs := make([]string, 0, len(sample.labels)/2)
+			for i := 0; i+1 < len(sample.labels); i += 2 {
+				pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", sample.labels[i], escapeLabelValue(sample.labels[i+1])))
+			}
+			lines = append(lines, fmt.Sprintf("%s{%s} %s\n", family.name, strings.Join(pairs, ","), strconv.FormatFloat(sample.value, 'g', -1, 64)))
+		}
+
+		sort.Strings(lines)
+		for _, line := range lines {
+			io.WriteString(w, line)
+		}
+	}
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	family := &metricFamily{name: "cronitor_job_last_exit_code", help: "Exit code of the last run"}
+	family.add(2, "key", "b", "name", `say "hi"`)
+	family.add(0, "key", "a", "name", "line\nbreak")
+
+	var output bytes.Buffer
+	writeMetrics(&output, []*metricFamily{family})
+
+	expected := strings.Join([]string{
+		"# HELP cronitor_job_last_exit_code Exit code of the last run",
+		"# TYPE cronitor_job_last_exit_code gauge",
+		`cronitor_job_last_exit_code{key="a",name="line\nbreak"} 0`,
+		`cronitor_job_last_exit_code{key="b",name="say \"hi\""} 2`,
+		"",
+	}, "\n")
+	if output.String() != expected {
+		t.Errorf("unexpected metrics output:\n%s", output.String())
+	}
+}
+
+func TestJobInfoLabelsOnlyHaveCodesBehindALogin(t *testing.T) {
+	crontab := &lib.Crontab{Filename: "/etc/crontab"}
+	line := &lib.Line{IsJob: true, CronExpression: "0 * * * *", RunAs: "root", Code: "abc123"}
+
+	if labels := strings.Join(jobInfoLabels(nil, crontab, line, false), ","); strings.Contains(labels, "abc123") {
+		t.Errorf("expected the exporter to leave monitor codes out, got %s", labels)
+	}
+	if labels := strings.Join(jobInfoLabels(nil, crontab, line, true), ","); !strings.Contains(labels, "code,abc123") {
+		t.Errorf("expected the dashboard metrics to include monitor codes, got %s", labels)
+	}
+}
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -70,7 +73,7 @@ func init() {
 	// Persistent flags for all monitor subcommands
 	monitorCmd.PersistentFlags().IntVar(&monitorPage, "page", 1, "Page number for paginated results")
 	monitorCmd.PersistentFlags().StringVar(&monitorEnv, "env", "", "Filter by environment")
-	monitorCmd.PersistentFlags().StringVar(&monitorFormat, "format", "", "Output format: json, table, yaml")
+	monitorCmd.PersistentFlags().StringVar(&monitorFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	monitorCmd.PersistentFlags().StringVarP(&monitorOutput, "output", "o", "", "Write output to file")
 }
 
@@ -94,123 +97,61 @@ Examples:
   cronitor monitor list --page-size 100
   cronitor monitor list --format yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if monitorPage > 1 {
-			params["page"] = fmt.Sprintf("%d", monitorPage)
-		}
-		if monitorPageSize > 0 {
-			params["pageSize"] = fmt.Sprintf("%d", monitorPageSize)
-		}
-		if monitorEnv != "" {
-			params["env"] = monitorEnv
-		}
-		if monitorGroup != "" {
-			params["group"] = monitorGroup
-		}
-		if monitorSearch != "" {
-			params["search"] = monitorSearch
-		}
-		if monitorSort != "" {
-			params["sort"] = monitorSort
-		}
-
-		// Handle array params by joining with comma (API may need multiple params)
-		if len(monitorType) > 0 {
-			params["type"] = strings.Join(monitorType, ",")
-		}
-		if len(monitorTag) > 0 {
-			params["tag"] = strings.Join(monitorTag, ",")
-		}
-		if len(monitorState) > 0 {
-			params["state"] = strings.Join(monitorState, ",")
-		}
-		if monitorWithEvents {
-			params["withEvents"] = "true"
-		}
-		if monitorWithInvocations {
-			params["withInvocations"] = "true"
-		}
-
-		// Check for YAML format
-		format := monitorFormat
-		if format == "yaml" {
-			params["format"] = "yaml"
-		}
-
-		resp, err := client.GET("/monitors", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to list monitors: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		// YAML format - output directly
-		if format == "yaml" {
-			outputToTarget(string(resp.Body))
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.MonitorListOptions{
+			ListOptions:     api.ListOptions{Page: monitorPage, PageSize: monitorPageSize, Concurrency: listConcurrency},
+			Env:             monitorEnv,
+			Group:           monitorGroup,
+			Search:          monitorSearch,
+			Sort:            monitorSort,
+			Types:           monitorType,
+			Tags:            monitorTag,
+			States:          monitorState,
+			WithEvents:      monitorWithEvents,
+			WithInvocations: monitorWithInvocations,
+		}
+
+		// YAML format - output the API's config directly
+		format := outputFormat(monitorFormat, "table")
+		if format == "yaml" && listAll && !outputFiltered() {
+			writeOutput(monitorOutput, exportMonitorsYAML(cmd.Context(), client, opts))
 			return
 		}
-
-		// Parse response
-		var result struct {
-			Monitors []struct {
-				Key     string `json:"key"`
-				Name    string `json:"name"`
-				Type    string `json:"type"`
-				Passing bool   `json:"passing"`
-				Paused  bool   `json:"paused"`
-				Group   string `json:"group"`
-			} `json:"monitors"`
-			PageInfo struct {
-				Page       int `json:"page"`
-				PageSize   int `json:"pageSize"`
-				TotalCount int `json:"totalMonitorCount"`
-			} `json:"page_info"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
-
-		if format == "" {
-			format = "table"
+		if format == "yaml" && !outputFiltered() {
+			body, err := client.Monitors.ListYAML(cmd.Context(), opts)
+			if err != nil {
+				exitOnAPIError(err, "list monitors", "")
+			}
+			writeOutput(monitorOutput, string(body))
+			return
 		}
 
-		if format == "json" {
-			outputToTarget(FormatJSON(resp.Body))
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.Monitors.List, client.Monitors.All), format, monitorOutput, monitorColumns)
 			return
 		}
 
-		// Table output
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "TYPE", "STATUS"},
+		page, err := client.Monitors.List(cmd.Context(), opts)
+		if err != nil {
+			exitOnAPIError(err, "list monitors", "")
 		}
 
-		for _, m := range result.Monitors {
-			name := m.Name
-			if name == "" {
-				name = m.Key
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: monitorColumns.headers,
 			}
-			status := successStyle.Render("passing")
-			if m.Paused {
-				status = warningStyle.Render("paused")
-			} else if !m.Passing {
-				status = errorStyle.Render("failing")
+
+			for _, m := range page.Items {
+				table.Rows = append(table.Rows, monitorTableRow(m))
 			}
-			table.Rows = append(table.Rows, []string{name, m.Key, m.Type, status})
-		}
 
-		output := table.Render()
-		if result.PageInfo.TotalCount > 0 {
-			output += mutedStyle.Render(fmt.Sprintf("\nShowing page %d • %d monitors total",
-				result.PageInfo.Page, result.PageInfo.TotalCount))
-		}
-		outputToTarget(output)
+			output := table.Render()
+			if page.Total > 0 {
+				output += mutedStyle.Render(fmt.Sprintf("\nShowing page %d • %d monitors total",
+					page.Page, page.Total))
+			}
+			return output
+		}).print(format, monitorOutput)
 	},
 }
 
@@ -230,73 +171,32 @@ Examples:
   cronitor monitor export --group production           # Export one group
   cronitor monitor export -o backup.yaml && cronitor monitor create -f backup.yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if monitorEnv != "" {
-			params["env"] = monitorEnv
-		}
-		if monitorGroup != "" {
-			params["group"] = monitorGroup
-		}
-		if len(monitorType) > 0 {
-			params["type"] = strings.Join(monitorType, ",")
-		}
-		if len(monitorTag) > 0 {
-			params["tag"] = strings.Join(monitorTag, ",")
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.MonitorListOptions{
+			ListOptions: api.ListOptions{Concurrency: listConcurrency},
+			Env:         monitorEnv,
+			Group:       monitorGroup,
+			Types:       monitorType,
+			Tags:        monitorTag,
 		}
 
-		// First, get page 1 as JSON to determine total page count
-		resp, err := client.GET("/monitors", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to export monitors: %s", err))
-			os.Exit(1)
-		}
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		var pageInfo struct {
-			PageInfo struct {
-				Page       int `json:"page"`
-				PageSize   int `json:"pageSize"`
-				TotalCount int `json:"totalMonitorCount"`
-			} `json:"page_info"`
-		}
-		json.Unmarshal(resp.Body, &pageInfo)
+		writeOutput(monitorOutput, exportMonitorsYAML(cmd.Context(), client, opts))
+	},
+}
 
-		totalPages := 1
-		if pageInfo.PageInfo.PageSize > 0 && pageInfo.PageInfo.TotalCount > 0 {
-			totalPages = (pageInfo.PageInfo.TotalCount + pageInfo.PageInfo.PageSize - 1) / pageInfo.PageInfo.PageSize
+// exportMonitorsYAML fetches every page of monitors matching opts as one YAML document
+func exportMonitorsYAML(ctx context.Context, client *api.Client, opts *api.MonitorListOptions) string {
+	var combined string
+	for config, err := range client.Monitors.ExportYAML(ctx, opts) {
+		if err != nil {
+			exitOnAPIError(err, "export monitors", "")
 		}
-
-		// Now fetch all pages as YAML
-		params["format"] = "yaml"
-		var combined string
-		for page := 1; page <= totalPages; page++ {
-			params["page"] = fmt.Sprintf("%d", page)
-			resp, err := client.GET("/monitors", params)
-			if err != nil {
-				Error(fmt.Sprintf("Failed to export monitors (page %d): %s", page, err))
-				os.Exit(1)
-			}
-			if !resp.IsSuccess() {
-				Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-				os.Exit(1)
-			}
-			body := strings.TrimSpace(string(resp.Body))
-			if body == "" {
-				break
-			}
-			if combined != "" {
-				combined += "\n"
-			}
-			combined += body
+		if combined != "" {
+			combined += "\n"
 		}
-
-		outputToTarget(combined)
-	},
+		combined += strings.TrimSpace(string(config))
+	}
+	return combined
 }
 
 // --- SEARCH ---
@@ -323,72 +223,34 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		query := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.ListOptions{Page: monitorPage}
 
-		params := map[string]string{"query": query}
-		if monitorPage > 1 {
-			params["page"] = fmt.Sprintf("%d", monitorPage)
-		}
-
-		format := monitorFormat
-		if format == "yaml" {
-			params["format"] = "yaml"
-		}
-
-		resp, err := client.GET("/search", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to search: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		// YAML format - output directly
-		if format == "yaml" {
-			outputToTarget(string(resp.Body))
-			return
-		}
-
-		if format == "json" || format == "" {
-			outputToTarget(FormatJSON(resp.Body))
+		// YAML format - output the API's config directly
+		format := outputFormat(monitorFormat, "json")
+		if format == "yaml" && !outputFiltered() {
+			body, err := client.Monitors.SearchYAML(cmd.Context(), query, opts)
+			if err != nil {
+				exitOnAPIError(err, "search", "")
+			}
+			writeOutput(monitorOutput, string(body))
 			return
 		}
 
-		// Parse for table output
-		var result struct {
-			Monitors []struct {
-				Key     string `json:"key"`
-				Name    string `json:"name"`
-				Type    string `json:"type"`
-				Passing bool   `json:"passing"`
-				Paused  bool   `json:"paused"`
-			} `json:"monitors"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			outputToTarget(FormatJSON(resp.Body))
-			return
+		page, err := client.Monitors.Search(cmd.Context(), query, opts)
+		if err != nil {
+			exitOnAPIError(err, "search", "")
 		}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "TYPE", "STATUS"},
-		}
-		for _, m := range result.Monitors {
-			name := m.Name
-			if name == "" {
-				name = m.Key
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: monitorColumns.headers,
 			}
-			status := successStyle.Render("passing")
-			if m.Paused {
-				status = warningStyle.Render("paused")
-			} else if !m.Passing {
-				status = errorStyle.Render("failing")
+			for _, m := range page.Items {
+				table.Rows = append(table.Rows, monitorTableRow(m))
 			}
-			table.Rows = append(table.Rows, []string{name, m.Key, m.Type, status})
-		}
-		outputToTarget(table.Render())
+			return table.Render()
+		}).print(format, monitorOutput)
 	},
 }
 
@@ -405,33 +267,17 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		params := make(map[string]string)
-		if monitorWithEvents {
-			params["withEvents"] = "true"
-		}
-		if monitorWithInvocations {
-			params["withInvocations"] = "true"
-		}
-
-		resp, err := client.GET(fmt.Sprintf("/monitors/%s", key), params)
+		monitor, err := client.Monitors.Get(cmd.Context(), key, &api.MonitorGetOptions{
+			WithEvents:      monitorWithEvents,
+			WithInvocations: monitorWithInvocations,
+		})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get monitor: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Monitor '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "get monitor", fmt.Sprintf("Monitor '%s' not found", key))
 		}
 
-		outputToTarget(FormatJSON(resp.Body))
+		output{raw: monitor.Raw()}.print(outputFormat(monitorFormat, "json"), monitorOutput)
 	},
 }
 
@@ -458,7 +304,7 @@ Examples:
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
 		// Check if bulk create (array) or YAML
 		var testArray []json.RawMessage
@@ -471,28 +317,37 @@ Examples:
 			strings.HasPrefix(bodyStr, "heartbeats:") ||
 			strings.HasPrefix(bodyStr, "sites:")
 
-		var resp *lib.APIResponse
+		var result []byte
 		if isYAML {
-			headers := map[string]string{"Content-Type": "application/yaml"}
-			resp, err = client.PUT("/monitors", body, headers)
+			result, err = client.Monitors.ApplyYAML(cmd.Context(), body)
 		} else if isBulk {
-			resp, err = client.PUT("/monitors", body, nil)
+			var monitors []*api.Monitor
+			if err := json.Unmarshal(body, &monitors); err != nil {
+				Error(fmt.Sprintf("Invalid JSON: %s", err))
+				os.Exit(1)
+			}
+			var created []api.Monitor
+			if created, err = client.Monitors.Upsert(cmd.Context(), monitors); err == nil {
+				result = monitorsJSON(created)
+			}
 		} else {
-			resp, err = client.POST("/monitors", body, nil)
+			var monitor api.Monitor
+			if err := json.Unmarshal(body, &monitor); err != nil {
+				Error(fmt.Sprintf("Invalid JSON: %s", err))
+				os.Exit(1)
+			}
+			var created *api.Monitor
+			if created, err = client.Monitors.Create(cmd.Context(), &monitor); err == nil {
+				result = created.Raw()
+			}
 		}
 
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create monitor: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "create monitor", "")
 		}
 
 		Success("Monitor created")
-		outputToTarget(FormatJSON(resp.Body))
+		output{raw: result}.print(outputFormat(monitorFormat, "json"), monitorOutput)
 	},
 }
 
@@ -519,35 +374,20 @@ Examples:
 			os.Exit(1)
 		}
 
-		// Parse and add key
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal(body, &bodyMap); err != nil {
+		var monitor api.Monitor
+		if err := json.Unmarshal(body, &monitor); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ = json.Marshal(bodyMap)
-		body = []byte(fmt.Sprintf("[%s]", string(body)))
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT("/monitors", body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Monitors.Update(cmd.Context(), key, &monitor)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update monitor: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Monitor '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update monitor", fmt.Sprintf("Monitor '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Monitor '%s' updated", key))
-		outputToTarget(FormatJSON(resp.Body))
+		output{raw: updated.Raw()}.print(outputFormat(monitorFormat, "json"), monitorOutput)
 	},
 }
 
@@ -562,52 +402,21 @@ Examples:
   cronitor monitor delete job1 job2 job3`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
 		if len(args) == 1 {
 			// Single delete
 			key := args[0]
-			resp, err := client.DELETE(fmt.Sprintf("/monitors/%s", key), nil, nil)
-			if err != nil {
-				Error(fmt.Sprintf("Failed to delete monitor: %s", err))
-				os.Exit(1)
-			}
-
-			if resp.IsNotFound() {
-				Error(fmt.Sprintf("Monitor '%s' not found", key))
-				os.Exit(1)
-			}
-
-			if resp.IsSuccess() {
-				Success(fmt.Sprintf("Monitor '%s' deleted", key))
-			} else {
-				Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-				os.Exit(1)
+			if err := client.Monitors.Delete(cmd.Context(), key); err != nil {
+				exitOnAPIError(err, "delete monitor", fmt.Sprintf("Monitor '%s' not found", key))
 			}
+			Success(fmt.Sprintf("Monitor '%s' deleted", key))
 		} else {
 			// Bulk delete
-			body := map[string][]string{"monitors": args}
-			bodyJSON, _ := json.Marshal(body)
-
-			resp, err := client.DELETE("/monitors", bodyJSON, nil)
+			result, err := client.Monitors.DeleteMany(cmd.Context(), args)
 			if err != nil {
-				Error(fmt.Sprintf("Failed to delete monitors: %s", err))
-				os.Exit(1)
-			}
-
-			if !resp.IsSuccess() {
-				Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-				os.Exit(1)
-			}
-
-			var result struct {
-				DeletedCount   int `json:"deleted_count"`
-				RequestedCount int `json:"requested_count"`
-				Errors         struct {
-					Missing []string `json:"missing"`
-				} `json:"errors"`
+				exitOnAPIError(err, "delete monitors", "")
 			}
-			json.Unmarshal(resp.Body, &result)
 
 			Success(fmt.Sprintf("Deleted %d of %d monitors", result.DeletedCount, result.RequestedCount))
 			if len(result.Errors.Missing) > 0 {
@@ -631,38 +440,15 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		body := map[string]string{"key": key}
-		if monitorCloneName != "" {
-			body["name"] = monitorCloneName
-		}
-		bodyJSON, _ := json.Marshal(body)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.POST("/monitors/clone", bodyJSON, nil)
+		clone, err := client.Monitors.Clone(cmd.Context(), key, monitorCloneName)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to clone monitor: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Monitor '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "clone monitor", fmt.Sprintf("Monitor '%s' not found", key))
 		}
 
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		json.Unmarshal(resp.Body, &result)
-
-		Success(fmt.Sprintf("Monitor cloned as '%s'", result.Key))
-		outputToTarget(FormatJSON(resp.Body))
+		Success(fmt.Sprintf("Monitor cloned as '%s'", clone.Key))
+		output{raw: clone.Raw()}.print(outputFormat(monitorFormat, "json"), monitorOutput)
 	},
 }
 
@@ -684,33 +470,24 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		endpoint := fmt.Sprintf("/monitors/%s/pause", key)
-		if monitorPauseHours != "" && monitorPauseHours != "0" {
-			endpoint = fmt.Sprintf("%s/%s", endpoint, monitorPauseHours)
-		}
-
-		resp, err := client.GET(endpoint, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to pause monitor: %s", err))
-			os.Exit(1)
+		hours := 0
+		if monitorPauseHours != "" {
+			var err error
+			if hours, err = strconv.Atoi(monitorPauseHours); err != nil || hours < 0 {
+				Error(fmt.Sprintf("Invalid --hours '%s': expected a whole number of hours", monitorPauseHours))
+				os.Exit(1)
+			}
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Monitor '%s' not found", key))
-			os.Exit(1)
+		client := lib.NewCronitorClient(dev, log)
+		if err := client.Monitors.Pause(cmd.Context(), key, hours); err != nil {
+			exitOnAPIError(err, "pause monitor", fmt.Sprintf("Monitor '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			if monitorPauseHours != "" && monitorPauseHours != "0" {
-				Success(fmt.Sprintf("Monitor '%s' paused for %s hours", key, monitorPauseHours))
-			} else {
-				Success(fmt.Sprintf("Monitor '%s' paused", key))
-			}
+		if hours > 0 {
+			Success(fmt.Sprintf("Monitor '%s' paused for %d hours", key, hours))
 		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			Success(fmt.Sprintf("Monitor '%s' paused", key))
 		}
 	},
 }
@@ -726,25 +503,13 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		resp, err := client.GET(fmt.Sprintf("/monitors/%s/pause/0", key), nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to unpause monitor: %s", err))
-			os.Exit(1)
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Monitor '%s' not found", key))
-			os.Exit(1)
+		if err := client.Monitors.Unpause(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "unpause monitor", fmt.Sprintf("Monitor '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Monitor '%s' unpaused", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Monitor '%s' unpaused", key))
 	},
 }
 
@@ -770,6 +535,7 @@ func init() {
 	monitorListCmd.Flags().StringVar(&monitorSort, "sort", "", "Sort order: created, -created, name, -name")
 	monitorListCmd.Flags().BoolVar(&monitorWithEvents, "with-events", false, "Include latest events for each monitor")
 	monitorListCmd.Flags().BoolVar(&monitorWithInvocations, "with-invocations", false, "Include recent invocations for each monitor")
+	addListAllFlags(monitorListCmd)
 
 	// Get flags
 	monitorGetCmd.Flags().BoolVar(&monitorWithEvents, "with-events", false, "Include latest events")
@@ -832,14 +598,33 @@ func getMonitorRequestBody() ([]byte, error) {
 	return nil, nil
 }
 
-func outputToTarget(content string) {
-	if monitorOutput != "" {
-		if err := os.WriteFile(monitorOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", monitorOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", monitorOutput))
-	} else {
-		fmt.Println(content)
+var monitorColumns = listColumns[api.Monitor]{
+	noun:    "monitors",
+	headers: []string{"NAME", "KEY", "TYPE", "STATUS"},
+	row:     monitorTableRow,
+}
+
+// monitorTableRow renders a monitor for the list and search tables
+func monitorTableRow(m api.Monitor) []string {
+	name := m.Name
+	if name == "" {
+		name = m.Key
+	}
+	status := successStyle.Render("passing")
+	if m.Paused {
+		status = warningStyle.Render("paused")
+	} else if !m.Passing {
+		status = errorStyle.Render("failing")
+	}
+	return []string{name, m.Key, m.Type, status}
+}
+
+// monitorsJSON re-assembles monitors into the JSON array the API returned them in
+func monitorsJSON(monitors []api.Monitor) []byte {
+	items := make([]json.RawMessage, 0, len(monitors))
+	for _, m := range monitors {
+		items = append(items, m.Raw())
 	}
+	data, _ := json.Marshal(items)
+	return data
 }
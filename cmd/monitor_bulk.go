@@ -0,0 +1,515 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	monitorBulkSelector    string
+	monitorBulkHours       int
+	monitorBulkConcurrency int
+	monitorBulkDryRun      bool
+	monitorBulkYes         bool
+)
+
+var monitorBulkCmd = &cobra.Command{
+	Use:   "bulk <action> [value]",
+	Short: "Change many monitors at once",
+	Long: `Apply one change to every monitor matching --selector, or to monitor keys read from stdin.
+
+Actions:
+  pause                 Pause alerts, indefinitely or for --hours
+  unpause               Resume alerts
+  add-tag <tags>        Add comma-separated tags
+  remove-tag <tags>     Remove comma-separated tags
+  notify <lists>        Set the notification lists, comma-separated
+  group <key>           Move the monitors to a group
+  grace <seconds>       Set the grace period
+  delete                Delete the monitors (requires --yes)
+
+A selector is a comma-separated list of filters: tag, state, type, group, env and search.
+Repeat tag, state or type to match any of several values.
+
+Examples:
+  cronitor monitor bulk pause --selector 'tag=db,state=failing' --hours 4
+  cronitor monitor bulk add-tag critical --selector 'type=job,group=production'
+  cronitor monitor bulk grace 300 --selector 'tag=nightly' --dry-run
+  cronitor monitor list --tag legacy --format 'template={{.key}}' | cronitor monitor bulk delete --yes`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		value := ""
+		if len(args) > 1 {
+			value = args[1]
+		}
+		action, err := newMonitorBulkAction(args[0], value, monitorBulkHours)
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+
+		client := lib.NewCronitorClient(dev, log)
+		monitors, failures := selectMonitors(cmd.Context(), client)
+		if len(monitors) == 0 && len(failures) == 0 {
+			Info("No monitors match the selector")
+			return
+		}
+
+		var changes []monitorChange
+		unchanged := 0
+		for _, m := range monitors {
+			if change := action.describe(m); change != "" {
+				changes = append(changes, monitorChange{monitor: m, change: change})
+			} else {
+				unchanged++
+			}
+		}
+
+		if monitorBulkDryRun {
+			printMonitorChanges(changes, unchanged)
+			printBulkFailures(failures)
+			return
+		}
+
+		if action.name == "delete" && !monitorBulkYes && len(changes) > 0 {
+			Error(fmt.Sprintf("This deletes %d monitors. Re-run with --yes to confirm, or --dry-run to see which.", len(changes)))
+			os.Exit(1)
+		}
+
+		failures = append(failures, applyMonitorChanges(cmd.Context(), changes, monitorBulkConcurrency, func(ctx context.Context, m api.Monitor) error {
+			return action.apply(ctx, client, m)
+		})...)
+
+		done := len(changes) - (len(failures) - countUnresolved(failures))
+		summary := fmt.Sprintf("%s %d of %d monitors", action.past, done, len(changes))
+		if unchanged > 0 {
+			summary += fmt.Sprintf(" (%d already up to date)", unchanged)
+		}
+		Success(summary)
+
+		if len(failures) > 0 {
+			printBulkFailures(failures)
+			os.Exit(1)
+		}
+	},
+}
+
+// monitorBulkAction is one change that `monitor bulk` applies to each selected monitor
+type monitorBulkAction struct {
+	name string
+	past string
+
+	// describe returns the change the action makes to a monitor, or "" when it makes none
+	describe func(m api.Monitor) string
+	apply    func(ctx context.Context, client *api.Client, m api.Monitor) error
+}
+
+func newMonitorBulkAction(name, value string, hours int) (*monitorBulkAction, error) {
+	needsValue := map[string]string{
+		"add-tag":    "a comma-separated list of tags",
+		"remove-tag": "a comma-separated list of tags",
+		"notify":     "a comma-separated list of notification list keys",
+		"group":      "a group key",
+		"grace":      "a number of seconds",
+	}
+	if what, ok := needsValue[name]; ok && value == "" {
+		return nil, fmt.Errorf("%s needs %s, e.g. cronitor monitor bulk %s <value> --selector ...", name, what, name)
+	} else if !ok && value != "" {
+		return nil, fmt.Errorf("%s does not take a value", name)
+	}
+	if hours != 0 && name != "pause" {
+		return nil, fmt.Errorf("--hours only applies to pause")
+	}
+
+	switch name {
+	case "pause":
+		if hours < 0 {
+			return nil, fmt.Errorf("invalid --hours %d: expected a whole number of hours", hours)
+		}
+		return &monitorBulkAction{
+			name: name,
+			past: "Paused",
+			describe: func(m api.Monitor) string {
+				if hours > 0 {
+					return fmt.Sprintf("pause for %d hours", hours)
+				}
+				if m.Paused {
+					return ""
+				}
+				return "pause"
+			},
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return client.Monitors.Pause(ctx, m.Key, hours)
+			},
+		}, nil
+
+	case "unpause":
+		return &monitorBulkAction{
+			name: name,
+			past: "Unpaused",
+			describe: func(m api.Monitor) string {
+				if !m.Paused {
+					return ""
+				}
+				return "unpause"
+			},
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return client.Monitors.Unpause(ctx, m.Key)
+			},
+		}, nil
+
+	case "add-tag", "remove-tag":
+		tags := splitList(value)
+		retag := func(m api.Monitor) []string {
+			if name == "add-tag" {
+				updated := slices.Clone(m.Tags)
+				for _, tag := range tags {
+					if !slices.Contains(updated, tag) {
+						updated = append(updated, tag)
+					}
+				}
+				return updated
+			}
+			return slices.DeleteFunc(slices.Clone(m.Tags), func(tag string) bool { return slices.Contains(tags, tag) })
+		}
+		past := "Tagged"
+		if name == "remove-tag" {
+			past = "Untagged"
+		}
+		return &monitorBulkAction{
+			name: name,
+			past: past,
+			describe: func(m api.Monitor) string {
+				updated := retag(m)
+				if slices.Equal(updated, m.Tags) {
+					return ""
+				}
+				return fmt.Sprintf("tags: %s → %s", listOrNone(m.Tags), listOrNone(updated))
+			},
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return updateMonitorFields(ctx, client, m.Key, map[string]interface{}{"tags": retag(m)})
+			},
+		}, nil
+
+	case "notify":
+		lists := splitList(value)
+		return &monitorBulkAction{
+			name: name,
+			past: "Updated notifications for",
+			describe: func(m api.Monitor) string {
+				if slices.Equal(m.Notify, lists) {
+					return ""
+				}
+				return fmt.Sprintf("notify: %s → %s", listOrNone(m.Notify), listOrNone(lists))
+			},
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return updateMonitorFields(ctx, client, m.Key, map[string]interface{}{"notify": lists})
+			},
+		}, nil
+
+	case "group":
+		return &monitorBulkAction{
+			name: name,
+			past: "Moved",
+			describe: func(m api.Monitor) string {
+				if m.Group == value {
+					return ""
+				}
+				return fmt.Sprintf("group: %s → %s", valueOrNone(m.Group), value)
+			},
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return updateMonitorFields(ctx, client, m.Key, map[string]interface{}{"group": value})
+			},
+		}, nil
+
+	case "grace":
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid grace period '%s': expected a whole number of seconds", value)
+		}
+		return &monitorBulkAction{
+			name: name,
+			past: "Updated grace period for",
+			describe: func(m api.Monitor) string {
+				if m.GraceSeconds == seconds {
+					return ""
+				}
+				return fmt.Sprintf("grace: %ds → %ds", m.GraceSeconds, seconds)
+			},
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return updateMonitorFields(ctx, client, m.Key, map[string]interface{}{"grace_seconds": seconds})
+			},
+		}, nil
+
+	case "delete":
+		return &monitorBulkAction{
+			name:     name,
+			past:     "Deleted",
+			describe: func(m api.Monitor) string { return "delete" },
+			apply: func(ctx context.Context, client *api.Client, m api.Monitor) error {
+				return client.Monitors.Delete(ctx, m.Key)
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown action '%s'. Use: pause, unpause, add-tag, remove-tag, notify, group, grace, delete", name)
+}
+
+// updateMonitorFields changes only the given fields of a monitor. The patch is decoded from JSON
+// so that an emptied list, such as removing a monitor's last tag, is still sent to the API.
+func updateMonitorFields(ctx context.Context, client *api.Client, key string, fields map[string]interface{}) error {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	var patch api.Monitor
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return err
+	}
+	_, err = client.Monitors.Update(ctx, key, &patch)
+	return err
+}
+
+// parseMonitorSelector turns a selector such as 'tag=db,state=failing,type=job' into list filters
+func parseMonitorSelector(selector string) (*api.MonitorListOptions, error) {
+	opts := &api.MonitorListOptions{}
+	for _, term := range splitList(selector) {
+		field, value, ok := strings.Cut(term, "=")
+		field, value = strings.TrimSpace(field), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid selector '%s': expected field=value", term)
+		}
+		switch field {
+		case "tag":
+			opts.Tags = append(opts.Tags, value)
+		case "state":
+			if value != "passing" && value != "failing" && value != "paused" {
+				return nil, fmt.Errorf("invalid state '%s': expected passing, failing or paused", value)
+			}
+			opts.States = append(opts.States, value)
+		case "type":
+			opts.Types = append(opts.Types, value)
+		case "group":
+			opts.Group = value
+		case "env":
+			opts.Env = value
+		case "search":
+			opts.Search = value
+		default:
+			return nil, fmt.Errorf("unknown selector field '%s'. Use: tag, state, type, group, env, search", field)
+		}
+	}
+	return opts, nil
+}
+
+// selectMonitors returns the monitors matching --selector, or the monitors whose keys are read
+// from stdin. Keys that cannot be looked up are returned as failures.
+func selectMonitors(ctx context.Context, client *api.Client) ([]api.Monitor, []bulkFailure) {
+	if monitorBulkSelector != "" {
+		opts, err := parseMonitorSelector(monitorBulkSelector)
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+		opts.Concurrency = monitorBulkConcurrency
+
+		var monitors []api.Monitor
+		for m, err := range client.Monitors.All(ctx, opts) {
+			if err != nil {
+				exitOnAPIError(err, "list monitors", "")
+			}
+			monitors = append(monitors, m)
+		}
+		return monitors, nil
+	}
+
+	stat, _ := os.Stdin.Stat()
+	if stat == nil || stat.Mode()&os.ModeCharDevice != 0 {
+		Error("Choose monitors with --selector, or pipe monitor keys to stdin, one per line")
+		os.Exit(1)
+	}
+	keys := readMonitorKeys(os.Stdin)
+
+	monitors := make([]api.Monitor, len(keys))
+	found := make([]bool, len(keys))
+	var mu sync.Mutex
+	var failures []bulkFailure
+	forEachLimit(len(keys), monitorBulkConcurrency, func(i int) {
+		m, err := client.Monitors.Get(ctx, keys[i], nil)
+		if err != nil {
+			mu.Lock()
+			failures = append(failures, bulkFailure{key: keys[i], err: err, unresolved: true})
+			mu.Unlock()
+			return
+		}
+		monitors[i], found[i] = *m, true
+	})
+
+	var resolved []api.Monitor
+	for i, m := range monitors {
+		if found[i] {
+			resolved = append(resolved, m)
+		}
+	}
+	return resolved, failures
+}
+
+// readMonitorKeys reads one key per line, skipping blank lines and # comments
+func readMonitorKeys(r io.Reader) []string {
+	var keys []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key := strings.TrimSpace(scanner.Text())
+		if key == "" || strings.HasPrefix(key, "#") || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type monitorChange struct {
+	monitor api.Monitor
+	change  string
+}
+
+type bulkFailure struct {
+	key string
+	err error
+
+	// unresolved is set when the monitor could not be looked up, so no change was attempted
+	unresolved bool
+}
+
+func countUnresolved(failures []bulkFailure) int {
+	n := 0
+	for _, f := range failures {
+		if f.unresolved {
+			n++
+		}
+	}
+	return n
+}
+
+// applyMonitorChanges runs apply for each change, at most concurrency at a time, and returns the
+// monitors it failed for
+func applyMonitorChanges(ctx context.Context, changes []monitorChange, concurrency int, apply func(context.Context, api.Monitor) error) []bulkFailure {
+	var mu sync.Mutex
+	var failures []bulkFailure
+	forEachLimit(len(changes), concurrency, func(i int) {
+		if err := apply(ctx, changes[i].monitor); err != nil {
+			mu.Lock()
+			failures = append(failures, bulkFailure{key: changes[i].monitor.Key, err: err})
+			mu.Unlock()
+		}
+	})
+	slices.SortFunc(failures, func(a, b bulkFailure) int { return strings.Compare(a.key, b.key) })
+	return failures
+}
+
+// forEachLimit calls fn for 0..n-1 from at most limit goroutines
+func forEachLimit(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(limit, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+func printMonitorChanges(changes []monitorChange, unchanged int) {
+	if len(changes) == 0 {
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("No changes: all %d monitors are already up to date", unchanged)))
+		return
+	}
+	table := &UITable{
+		Headers: []string{"KEY", "NAME", "CHANGE"},
+	}
+	for _, c := range changes {
+		table.Rows = append(table.Rows, []string{c.monitor.Key, c.monitor.Name, c.change})
+	}
+	summary := fmt.Sprintf("\n%d monitors would change (dry run)", len(changes))
+	if unchanged > 0 {
+		summary += fmt.Sprintf(", %d already up to date", unchanged)
+	}
+	fmt.Println(table.Render() + mutedStyle.Render(summary))
+}
+
+func printBulkFailures(failures []bulkFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	Warning(fmt.Sprintf("%d monitors failed:", len(failures)))
+	table := &UITable{
+		Headers: []string{"KEY", "ERROR"},
+	}
+	for _, f := range failures {
+		message := f.err.Error()
+		if errors.Is(f.err, api.ErrNotFound) {
+			message = "not found"
+		}
+		table.Rows = append(table.Rows, []string{f.key, message})
+	}
+	fmt.Println(table.Render())
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "(none)"
+	}
+	return strings.Join(items, ", ")
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func init() {
+	monitorCmd.AddCommand(monitorBulkCmd)
+	monitorBulkCmd.Flags().StringVar(&monitorBulkSelector, "selector", "", "Monitors to change, e.g. 'tag=db,state=failing,type=job' (default: keys from stdin)")
+	monitorBulkCmd.Flags().IntVar(&monitorBulkHours, "hours", 0, "Hours to pause for with the pause action (default: indefinite)")
+	monitorBulkCmd.Flags().IntVar(&monitorBulkConcurrency, "concurrency", 4, "Number of monitors to change at once")
+	monitorBulkCmd.Flags().BoolVar(&monitorBulkDryRun, "dry-run", false, "Show what would change without changing anything")
+	monitorBulkCmd.Flags().BoolVar(&monitorBulkYes, "yes", false, "Confirm the delete action")
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/internal/testutil"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+const bulkMonitorsPage = `{"monitors":[{"key":"backup","name":"Nightly backup","tags":["db"]},{"key":"sync","name":"Sync","tags":["db","nightly"]}],"page_info":{"page":1,"pageSize":50,"totalMonitorCount":2}}`
+
+func resetMonitorBulkFlags() {
+	monitorBulkSelector, monitorBulkHours, monitorBulkConcurrency = "", 0, 4
+	monitorBulkDryRun, monitorBulkYes = false, false
+}
+
+func TestParseMonitorSelector(t *testing.T) {
+	opts, err := parseMonitorSelector("tag=db, state=failing,type=job,tag=nightly,group=production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(opts.Tags, ",") != "db,nightly" || strings.Join(opts.States, ",") != "failing" || strings.Join(opts.Types, ",") != "job" || opts.Group != "production" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	for _, selector := range []string{"tag", "state=broken", "owner=me", "tag="} {
+		if _, err := parseMonitorSelector(selector); err == nil {
+			t.Errorf("expected an error for %q", selector)
+		}
+	}
+}
+
+func TestMonitorBulkAction_Describe(t *testing.T) {
+	m := api.Monitor{Key: "backup", Tags: []string{"db", "nightly"}, GraceSeconds: 60}
+
+	tests := []struct {
+		action, value string
+		expected      string
+	}{
+		{"add-tag", "critical,db", "tags: db, nightly → db, nightly, critical"},
+		{"add-tag", "db", ""},
+		{"remove-tag", "db,nightly", "tags: db, nightly → (none)"},
+		{"grace", "60", ""},
+		{"grace", "300", "grace: 60s → 300s"},
+		{"group", "production", "group: (none) → production"},
+		{"unpause", "", ""},
+		{"pause", "", "pause"},
+	}
+	for _, tt := range tests {
+		action, err := newMonitorBulkAction(tt.action, tt.value, 0)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error: %v", tt.action, tt.value, err)
+		}
+		if got := action.describe(m); got != tt.expected {
+			t.Errorf("%s %s: expected %q, got %q", tt.action, tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestMonitorBulkAction_Invalid(t *testing.T) {
+	for _, args := range [][]string{{"add-tag", ""}, {"delete", "now"}, {"grace", "soon"}, {"archive", ""}} {
+		if _, err := newMonitorBulkAction(args[0], args[1], 0); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+	if _, err := newMonitorBulkAction("unpause", "", 4); err == nil {
+		t.Error("expected --hours to be rejected for unpause")
+	}
+}
+
+func TestApplyMonitorChanges_CollectsFailures(t *testing.T) {
+	var changes []monitorChange
+	for _, key := range []string{"d", "a", "c", "b"} {
+		changes = append(changes, monitorChange{monitor: api.Monitor{Key: key}})
+	}
+
+	var running, peak int32
+	failures := applyMonitorChanges(context.Background(), changes, 2, func(ctx context.Context, m api.Monitor) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		if m.Key == "d" || m.Key == "b" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if len(failures) != 2 || failures[0].key != "b" || failures[1].key != "d" {
+		t.Errorf("expected failures for b and d in key order, got %+v", failures)
+	}
+	if peak > 2 {
+		t.Errorf("expected at most 2 changes at once, saw %d", peak)
+	}
+}
+
+func TestIntegration_MonitorBulk_DryRunMakesNoChanges(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, bulkMonitorsPage)
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+	defer resetMonitorBulkFlags()
+
+	output, err := executeCmd("monitor", "bulk", "add-tag", "nightly", "--selector", "tag=db", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "backup") || !strings.Contains(output, "db, nightly") {
+		t.Errorf("expected the change for backup in the preview, got:\n%s", output)
+	}
+	if !strings.Contains(output, "1 monitors would change (dry run), 1 already up to date") {
+		t.Errorf("expected a dry run summary, got:\n%s", output)
+	}
+	for _, req := range mock.Requests {
+		if req.Method != "GET" {
+			t.Errorf("expected no writes during a dry run, got %s %s", req.Method, req.Path)
+		}
+	}
+	if got := mock.Requests[0].QueryParams.Get("tag"); got != "db" {
+		t.Errorf("expected the selector to filter by tag, got %q", got)
+	}
+}
+
+func TestIntegration_MonitorBulk_RemoveLastTag(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, bulkMonitorsPage)
+	mock.On("PUT", "/monitors", 200, `[{"key":"backup","tags":[]}]`)
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+	defer resetMonitorBulkFlags()
+
+	output, err := executeCmd("monitor", "bulk", "remove-tag", "db", "--selector", "tag=db", "--concurrency", "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Untagged 2 of 2 monitors") {
+		t.Errorf("expected a summary, got:\n%s", output)
+	}
+
+	var bodies []string
+	for _, req := range mock.Requests {
+		if req.Method == "PUT" {
+			bodies = append(bodies, req.Body)
+		}
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected an update per monitor, got %d", len(bodies))
+	}
+	if !strings.Contains(bodies[0], `"tags":[]`) || !strings.Contains(bodies[0], `"key":"backup"`) {
+		t.Errorf("expected the emptied tag list to be sent, got %s", bodies[0])
+	}
+	if strings.Contains(bodies[1], `"name"`) {
+		t.Errorf("expected only the changed fields to be sent, got %s", bodies[1])
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -55,8 +56,9 @@ func init() {
 	RootCmd.AddCommand(notificationCmd)
 	notificationCmd.PersistentFlags().IntVar(&notificationPage, "page", 1, "Page number")
 	notificationCmd.PersistentFlags().IntVar(&notificationPageSize, "page-size", 0, "Number of results per page")
-	notificationCmd.PersistentFlags().StringVar(&notificationFormat, "format", "", "Output format: json, table")
+	notificationCmd.PersistentFlags().StringVar(&notificationFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	notificationCmd.PersistentFlags().StringVarP(&notificationOutput, "output", "o", "", "Write output to file")
+	addListAllFlags(notificationListCmd)
 }
 
 // --- LIST ---
@@ -71,66 +73,43 @@ Examples:
   cronitor notification list --page-size 100
   cronitor notification list --format json`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-		if notificationPage > 1 {
-			params["page"] = fmt.Sprintf("%d", notificationPage)
-		}
-		if notificationPageSize > 0 {
-			params["pageSize"] = fmt.Sprintf("%d", notificationPageSize)
-		}
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.ListOptions{Page: notificationPage, PageSize: notificationPageSize, Concurrency: listConcurrency}
 
-		resp, err := client.GET("/notifications", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to list notification lists: %s", err))
-			os.Exit(1)
-		}
+		format := outputFormat(notificationFormat, "table")
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.Notifications.List, client.Notifications.All), format, notificationOutput, notificationColumns)
+			return
 		}
 
-		var result struct {
-			Templates []struct {
-				Key           string `json:"key"`
-				Name          string `json:"name"`
-				Notifications struct {
-					Emails   []string `json:"emails"`
-					Slack    []string `json:"slack"`
-					Webhooks []string `json:"webhooks"`
-					Phones   []string `json:"phones"`
-				} `json:"notifications"`
-				Monitors []string `json:"monitors"`
-			} `json:"templates"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
+		page, err := client.Notifications.List(cmd.Context(), opts)
+		if err != nil {
+			exitOnAPIError(err, "list notification lists", "")
 		}
 
-		format := notificationFormat
-		if format == "" {
-			format = "table"
-		}
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: notificationColumns.headers,
+			}
 
-		if format == "json" {
-			notificationOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
+			for _, n := range page.Items {
+				table.Rows = append(table.Rows, notificationColumns.row(n))
+			}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "EMAILS", "SLACK", "MONITORS"},
-		}
-
-		for _, n := range result.Templates {
-			emailCount := fmt.Sprintf("%d", len(n.Notifications.Emails))
-			slackCount := fmt.Sprintf("%d", len(n.Notifications.Slack))
-			monitorCount := fmt.Sprintf("%d", len(n.Monitors))
-			table.Rows = append(table.Rows, []string{n.Name, n.Key, emailCount, slackCount, monitorCount})
-		}
+			return table.Render()
+		}).print(format, notificationOutput)
+	},
+}
 
-		notificationOutputToTarget(table.Render())
+var notificationColumns = listColumns[api.NotificationList]{
+	noun:    "notification lists",
+	headers: []string{"NAME", "KEY", "EMAILS", "SLACK", "MONITORS"},
+	row: func(n api.NotificationList) []string {
+		emailCount := fmt.Sprintf("%d", len(n.Notifications.Emails))
+		slackCount := fmt.Sprintf("%d", len(n.Notifications.Slack))
+		monitorCount := fmt.Sprintf("%d", len(n.Monitors))
+		return []string{n.Name, n.Key, emailCount, slackCount, monitorCount}
 	},
 }
 
@@ -147,25 +126,14 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.GET(fmt.Sprintf("/notifications/%s", key), nil)
+		notifications, err := client.Notifications.Get(cmd.Context(), key)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get notification list: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Notification list '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "get notification list", fmt.Sprintf("Notification list '%s' not found", key))
 		}
 
-		notificationOutputToTarget(FormatJSON(resp.Body))
+		output{raw: notifications.Raw()}.print(outputFormat(notificationFormat, "json"), notificationOutput)
 	},
 }
 
@@ -184,36 +152,22 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(notificationData), &js); err != nil {
+		var notifications api.NotificationList
+		if err := json.Unmarshal([]byte(notificationData), &notifications); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/notifications", []byte(notificationData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.Notifications.Create(cmd.Context(), &notifications)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create notification list: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "create notification list", "")
 		}
 
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created notification list: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Notification list created")
-		}
+		Success(fmt.Sprintf("Created notification list: %s (key: %s)", created.Name, created.Key))
 
-		if notificationFormat == "json" {
-			notificationOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(notificationFormat, ""); format != "" {
+			output{raw: created.Raw()}.print(format, notificationOutput)
 		}
 	},
 }
@@ -236,34 +190,21 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(notificationData), &bodyMap); err != nil {
+		var notifications api.NotificationList
+		if err := json.Unmarshal([]byte(notificationData), &notifications); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/notifications/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Notifications.Update(cmd.Context(), key, &notifications)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update notification list: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Notification '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update notification list", fmt.Sprintf("Notification '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Notification list '%s' updated", key))
-		if notificationFormat == "json" {
-			notificationOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(notificationFormat, ""); format != "" {
+			output{raw: updated.Raw()}.print(format, notificationOutput)
 		}
 	},
 }
@@ -281,25 +222,13 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		resp, err := client.DELETE(fmt.Sprintf("/notifications/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete notification list: %s", err))
-			os.Exit(1)
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Notification list '%s' not found", key))
-			os.Exit(1)
+		if err := client.Notifications.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete notification list", fmt.Sprintf("Notification list '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Notification list '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Notification list '%s' deleted", key))
 	},
 }
 
@@ -316,15 +245,3 @@ func init() {
 	// Update command flags
 	notificationUpdateCmd.Flags().StringVarP(&notificationData, "data", "d", "", "JSON payload")
 }
-
-func notificationOutputToTarget(content string) {
-	if notificationOutput != "" {
-		if err := os.WriteFile(notificationOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", notificationOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", notificationOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
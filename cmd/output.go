@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/itchyny/gojq"
+	"gopkg.in/yaml.v3"
+)
+
+// Output filters shared by every resource command, set with --query and --fields
+var (
+	outputQuery  string
+	outputFields []string
+)
+
+// output is one API response on its way to stdout or the --output file
+type output struct {
+	raw   json.RawMessage   // the response, printed as-is by --format json
+	items []json.RawMessage // the records of a list response, nil when raw is a single resource
+	table func() string     // the command's own table, nil to print the records as a plain table
+}
+
+// print writes the output in format to path, or to stdout when path is empty. The command's
+// own table and the API's JSON are printed untouched unless --query or --fields narrow them.
+func (o output) print(format, path string) {
+	filtered := outputFiltered()
+	switch {
+	case format == "json" && !filtered:
+		writeOutput(path, FormatJSON(o.raw))
+		return
+	case format == "yaml" && !filtered:
+		writeOutput(path, strings.TrimSpace(jsonToYAML(o.raw)))
+		return
+	case format == "table" && !filtered && o.table != nil:
+		writeOutput(path, o.table())
+		return
+	}
+
+	w, done := openOutput(path)
+	if outputQuery == "" && o.items != nil {
+		p := newPrinter(format, w, true)
+		for _, item := range o.items {
+			p.item(decodeJSON(item))
+		}
+		p.close()
+	} else {
+		p := newPrinter(format, w, false)
+		p.document(decodeJSON(o.raw))
+		p.close()
+	}
+	done(true)
+}
+
+// pageOutput is the output for one page of a list
+func pageOutput[T interface{ Raw() json.RawMessage }](page *api.Page[T], table func() string) output {
+	items := make([]json.RawMessage, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = item.Raw()
+	}
+	return output{raw: page.Raw(), items: items, table: table}
+}
+
+// outputFiltered reports whether --query or --fields narrow the output
+func outputFiltered() bool {
+	return outputQuery != "" || len(outputFields) > 0
+}
+
+// outputFormat returns format, or the command's default when it is empty. Output narrowed by
+// --query defaults to JSON, since a query rarely leaves the columns of the command's table.
+func outputFormat(format, fallback string) string {
+	if format != "" {
+		return format
+	}
+	if outputQuery != "" {
+		return "json"
+	}
+	return fallback
+}
+
+// writeOutput writes content to path, or prints it when path is empty
+func writeOutput(path, content string) {
+	w, done := openOutput(path)
+	fmt.Fprintln(w, content)
+	done(true)
+}
+
+// openOutput opens the file given by --output for writing, or returns stdout. done closes the
+// file and, when the output is complete, reports where it went.
+func openOutput(path string) (w io.Writer, done func(complete bool)) {
+	if path == "" {
+		return os.Stdout, func(bool) {}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		Error(fmt.Sprintf("Failed to write to %s: %s", path, err))
+		os.Exit(1)
+	}
+	return file, func(complete bool) {
+		file.Close()
+		if complete {
+			Info(fmt.Sprintf("Output written to %s", path))
+		}
+	}
+}
+
+// printer writes records in one of the --format formats: json, jsonl, yaml, csv, tsv, table
+// or template=<Go template>. Formats that print a record per line write each one as it
+// arrives; json, yaml and table wait for close.
+type printer struct {
+	format   string
+	w        io.Writer
+	query    *gojq.Code
+	fields   []string
+	template *template.Template
+	csv      *csv.Writer
+	started  bool
+	headers  []string
+	table    *UITable
+
+	// A printer for a list holds back its items until close when the format prints the list
+	// as a whole, or when --query has to see all of it
+	list    bool
+	pending []any
+}
+
+// newPrinter returns a printer for a single document, or for the items of a list when list
+// is true
+func newPrinter(format string, w io.Writer, list bool) *printer {
+	p := &printer{format: format, w: w, fields: outputFields, list: list, pending: []any{}}
+
+	switch {
+	case format == "json", format == "jsonl", format == "yaml", format == "table":
+	case format == "csv":
+		p.csv = csv.NewWriter(w)
+	case format == "tsv":
+	case strings.HasPrefix(format, "template="):
+		tmpl, err := template.New("format").Option("missingkey=zero").Parse(strings.TrimPrefix(format, "template="))
+		if err != nil {
+			Error(fmt.Sprintf("Invalid --format template: %s", err))
+			os.Exit(1)
+		}
+		p.template = tmpl
+	default:
+		Error(fmt.Sprintf("Unknown format '%s'. Use json, jsonl, yaml, csv, tsv, table or template='{{.key}}'", format))
+		os.Exit(1)
+	}
+
+	if outputQuery != "" {
+		query, err := gojq.Parse(outputQuery)
+		if err != nil {
+			Error(fmt.Sprintf("Invalid --query: %s", err))
+			os.Exit(1)
+		}
+		code, err := gojq.Compile(query)
+		if err != nil {
+			Error(fmt.Sprintf("Invalid --query: %s", err))
+			os.Exit(1)
+		}
+		p.query = code
+	}
+	return p
+}
+
+// document prints a whole response: json and yaml print each result of --query, and the other
+// formats print a record for each result, or for each element of a result that is an array.
+func (p *printer) document(v any) {
+	for _, result := range p.run(v) {
+		switch p.format {
+		case "json":
+			p.writeJSON(project(result, p.fields))
+		case "yaml":
+			p.writeYAML(project(result, p.fields))
+		default:
+			if list, ok := result.([]any); ok {
+				for _, element := range list {
+					p.record(element)
+				}
+			} else {
+				p.record(result)
+			}
+		}
+	}
+}
+
+// item prints one record of a list. With --query, each jsonl line is filtered on its own, like
+// piping it through jq; every other format runs the query once on the whole list at close.
+func (p *printer) item(v any) {
+	switch {
+	case p.query != nil && p.format == "jsonl":
+		for _, result := range p.run(v) {
+			p.record(result)
+		}
+	case p.holdsItems():
+		p.pending = append(p.pending, v)
+	default:
+		p.record(v)
+	}
+}
+
+func (p *printer) holdsItems() bool {
+	return p.list && (p.query != nil && p.format != "jsonl" || p.format == "json" || p.format == "yaml")
+}
+
+// close prints what the format holds back until every record is in
+func (p *printer) close() {
+	if p.holdsItems() {
+		p.document(p.pending)
+	}
+
+	switch {
+	case p.csv != nil:
+		p.csv.Flush()
+	case p.format == "table":
+		if p.table == nil || len(p.table.Rows) == 0 {
+			fmt.Fprintln(p.w, mutedStyle.Render("No results found"))
+		} else {
+			fmt.Fprintln(p.w, p.table.Render())
+		}
+	}
+}
+
+// record prints one record in a line-based format, or adds it to the table
+func (p *printer) record(v any) {
+	v = project(v, p.fields)
+	switch {
+	case p.format == "jsonl":
+		data, _ := json.Marshal(v)
+		fmt.Fprintln(p.w, string(data))
+	case p.template != nil:
+		if s, ok := v.(selection); ok {
+			v = s.values
+		}
+		if err := p.template.Execute(p.w, v); err != nil {
+			Error(fmt.Sprintf("Failed to render --format template: %s", err))
+			os.Exit(1)
+		}
+		fmt.Fprintln(p.w)
+	case p.format == "csv" || p.format == "tsv":
+		if !p.started {
+			p.started = true
+			p.headers = columnsOf(v)
+			if len(p.headers) > 0 {
+				p.writeRow(p.headers)
+			}
+		}
+		p.writeRow(cellsOf(v, p.headers))
+	default:
+		if !p.started {
+			p.started = true
+			p.headers = columnsOf(v)
+			p.table = &UITable{Headers: []string{"VALUE"}}
+			if len(p.headers) > 0 {
+				p.table.Headers = make([]string, len(p.headers))
+				for i, header := range p.headers {
+					p.table.Headers[i] = strings.ToUpper(header)
+				}
+			}
+		}
+		p.table.Rows = append(p.table.Rows, cellsOf(v, p.headers))
+	}
+}
+
+// writeRow writes a csv row, or a tsv row with any tabs and line breaks in a cell turned into
+// spaces so that every row stays on one line
+func (p *printer) writeRow(cells []string) {
+	if p.csv != nil {
+		p.csv.Write(cells)
+		return
+	}
+	for i, cell := range cells {
+		cells[i] = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ").Replace(cell)
+	}
+	fmt.Fprintln(p.w, strings.Join(cells, "\t"))
+}
+
+func (p *printer) run(v any) []any {
+	if p.query == nil {
+		return []any{v}
+	}
+	var results []any
+	iter := p.query.Run(v)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := result.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				break
+			}
+			Error(fmt.Sprintf("--query failed: %s", err))
+			os.Exit(1)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (p *printer) writeJSON(v any) {
+	data, _ := json.Marshal(v)
+	fmt.Fprintln(p.w, FormatJSON(data))
+}
+
+func (p *printer) writeYAML(v any) {
+	data, _ := json.Marshal(v)
+	fmt.Fprint(p.w, jsonToYAML(data))
+}
+
+// selection is a record narrowed to --fields, which keeps the fields in the order they were given
+type selection struct {
+	keys   []string
+	values map[string]any
+}
+
+func (s selection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range s.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(s.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// project narrows an object, or each object in an array, to fields. A field may name a nested
+// value with dots, such as latest_event.stamp.
+func project(v any, fields []string) any {
+	if len(fields) == 0 {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		s := selection{keys: fields, values: make(map[string]any, len(fields))}
+		for _, field := range fields {
+			s.values[field] = lookup(v, field)
+		}
+		return s
+	case []any:
+		projected := make([]any, len(v))
+		for i, element := range v {
+			projected[i] = project(element, fields)
+		}
+		return projected
+	}
+	return v
+}
+
+func lookup(v any, path string) any {
+	for _, part := range strings.Split(path, ".") {
+		object, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = object[part]
+	}
+	return v
+}
+
+// columnsOf returns the column names for a record: its fields in order, or the keys of an
+// object sorted by name. Other values are printed in a single column without a name.
+func columnsOf(v any) []string {
+	switch v := v.(type) {
+	case selection:
+		return v.keys
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return nil
+}
+
+func cellsOf(v any, columns []string) []string {
+	if columns == nil {
+		return []string{cell(v)}
+	}
+	var values map[string]any
+	switch v := v.(type) {
+	case selection:
+		values = v.values
+	case map[string]any:
+		values = v
+	}
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = cell(values[column])
+	}
+	return cells
+}
+
+// cell formats a value for one column: scalars as text, and objects and arrays as JSON
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func decodeJSON(data []byte) any {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	return v
+}
+
+// jsonToYAML converts JSON to block-style YAML, keeping the order of object keys
+func jsonToYAML(data []byte) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return string(data)
+	}
+	var unflow func(*yaml.Node)
+	unflow = func(n *yaml.Node) {
+		n.Style = 0
+		for _, child := range n.Content {
+			unflow(child)
+		}
+	}
+	unflow(&node)
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return string(data)
+	}
+	return out.String()
+}
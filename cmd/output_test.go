@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/internal/testutil"
+)
+
+const monitorsPage = `{"monitors":[{"key":"backup","name":"Nightly backup","passing":true,"latest_event":{"stamp":1700000000}},{"key":"sync","name":"Sync, hourly","passing":false}],"page_info":{"page":1,"pageSize":50,"totalMonitorCount":2}}`
+
+func withOutputFilters(t *testing.T, query string, fields ...string) {
+	t.Helper()
+	outputQuery, outputFields = query, fields
+	t.Cleanup(func() { outputQuery, outputFields = "", nil })
+}
+
+func monitorsOutput() output {
+	var page struct {
+		Monitors []json.RawMessage `json:"monitors"`
+	}
+	json.Unmarshal([]byte(monitorsPage), &page)
+	return output{raw: json.RawMessage(monitorsPage), items: page.Monitors, table: func() string { return "own table" }}
+}
+
+func printed(o output, format string) string {
+	return testutil.CaptureStdout(func() { o.print(format, "") })
+}
+
+func TestOutput_UnfilteredKeepsResponse(t *testing.T) {
+	if got := printed(monitorsOutput(), "json"); got != FormatJSON([]byte(monitorsPage))+"\n" {
+		t.Errorf("expected the response untouched, got:\n%s", got)
+	}
+	if got := printed(monitorsOutput(), "table"); got != "own table\n" {
+		t.Errorf("expected the command's own table, got %q", got)
+	}
+}
+
+func TestOutput_QueryRunsOnResponse(t *testing.T) {
+	withOutputFilters(t, ".monitors[].key")
+
+	if got := printed(monitorsOutput(), "json"); got != "\"backup\"\n\"sync\"\n" {
+		t.Errorf("expected one result per line, got %q", got)
+	}
+}
+
+func TestOutput_QueryArrayBecomesRows(t *testing.T) {
+	withOutputFilters(t, "[.monitors[] | select(.passing)]", "key")
+
+	if got := printed(monitorsOutput(), "csv"); got != "key\nbackup\n" {
+		t.Errorf("expected a row for each passing monitor, got %q", got)
+	}
+}
+
+func TestOutput_FieldsSelectFromItems(t *testing.T) {
+	withOutputFilters(t, "", "name", "key", "latest_event.stamp")
+
+	got := printed(monitorsOutput(), "csv")
+	expected := "name,key,latest_event.stamp\nNightly backup,backup,1700000000\n\"Sync, hourly\",sync,\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	got = printed(monitorsOutput(), "jsonl")
+	expected = `{"name":"Nightly backup","key":"backup","latest_event.stamp":1700000000}` + "\n" + `{"name":"Sync, hourly","key":"sync","latest_event.stamp":null}` + "\n"
+	if got != expected {
+		t.Errorf("expected fields in the order given:\n%s\ngot:\n%s", expected, got)
+	}
+
+	got = printed(monitorsOutput(), "table")
+	if !strings.Contains(got, "NAME") || !strings.Contains(got, "Nightly backup") || strings.Contains(got, "own table") {
+		t.Errorf("expected a table of the selected fields, got:\n%s", got)
+	}
+}
+
+func TestOutput_TSVWithoutFieldsUsesSortedKeys(t *testing.T) {
+	got := printed(monitorsOutput(), "tsv")
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 3 || lines[0] != "key\tlatest_event\tname\tpassing" {
+		t.Fatalf("expected a header and two rows, got:\n%s", got)
+	}
+	if lines[1] != "backup\t{\"stamp\":1700000000}\tNightly backup\ttrue" {
+		t.Errorf("expected nested values as JSON, got %q", lines[1])
+	}
+}
+
+func TestOutput_Template(t *testing.T) {
+	got := printed(monitorsOutput(), "template={{.key}}: {{.name}}")
+	if got != "backup: Nightly backup\nsync: Sync, hourly\n" {
+		t.Errorf("expected one line per monitor, got %q", got)
+	}
+}
+
+func TestOutput_YAMLKeepsKeyOrder(t *testing.T) {
+	got := printed(output{raw: json.RawMessage(`{"name":"Nightly backup","key":"backup","tags":["db","nightly"],"note":"true"}`)}, "yaml")
+	expected := "name: Nightly backup\nkey: backup\ntags:\n  - db\n  - nightly\nnote: \"true\"\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestOutputFormat_QueryDefaultsToJSON(t *testing.T) {
+	if got := outputFormat("", "table"); got != "table" {
+		t.Errorf("expected the command's default, got %q", got)
+	}
+	withOutputFilters(t, ".key")
+	if got := outputFormat("", "table"); got != "json" {
+		t.Errorf("expected json for a query, got %q", got)
+	}
+	if got := outputFormat("csv", "table"); got != "csv" {
+		t.Errorf("expected an explicit format to win, got %q", got)
+	}
+}
+
+func TestIntegration_MonitorList_QueryAllAsJSONLines(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, monitorsPage)
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+	defer func() { listAll, outputQuery = false, "" }()
+
+	monitorFormat = ""
+	monitorOutput = ""
+	monitorPage = 1
+
+	output, err := executeCmd("monitor", "list", "--all", "--format", "jsonl", "--query", "select(.passing | not) | {key}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != `{"key":"sync"}`+"\n" {
+		t.Errorf("expected the query to run on each line, got %q", output)
+	}
+}
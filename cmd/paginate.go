@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/cobra"
+)
+
+// Pagination flags shared by every list command
+var (
+	listAll         bool
+	listConcurrency int
+)
+
+func addListAllFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&listAll, "all", false, "Fetch every page instead of only --page")
+	cmd.Flags().IntVar(&listConcurrency, "concurrency", 1, "Number of pages to fetch at once with --all")
+}
+
+// listColumns describe how a list command renders one resource as a table row
+type listColumns[T any] struct {
+	noun    string
+	headers []string
+	row     func(T) []string
+}
+
+// listItems iterates every page from the one opts asks for when --all is set, and only that
+// page otherwise
+func listItems[T any, O any](ctx context.Context, opts *O, list func(context.Context, *O) (*api.Page[T], error), all func(context.Context, *O) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	if listAll {
+		return all(ctx, opts)
+	}
+	return func(yield func(T, error) bool) {
+		page, err := list(ctx, opts)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, item := range page.Items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// writeItems writes items to output, or stdout when output is empty. The jsonl, csv, tsv and
+// template formats stream one record per line as pages arrive; the others are written once the
+// last page is in.
+func writeItems[T interface{ Raw() json.RawMessage }](items iter.Seq2[T, error], format, output string, columns listColumns[T]) {
+	w, done := openOutput(output)
+
+	if outputFiltered() || (format != "jsonl" && format != "json" && format != "table") {
+		p := newPrinter(format, w, true)
+		for item, err := range items {
+			if err != nil {
+				done(false)
+				exitOnAPIError(err, "list "+columns.noun, "")
+			}
+			p.item(decodeJSON(item.Raw()))
+		}
+		p.close()
+		done(true)
+		return
+	}
+
+	var raws []json.RawMessage
+	table := &UITable{Headers: columns.headers}
+	for item, err := range items {
+		if err != nil {
+			done(false)
+			exitOnAPIError(err, "list "+columns.noun, "")
+		}
+		switch format {
+		case "jsonl":
+			var line bytes.Buffer
+			if err := json.Compact(&line, item.Raw()); err != nil {
+				line.Write(item.Raw())
+			}
+			line.WriteByte('\n')
+			w.Write(line.Bytes())
+		case "json":
+			raws = append(raws, item.Raw())
+		default:
+			table.Rows = append(table.Rows, columns.row(item))
+		}
+	}
+
+	switch format {
+	case "jsonl":
+	case "json":
+		if raws == nil {
+			raws = []json.RawMessage{}
+		}
+		data, _ := json.Marshal(raws)
+		fmt.Fprintln(w, FormatJSON(data))
+	default:
+		if len(table.Rows) == 0 {
+			fmt.Fprintln(w, mutedStyle.Render(fmt.Sprintf("No %s found", columns.noun)))
+		} else {
+			fmt.Fprintln(w, table.Render()+mutedStyle.Render(fmt.Sprintf("\n%d %s", len(table.Rows), columns.noun)))
+		}
+	}
+	done(true)
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultProfile selects the settings at the top level of the config file rather than a named profile
+const defaultProfile = "default"
+
+// ProfileConfig holds the settings of one Cronitor account. Everything else in the config file is shared by
+// all profiles.
+type ProfileConfig struct {
+	ApiKey         string `json:"CRONITOR_API_KEY"`
+	PingApiAuthKey string `json:"CRONITOR_PING_API_KEY,omitempty"`
+	Env            string `json:"CRONITOR_ENV,omitempty"`
+	URL            string `json:"CRONITOR_URL,omitempty"`
+	ApiVersion     string `json:"CRONITOR_API_VERSION,omitempty"`
+}
+
+// profileSettings maps each setting a profile holds to the flag that overrides it. The account settings are
+// always taken from the profile so that keys never leak between accounts, while the others fall back to the
+// shared setting when the profile leaves them empty.
+var profileSettings = []struct {
+	key, flag string
+	shared    bool
+}{
+	{varApiKey, "api-key", false},
+	{varPingApiKey, "ping-api-key", false},
+	{varEnv, "env", false},
+	{varURL, "", true},
+	{varApiVersion, "api-version", true},
+}
+
+func (p ProfileConfig) get(key string) string {
+	switch key {
+	case varApiKey:
+		return p.ApiKey
+	case varPingApiKey:
+		return p.PingApiAuthKey
+	case varEnv:
+		return p.Env
+	case varURL:
+		return p.URL
+	case varApiVersion:
+		return p.ApiVersion
+	}
+	return ""
+}
+
+// profileFromViper collects the profile settings currently in effect
+func profileFromViper() ProfileConfig {
+	return ProfileConfig{
+		ApiKey:         viper.GetString(varApiKey),
+		PingApiAuthKey: viper.GetString(varPingApiKey),
+		Env:            viper.GetString(varEnv),
+		URL:            viper.GetString(varURL),
+		ApiVersion:     viper.GetString(varApiVersion),
+	}
+}
+
+// activeProfile returns the selected profile: --profile, then CRONITOR_PROFILE from the environment or the
+// config file. It is empty when the top-level settings are used.
+func activeProfile() string {
+	if name := viper.GetString(varProfile); name != defaultProfile {
+		return name
+	}
+	return ""
+}
+
+// readConfigFile reads the config file as written, without flags, environment variables or a profile applied.
+// A missing file is an empty config.
+func readConfigFile() (ConfigFile, error) {
+	var config ConfigFile
+	data, err := ioutil.ReadFile(configFilePath())
+	if os.IsNotExist(err) {
+		return config, nil
+	} else if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("cannot read %s: %v", configFilePath(), err)
+	}
+	return config, nil
+}
+
+// findProfile returns the name a profile is saved under, matching case-insensitively
+func findProfile(config ConfigFile, name string) (string, bool) {
+	if _, ok := config.Profiles[name]; ok {
+		return name, true
+	}
+	for saved := range config.Profiles {
+		if strings.EqualFold(saved, name) {
+			return saved, true
+		}
+	}
+	return name, false
+}
+
+// applyProfile makes the settings of the active profile take effect for this command, so that every lookup
+// of an API key, ping API key or environment, from NewAPIClient to sendPing and the crontab lines written by
+// discover, resolves through it. Settings passed as flags still win.
+func applyProfile(cmd *cobra.Command) error {
+	name := activeProfile()
+	if name == "" {
+		return nil
+	}
+
+	config, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	saved, ok := findProfile(config, name)
+	if !ok {
+		return fmt.Errorf("Profile '%s' not found in %s. Create it with 'cronitor configure --profile %s --api-key <key>'", name, configFilePath(), name)
+	}
+
+	applyProfileSettings(cmd, config.Profiles[saved])
+	return nil
+}
+
+func applyProfileSettings(cmd *cobra.Command, profile ProfileConfig) {
+	for _, setting := range profileSettings {
+		if setting.flag != "" && cmd.Flags().Lookup(setting.flag) != nil && cmd.Flags().Changed(setting.flag) {
+			viper.Set(setting.key, cmd.Flags().Lookup(setting.flag).Value.String())
+		} else if value := profile.get(setting.key); value != "" || !setting.shared {
+			viper.Set(setting.key, value)
+		}
+	}
+}
+
+// writeConfigSetting changes one top-level setting of the config file and leaves the rest as written
+func writeConfigSetting(key string, value interface{}) error {
+	path := configFilePath()
+	settings := map[string]json.RawMessage{}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("cannot read %s: %v", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if value == nil {
+		delete(settings, key)
+	} else {
+		encoded, _ := json.Marshal(value)
+		settings[key] = encoded
+	}
+
+	b, err := json.MarshalIndent(settings, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+func maskKey(key string) string {
+	if key == "" {
+		return "Not Set"
+	}
+	if len(key) <= 8 {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", 8) + key[len(key)-4:]
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles for multiple Cronitor accounts",
+	Long: `Manage named profiles in the config file, each with its own API key, ping API key and environment.
+
+Select a profile for one command with --profile or CRONITOR_PROFILE, or make it the default with
+'cronitor profile use'. Flags such as --api-key still override the profile's settings. The profile named
+"default" is the settings at the top level of the config file.
+
+Crontab lines written by 'cronitor discover' include the profile, so jobs keep reporting to the account they
+were discovered with.
+
+Examples:
+  cronitor configure --profile staging --api-key <key> --env staging
+  cronitor profile list
+  cronitor profile use staging
+  cronitor monitor list --profile prod
+  CRONITOR_PROFILE=staging cronitor ping nightly-backup --complete`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := readConfigFile()
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+		if len(config.Profiles) == 0 {
+			Info("No profiles configured. Add one with 'cronitor configure --profile <name> --api-key <key>'")
+			return
+		}
+
+		names := []string{defaultProfile}
+		for name := range config.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names[1:])
+
+		active, _ := findProfile(config, activeProfile())
+		table := &UITable{Headers: []string{"", "PROFILE", "API KEY", "ENV"}}
+		for _, name := range names {
+			profile := ProfileConfig{ApiKey: config.ApiKey, Env: config.Env}
+			if name != defaultProfile {
+				profile = config.Profiles[name]
+			}
+			marker := ""
+			if name == active || (name == defaultProfile && active == "") {
+				marker = "*"
+			}
+			table.Rows = append(table.Rows, []string{marker, name, maskKey(profile.ApiKey), valueOrNone(profile.Env)})
+		}
+		fmt.Println(table.Render())
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the default",
+	Long: `Make a profile the default for every command, by saving CRONITOR_PROFILE to the config file.
+
+Examples:
+  cronitor profile use staging
+  cronitor profile use default   # Go back to the top-level settings`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := readConfigFile()
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+
+		name := args[0]
+		var value interface{}
+		if name != defaultProfile {
+			saved, ok := findProfile(config, name)
+			if !ok {
+				Error(fmt.Sprintf("Profile '%s' not found in %s", name, configFilePath()))
+				os.Exit(1)
+			}
+			name, value = saved, saved
+		}
+
+		if err := writeConfigSetting(varProfile, value); err != nil {
+			Error(fmt.Sprintf("Could not update %s: %v", configFilePath(), err))
+			os.Exit(1)
+		}
+		Success(fmt.Sprintf("Using profile '%s'", name))
+		if os.Getenv(varProfile) != "" && !strings.EqualFold(os.Getenv(varProfile), name) {
+			Warning(fmt.Sprintf("CRONITOR_PROFILE=%s is set in your environment and takes precedence", os.Getenv(varProfile)))
+		}
+	},
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the settings of a profile",
+	Long: `Show the settings of a profile, by default the active one.
+
+Examples:
+  cronitor profile show
+  cronitor profile show staging`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := readConfigFile()
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+
+		name := activeProfile()
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		var profile ProfileConfig
+		if name == "" || name == defaultProfile {
+			name = defaultProfile
+			profile = ProfileConfig{ApiKey: config.ApiKey, PingApiAuthKey: config.PingApiAuthKey, Env: config.Env, URL: config.URL, ApiVersion: config.ApiVersion}
+		} else {
+			saved, ok := findProfile(config, name)
+			if !ok {
+				Error(fmt.Sprintf("Profile '%s' not found in %s", name, configFilePath()))
+				os.Exit(1)
+			}
+			name, profile = saved, config.Profiles[saved]
+		}
+
+		fmt.Println(RenderKeyValue("Profile", name))
+		fmt.Println(RenderKeyValue("Config File", configFilePath()))
+		fmt.Println(RenderKeyValue("API Key", maskKey(profile.ApiKey)))
+		fmt.Println(RenderKeyValue("Ping API Key", maskKey(profile.PingApiAuthKey)))
+		fmt.Println(RenderKeyValue("Environment", valueOrNone(profile.Env)))
+		if profile.URL != "" {
+			fmt.Println(RenderKeyValue("URL", profile.URL))
+		}
+		if profile.ApiVersion != "" {
+			fmt.Println(RenderKeyValue("API Version", profile.ApiVersion))
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileShowCmd)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/internal/testutil"
+	"github.com/spf13/viper"
+)
+
+// setupProfileTest points the config file at a temporary one with these contents
+func setupProfileTest(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cronitor.json")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set(varConfig, path)
+
+	t.Cleanup(func() {
+		// Empty the config viper read from the file, and forget the settings the profile applied
+		os.WriteFile(path, []byte("{}"), 0644)
+		viper.ReadInConfig()
+		for _, key := range []string{varConfig, varApiKey, varPingApiKey, varEnv, varURL} {
+			viper.Set(key, "")
+		}
+		for _, flag := range []string{"profile", "api-key", "env"} {
+			RootCmd.PersistentFlags().Lookup(flag).Changed = false
+		}
+		configureCmd.Flags().Lookup("env").Changed = false
+		profileName, apiKey, environment = "", "", ""
+	})
+	return path
+}
+
+func readTestConfig(t *testing.T, path string) ConfigFile {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config ConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("invalid config file: %v\n%s", err, data)
+	}
+	return config
+}
+
+func TestProfile_ConfigureAndUse(t *testing.T) {
+	path := setupProfileTest(t, `{"CRONITOR_API_KEY": "prod-key-1234567890", "CRONITOR_ENV": "production", "CRONITOR_HOSTNAME": "web1"}`)
+
+	if _, err := executeCmd("configure", "--profile", "staging", "--api-key", "staging-key-0987654321", "--env", "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := readTestConfig(t, path)
+	if config.ApiKey != "prod-key-1234567890" || config.Env != "production" || config.Hostname != "web1" {
+		t.Errorf("expected the top-level settings to be kept, got %+v", config)
+	}
+	if staging := config.Profiles["staging"]; staging.ApiKey != "staging-key-0987654321" || staging.Env != "staging" || staging.PingApiAuthKey != "" {
+		t.Errorf("expected the staging profile to be saved, got %+v", config.Profiles)
+	}
+	if config.Profile != "" {
+		t.Errorf("expected configure not to change the default profile, got %q", config.Profile)
+	}
+
+	if _, err := executeCmd("profile", "use", "Staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config = readTestConfig(t, path)
+	if config.Profile != "staging" || config.ApiKey != "prod-key-1234567890" || config.Profiles["staging"].ApiKey == "" {
+		t.Errorf("expected profile use to only set the default profile, got %+v", config)
+	}
+
+	output, err := executeCmd("profile", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "default") || !strings.Contains(output, "********4321") || !strings.Contains(output, "production") {
+		t.Errorf("expected both profiles with masked keys, got:\n%s", output)
+	}
+	if strings.Contains(output, "staging-key-0987654321") {
+		t.Errorf("expected API keys to be masked, got:\n%s", output)
+	}
+
+	output, err = executeCmd("profile", "show", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "********7890") || !strings.Contains(output, "production") {
+		t.Errorf("expected the top-level settings, got:\n%s", output)
+	}
+}
+
+func TestProfile_ResolvesAPIKeyAndPings(t *testing.T) {
+	setupProfileTest(t, `{
+    "CRONITOR_API_KEY": "prod-key-1234567890",
+    "profiles": {
+        "staging": {"CRONITOR_API_KEY": "staging-key-0987654321", "CRONITOR_ENV": "staging"}
+    }
+}`)
+
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, testutil.LoadFixture("monitors_list.json"))
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+	monitorFormat = ""
+	monitorOutput = ""
+	monitorPage = 1
+
+	if _, err := executeCmd("monitor", "list", "--profile", "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("staging-key-0987654321:"))
+	if got := mock.LastRequest().Headers.Get("Authorization"); got != expected {
+		t.Errorf("expected the API to be called with the staging key, got %q", got)
+	}
+
+	viper.Set(varURL, mock.Server.URL)
+	defer func() { complete = false }()
+	if _, err := executeCmd("ping", "nightly-backup", "--complete", "--profile", "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ping := mock.LastRequest()
+	if ping.Path != "/ping/staging-key-0987654321/nightly-backup" || ping.QueryParams.Get("env") != "staging" {
+		t.Errorf("expected the ping to use the staging key and environment, got %s?%s", ping.Path, ping.QueryParams.Encode())
+	}
+}
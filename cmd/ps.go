@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/spf13/cobra"
+)
+
+var psJSON bool
+
+// runningJob is a running cron job shown by `cronitor ps` and targeted by `cronitor kill`
+type runningJob struct {
+	Code    string    `json:"code,omitempty"`
+	LineKey string    `json:"line_key,omitempty"`
+	Command string    `json:"command"`
+	PID     int       `json:"pid"`
+	PGID    int       `json:"pgid"`
+	Started time.Time `json:"started"`
+	Crontab string    `json:"crontab,omitempty"`
+	Source  string    `json:"source"`
+}
+
+// Runtime is how long the job has been running, to the second
+func (j runningJob) Runtime() time.Duration {
+	return time.Since(j.Started).Round(time.Second)
+}
+
+var psCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "List running cron jobs",
+	Long: `
+Cronitor ps lists the cron jobs that are running right now.
+
+Jobs wrapped with 'cronitor exec' are read from the registry each run writes to the state directory.
+Jobs that are not wrapped are found by matching their command against the process list.
+
+Example:
+  $ cronitor ps
+      > List running jobs with their monitor key, runtime, pid, process group and crontab
+
+  $ cronitor ps --json
+      > Output running jobs as JSON
+	`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		jobs, err := findRunningJobs()
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+
+		if psJSON {
+			output, _ := json.MarshalIndent(jobs, "", "  ")
+			fmt.Println(string(output))
+			return
+		}
+
+		if len(jobs) == 0 {
+			printWarningText("No running jobs found", false)
+			return
+		}
+
+		table := &UITable{Headers: []string{"KEY", "PID", "PGID", "RUNTIME", "CRONTAB", "COMMAND"}}
+		for _, job := range jobs {
+			key := job.Code
+			if key == "" {
+				key = "-"
+			}
+			table.Rows = append(table.Rows, []string{
+				key,
+				strconv.Itoa(job.PID),
+				strconv.Itoa(job.PGID),
+				job.Runtime().String(),
+				job.Crontab,
+				truncateString(job.Command, 60),
+			})
+		}
+		fmt.Print(table.Render())
+	},
+}
+
+// findRunningJobs returns running cron jobs, oldest first. Runs in the instance registry come first and the ps
+// heuristic only covers crontab lines that are not wrapped with `cronitor exec`.
+func findRunningJobs() ([]runningJob, error) {
+	registered, err := instanceRegistry().List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read instance registry: %v", err)
+	}
+
+	crontabs, _ := lib.GetAllCrontabs(parseUsers())
+
+	jobs := []runningJob{}
+	registeredGroups := make(map[int]bool)
+	for _, instance := range registered {
+		job := runningJob{
+			Code:    instance.Code,
+			Command: instance.Command,
+			PID:     instance.PID,
+			PGID:    instance.PGID,
+			Started: instance.Started,
+			Source:  "registry",
+		}
+
+		for _, crontab := range crontabs {
+			for _, line := range crontab.Lines {
+				if line.IsJob && instance.Code != "" && line.Code == instance.Code {
+					job.Crontab = crontab.DisplayName()
+					job.LineKey = line.Key(crontab.CanonicalName())
+				}
+			}
+		}
+
+		registeredGroups[instance.PGID] = true
+		jobs = append(jobs, job)
+	}
+
+	for _, crontab := range crontabs {
+		for _, line := range crontab.Lines {
+			if !line.IsJob || line.IsComment || line.Code != "" || line.CommandToRun == "" {
+				continue
+			}
+
+			for _, instance := range findInstances([]string{line.CommandToRun}) {
+				pgid, err := strconv.Atoi(instance.PID)
+				if err != nil || registeredGroups[pgid] {
+					continue
+				}
+				started, _ := time.ParseInLocation(time.ANSIC, instance.Started, time.Local)
+
+				registeredGroups[pgid] = true
+				jobs = append(jobs, runningJob{
+					LineKey: line.Key(crontab.CanonicalName()),
+					Command: line.CommandToRun,
+					PID:     pgid,
+					PGID:    pgid,
+					Started: started,
+					Crontab: crontab.DisplayName(),
+					Source:  "ps",
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].Started.Before(jobs[j].Started) })
+	return jobs, nil
+}
+
+func init() {
+	RootCmd.AddCommand(psCmd)
+	psCmd.Flags().BoolVarP(&psJSON, "json", "j", false, "Output as JSON")
+}
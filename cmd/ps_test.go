@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+)
+
+func TestSelectRunningJobs(t *testing.T) {
+	jobs := []runningJob{
+		{Code: "abc123", LineKey: "key-1", PID: 100, PGID: 100},
+		{Code: "abc123", LineKey: "key-1", PID: 200, PGID: 200},
+		{LineKey: "key-2", PID: 301, PGID: 300},
+	}
+
+	tests := []struct {
+		target string
+		count  int
+	}{
+		{"abc123", 2},
+		{"key-2", 1},
+		{"300", 1},
+		{"301", 1},
+		{"999", 0},
+		{"missing", 0},
+	}
+
+	for _, test := range tests {
+		if selected := selectRunningJobs(jobs, test.target); len(selected) != test.count {
+			t.Errorf("selectRunningJobs(%q) matched %d jobs; want %d", test.target, len(selected), test.count)
+		}
+	}
+}
+
+func TestKillGroupMustMatchProcess(t *testing.T) {
+	pid := os.Getpid()
+	if pgid := lib.ProcessGroupID(pid); pgid > 1 {
+		if killGroup(runningJob{PID: pid, PGID: pgid}) != pgid {
+			t.Error("expected the process's own group to be stopped")
+		}
+	}
+
+	other := exec.Command("sleep", "5")
+	if err := other.Start(); err != nil {
+		t.Skip("cannot start a process to test with")
+	}
+	defer other.Process.Kill()
+
+	for _, pgid := range []int{0, 1, other.Process.Pid} {
+		if group := killGroup(runningJob{PID: pid, PGID: pgid}); group != 0 {
+			t.Errorf("expected a recorded group of %d to fall back to the process, got group %d", pgid, group)
+		}
+	}
+}
+
+func TestKillCommandFlags(t *testing.T) {
+	for _, flag := range []string{"signal", "grace"} {
+		if killCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("Expected flag '--%s' not found in kill command", flag)
+		}
+	}
+}
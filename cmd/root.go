@@ -36,9 +36,12 @@ var debugLog string
 var dev bool
 var hostname string
 var pingApiKey string
+var profileName string
 var verbose bool
 var noStdoutPassthru bool
 var users string
+var remoteHost string
+var sshIdentityFile string
 
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
@@ -47,6 +50,22 @@ var RootCmd = &cobra.Command{
 	Long: shortDescription(Version) + `
 
 Command line tools for Cronitor.io. See https://cronitor.io/docs/using-cronitor-cli for details.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// A profile that does not exist yet can still be created with configure, or inspected with profile
+		err := applyProfile(cmd)
+		if err == nil || cmd == configureCmd || cmd.Parent() == profileCmd {
+			return
+		}
+		// A crontab line can pin a profile that is missing from the config of the user cron runs it as, and the
+		// job must still run
+		if cmd == execCmd {
+			log(err.Error())
+			fmt.Fprintln(os.Stderr, "cronitor: "+err.Error())
+			return
+		}
+		Error(err.Error())
+		os.Exit(1)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -69,6 +88,16 @@ var varDashPassword = "CRONITOR_DASH_PASS"
 var varAllowedIPs = "CRONITOR_ALLOWED_IPS"
 var varUsers = "CRONITOR_USERS"
 var varApiVersion = "CRONITOR_API_VERSION"
+var varCronDialect = "CRONITOR_CRON_DIALECT"
+var varStateDir = "CRONITOR_STATE_DIR"
+var varRunHistoryDays = "CRONITOR_RUN_HISTORY_DAYS"
+var varRunHistoryRuns = "CRONITOR_RUN_HISTORY_RUNS"
+var varAuditLog = "CRONITOR_AUDIT_LOG"
+var varAuditSyslog = "CRONITOR_AUDIT_SYSLOG"
+var varTimeout = "CRONITOR_TIMEOUT"
+var varCABundle = "CRONITOR_CA_BUNDLE"
+var varURL = "CRONITOR_URL"
+var varProfile = "CRONITOR_PROFILE"
 
 func init() {
 	userAgent = fmt.Sprintf("CronitorCLI/%s", Version)
@@ -81,12 +110,17 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&environment, "env", environment, "Cronitor Environment")
 	RootCmd.PersistentFlags().StringVarP(&apiKey, "api-key", "k", apiKey, "Cronitor API Key")
 	RootCmd.PersistentFlags().StringVarP(&pingApiKey, "ping-api-key", "p", pingApiKey, "Ping API Key")
+	RootCmd.PersistentFlags().StringVar(&profileName, "profile", profileName, "Use the settings of this profile from the config file")
 	RootCmd.PersistentFlags().StringVarP(&hostname, "hostname", "n", hostname, "A unique identifier for this host (default: system hostname)")
 	RootCmd.PersistentFlags().StringVarP(&debugLog, "log", "l", debugLog, "Write debug logs to supplied file")
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", verbose, "Verbose output")
 	RootCmd.PersistentFlags().StringVarP(&users, "users", "u", users, "Comma-separated list of users whose crontabs to include (default: current user only)")
 
 	RootCmd.PersistentFlags().String("api-version", "", "Cronitor API version (e.g. 2025-11-28)")
+	RootCmd.PersistentFlags().Duration("timeout", 0, "Give up on a request to Cronitor after this long, including retries (default: 10s for pings, 2m for API calls)")
+	RootCmd.PersistentFlags().String("ca-bundle", "", "PEM file of additional CA certificates to trust, e.g. for a TLS-inspecting proxy")
+	RootCmd.PersistentFlags().StringVar(&outputQuery, "query", "", "Filter API output with a jq expression, e.g. '.monitors[].key'")
+	RootCmd.PersistentFlags().StringSliceVar(&outputFields, "fields", nil, "Only output these fields of each result, e.g. name,key,passing")
 	RootCmd.PersistentFlags().BoolVar(&dev, "use-dev", dev, "Dev mode")
 	RootCmd.PersistentFlags().MarkHidden("use-dev")
 
@@ -96,8 +130,11 @@ func init() {
 	viper.BindPFlag(varHostname, RootCmd.PersistentFlags().Lookup("hostname"))
 	viper.BindPFlag(varLog, RootCmd.PersistentFlags().Lookup("log"))
 	viper.BindPFlag(varPingApiKey, RootCmd.PersistentFlags().Lookup("ping-api-key"))
+	viper.BindPFlag(varProfile, RootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag(varConfig, RootCmd.PersistentFlags().Lookup("config"))
 	viper.BindPFlag(varApiVersion, RootCmd.PersistentFlags().Lookup("api-version"))
+	viper.BindPFlag(varTimeout, RootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag(varCABundle, RootCmd.PersistentFlags().Lookup("ca-bundle"))
 	viper.BindPFlag(varDashUsername, RootCmd.PersistentFlags().Lookup("dash-username"))
 	viper.BindPFlag(varDashPassword, RootCmd.PersistentFlags().Lookup("dash-password"))
 	viper.BindPFlag(varUsers, RootCmd.PersistentFlags().Lookup("users"))
@@ -130,8 +167,10 @@ func sendPing(endpoint string, uniqueIdentifier string, message string, series s
 		defer group.Done()
 	}
 
+	// Pings retry on their own below, alternating hosts, so they skip the retrying API client
 	Client := &http.Client{
-		Timeout: time.Second * 10,
+		Timeout:   lib.HTTPTimeout(time.Second * 10),
+		Transport: lib.SharedTransport(),
 	}
 
 	hostname := effectiveHostname()
@@ -209,7 +248,9 @@ func sendPing(endpoint string, uniqueIdentifier string, message string, series s
 	pingSent := false
 	uri := ""
 	for i := 1; i <= 6; i++ {
-		if dev {
+		if server := lib.ServerURL(); server != "" {
+			pingApiHost = server
+		} else if dev {
 			pingApiHost = "http://localhost:8000"
 		} else if i > 2 && pingApiHost == "https://cronitor.link" {
 			pingApiHost = "https://cronitor.io"
@@ -267,7 +308,7 @@ func effectiveHostname() string {
 		return viper.GetString(varHostname)
 	}
 
-	hostname, _ := os.Hostname()
+	hostname, _ := lib.ActiveTransport.Hostname()
 	return hostname
 }
 
@@ -392,12 +433,29 @@ func printLn() {
 }
 
 func isPathToDirectory(path string) bool {
-	fileInfo, err := os.Stat(path)
+	return lib.ActiveTransport.IsDir(path)
+}
+
+// addRemoteHostFlags adds the flags used to manage crontabs on another server over SSH
+func addRemoteHostFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&remoteHost, "host", remoteHost, "Manage crontabs on a remote server over SSH, e.g. deploy@web1 or deploy@web1:2222")
+	cmd.Flags().StringVar(&sshIdentityFile, "ssh-key", sshIdentityFile, "Private key to use with --host (default: ssh-agent and ~/.ssh/id_*)")
+}
+
+// connectRemoteHost switches crontab reads and writes to the --host server, if one was given.
+// Host keys are verified against ~/.ssh/known_hosts.
+func connectRemoteHost() {
+	if remoteHost == "" {
+		return
+	}
+
+	transport, err := lib.DialSSH(remoteHost, lib.SSHOptions{IdentityFile: sshIdentityFile})
 	if err != nil {
-		return false
+		fatal(err.Error(), 1)
 	}
 
-	return fileInfo.Mode().IsDir()
+	lib.UseTransport(transport)
+	log(fmt.Sprintf("Connected to %s over SSH", remoteHost))
 }
 
 func log(msg string) {
@@ -433,6 +491,10 @@ func formatStamp(timestamp float64) string {
 	return strconv.FormatFloat(timestamp, 'f', 3, 64)
 }
 
+func stampToTime(timestamp float64) time.Time {
+	return time.Unix(0, int64(timestamp*float64(time.Second)))
+}
+
 func shortDescription(version string) string {
 	return fmt.Sprintf("CronitorCLI version %s", version)
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 )
 
@@ -73,7 +74,7 @@ func init() {
 	RootCmd.AddCommand(siteCmd)
 	siteCmd.PersistentFlags().IntVar(&sitePage, "page", 1, "Page number")
 	siteCmd.PersistentFlags().IntVar(&sitePageSize, "page-size", 0, "Results per page")
-	siteCmd.PersistentFlags().StringVar(&siteFormat, "format", "", "Output format: json, table")
+	siteCmd.PersistentFlags().StringVar(&siteFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	siteCmd.PersistentFlags().StringVarP(&siteOutput, "output", "o", "", "Write output to file")
 }
 
@@ -87,70 +88,52 @@ Examples:
   cronitor site list
   cronitor site list --page-size 100`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.ListOptions{Page: sitePage, PageSize: sitePageSize, Concurrency: listConcurrency}
 
-		if sitePage > 1 {
-			params["page"] = fmt.Sprintf("%d", sitePage)
-		}
-		if sitePageSize > 0 {
-			params["pageSize"] = fmt.Sprintf("%d", sitePageSize)
+		format := outputFormat(siteFormat, "table")
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.Sites.List, client.Sites.All), format, siteOutput, siteColumns)
+			return
 		}
 
-		resp, err := client.GET("/sites", params)
+		page, err := client.Sites.List(cmd.Context(), opts)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to list sites: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "list sites", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		pageOutput(page, func() string {
+			if len(page.Items) == 0 {
+				return mutedStyle.Render("No sites found")
+			}
 
-		if siteFormat == "json" {
-			siteOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
+			table := &UITable{
+				Headers: siteColumns.headers,
+			}
 
-		var result struct {
-			Sites []struct {
-				Key              string `json:"key"`
-				Name             string `json:"name"`
-				ClientKey        string `json:"client_key"`
-				WebVitalsEnabled bool   `json:"webvitals_enabled"`
-				ErrorsEnabled    bool   `json:"errors_enabled"`
-				Sampling         int    `json:"sampling"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
+			for _, s := range page.Items {
+				table.Rows = append(table.Rows, siteColumns.row(s))
+			}
 
-		if len(result.Sites) == 0 {
-			siteOutputToTarget(mutedStyle.Render("No sites found"))
-			return
-		}
+			return table.Render()
+		}).print(format, siteOutput)
+	},
+}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "WEB VITALS", "ERRORS", "SAMPLING"},
+var siteColumns = listColumns[api.Site]{
+	noun:    "sites",
+	headers: []string{"NAME", "KEY", "WEB VITALS", "ERRORS", "SAMPLING"},
+	row: func(s api.Site) []string {
+		webVitals := "off"
+		if s.WebVitalsEnabled {
+			webVitals = successStyle.Render("on")
 		}
-
-		for _, s := range result.Sites {
-			webVitals := "off"
-			if s.WebVitalsEnabled {
-				webVitals = successStyle.Render("on")
-			}
-			errors := "off"
-			if s.ErrorsEnabled {
-				errors = successStyle.Render("on")
-			}
-			sampling := fmt.Sprintf("%d%%", s.Sampling)
-			table.Rows = append(table.Rows, []string{s.Name, s.Key, webVitals, errors, sampling})
+		errors := "off"
+		if s.ErrorsEnabled {
+			errors = successStyle.Render("on")
 		}
-
-		siteOutputToTarget(table.Render())
+		sampling := fmt.Sprintf("%d%%", s.Sampling)
+		return []string{s.Name, s.Key, webVitals, errors, sampling}
 	},
 }
 
@@ -166,30 +149,14 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if siteWithSnippet {
-			params["withSnippet"] = "true"
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.GET(fmt.Sprintf("/sites/%s", key), params)
+		site, err := client.Sites.Get(cmd.Context(), key, &api.SiteGetOptions{WithSnippet: siteWithSnippet})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get site: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "get site", fmt.Sprintf("Site '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Site '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		siteOutputToTarget(FormatJSON(resp.Body))
+		output{raw: site.Raw()}.print(outputFormat(siteFormat, "json"), siteOutput)
 	},
 }
 
@@ -208,38 +175,23 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(siteData), &js); err != nil {
+		var site api.Site
+		if err := json.Unmarshal([]byte(siteData), &site); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/sites", []byte(siteData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.Sites.Create(cmd.Context(), &site)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create site: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "create site", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Created site: %s (key: %s)", created.Name, created.Key))
+		Info(fmt.Sprintf("Client key for browser: %s", created.ClientKey))
 
-		var result struct {
-			Key       string `json:"key"`
-			Name      string `json:"name"`
-			ClientKey string `json:"client_key"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created site: %s (key: %s)", result.Name, result.Key))
-			Info(fmt.Sprintf("Client key for browser: %s", result.ClientKey))
-		} else {
-			Success("Site created")
-		}
-
-		if siteFormat == "json" {
-			siteOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(siteFormat, ""); format != "" {
+			output{raw: created.Raw()}.print(format, siteOutput)
 		}
 	},
 }
@@ -263,34 +215,21 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(siteData), &bodyMap); err != nil {
+		var site api.Site
+		if err := json.Unmarshal([]byte(siteData), &site); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/sites/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Sites.Update(cmd.Context(), key, &site)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update site: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Site '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update site", fmt.Sprintf("Site '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Site '%s' updated", key))
-		if siteFormat == "json" {
-			siteOutputToTarget(FormatJSON(resp.Body))
+		if format := outputFormat(siteFormat, ""); format != "" {
+			output{raw: updated.Raw()}.print(format, siteOutput)
 		}
 	},
 }
@@ -302,25 +241,13 @@ var siteDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.DELETE(fmt.Sprintf("/sites/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete site: %s", err))
-			os.Exit(1)
+		if err := client.Sites.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete site", fmt.Sprintf("Site '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Site '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Site '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Site '%s' deleted", key))
 	},
 }
 
@@ -368,86 +295,63 @@ Examples:
 			os.Exit(1)
 		}
 
-		payload := map[string]interface{}{
-			"site": siteQuerySite,
-			"type": siteQueryType,
+		query := &api.SiteQuery{
+			Site:     siteQuerySite,
+			Type:     siteQueryType,
+			Time:     siteQueryTime,
+			Start:    siteQueryStart,
+			End:      siteQueryEnd,
+			Timezone: siteQueryTimezone,
 		}
-
-		// Time range
-		if siteQueryTime != "" {
-			payload["time"] = siteQueryTime
-		} else {
-			payload["time"] = "24h"
-		}
-		if siteQueryStart != "" {
-			payload["start"] = siteQueryStart
-		}
-		if siteQueryEnd != "" {
-			payload["end"] = siteQueryEnd
-		}
-		if siteQueryTimezone != "" {
-			payload["timezone"] = siteQueryTimezone
+		if query.Time == "" {
+			query.Time = "24h"
 		}
 
 		// Metrics
 		if siteQueryMetrics != "" {
-			payload["metrics"] = splitAndTrimSite(siteQueryMetrics)
+			query.Metrics = splitAndTrimSite(siteQueryMetrics)
 		}
 
 		// Dimensions (for breakdown)
 		if siteQueryGroupBy != "" {
-			payload["dimensions"] = splitAndTrimSite(siteQueryGroupBy)
-		}
-
-		// Time bucket (for timeseries)
-		if siteQueryBucket != "" {
-			payload["time_bucket"] = siteQueryBucket
+			query.Dimensions = splitAndTrimSite(siteQueryGroupBy)
 		}
 
 		// Filters
 		if siteQueryFilters != "" {
-			filters := parseFilters(siteQueryFilters)
-			if len(filters) > 0 {
-				payload["filters"] = filters
-			}
+			query.Filters = parseFilters(siteQueryFilters)
 		}
 
+		// Time bucket (for timeseries)
+		query.TimeBucket = siteQueryBucket
+
 		// Order by
 		if siteQueryOrderBy != "" {
-			payload["order_by"] = splitAndTrimSite(siteQueryOrderBy)
+			query.OrderBy = splitAndTrimSite(siteQueryOrderBy)
 		}
 
 		// Compare
 		if siteQueryCompare {
-			payload["compare"] = "previous_time_range"
+			query.Compare = "previous_time_range"
 		}
 
 		// Pagination
 		if sitePage > 1 {
-			payload["page"] = sitePage
-		}
-		if sitePageSize > 0 {
-			payload["page_size"] = sitePageSize
+			query.Page = sitePage
 		}
+		query.PageSize = sitePageSize
 
-		body, _ := json.Marshal(payload)
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/sites/query", body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		result, err := client.Sites.Query(cmd.Context(), query)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to query site: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "query site", "")
 		}
 
 		// For query results, JSON is the default since structure varies by query type
-		if siteFormat == "table" {
-			renderQueryTable(resp.Body, siteQueryType)
+		if format := outputFormat(siteFormat, "json"); format == "table" && !outputFiltered() {
+			renderQueryTable(result, siteQueryType)
 		} else {
-			siteOutputToTarget(FormatJSON(resp.Body))
+			output{raw: result}.print(format, siteOutput)
 		}
 	},
 }
@@ -479,73 +383,40 @@ Examples:
   cronitor site error list --site my-site
   cronitor site error list --site my-site --page-size 100`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if sitePage > 1 {
-			params["page"] = fmt.Sprintf("%d", sitePage)
-		}
-		if sitePageSize > 0 {
-			params["pageSize"] = fmt.Sprintf("%d", sitePageSize)
-		}
-
 		siteKey, _ := cmd.Flags().GetString("site")
-		if siteKey != "" {
-			params["site"] = siteKey
-		}
 
-		resp, err := client.GET("/site_errors", params)
+		client := lib.NewCronitorClient(dev, log)
+		page, err := client.Sites.ListErrors(cmd.Context(), &api.SiteErrorListOptions{
+			ListOptions: api.ListOptions{Page: sitePage, PageSize: sitePageSize},
+			Site:        siteKey,
+		})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to list errors: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "list errors", "")
 		}
 
-		if siteFormat == "json" {
-			siteOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
-
-		var result struct {
-			Errors []struct {
-				Key       string `json:"key"`
-				Message   string `json:"message"`
-				ErrorType string `json:"error_type"`
-				Filename  string `json:"filename"`
-				Count     int    `json:"count"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
-
-		if len(result.Errors) == 0 {
-			siteOutputToTarget(mutedStyle.Render("No errors found"))
-			return
-		}
-
-		table := &UITable{
-			Headers: []string{"KEY", "TYPE", "MESSAGE", "FILE", "COUNT"},
-		}
+		pageOutput(page, func() string {
+			if len(page.Items) == 0 {
+				return mutedStyle.Render("No errors found")
+			}
 
-		for _, e := range result.Errors {
-			msg := e.Message
-			if len(msg) > 40 {
-				msg = msg[:37] + "..."
+			table := &UITable{
+				Headers: []string{"KEY", "TYPE", "MESSAGE", "FILE", "COUNT"},
 			}
-			filename := e.Filename
-			if len(filename) > 30 {
-				filename = "..." + filename[len(filename)-27:]
+
+			for _, e := range page.Items {
+				msg := e.Message
+				if len(msg) > 40 {
+					msg = msg[:37] + "..."
+				}
+				filename := e.Filename
+				if len(filename) > 30 {
+					filename = "..." + filename[len(filename)-27:]
+				}
+				table.Rows = append(table.Rows, []string{e.Key, e.ErrorType, msg, filename, fmt.Sprintf("%d", e.Count)})
 			}
-			table.Rows = append(table.Rows, []string{e.Key, e.ErrorType, msg, filename, fmt.Sprintf("%d", e.Count)})
-		}
 
-		siteOutputToTarget(table.Render())
+			return table.Render()
+		}).print(outputFormat(siteFormat, "table"), siteOutput)
 	},
 }
 
@@ -560,25 +431,14 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.GET(fmt.Sprintf("/site_errors/%s", key), nil)
+		siteError, err := client.Sites.GetError(cmd.Context(), key)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get error: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "get error", fmt.Sprintf("Error '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Error '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		siteOutputToTarget(FormatJSON(resp.Body))
+		output{raw: siteError.Raw()}.print(outputFormat(siteFormat, "json"), siteOutput)
 	},
 }
 
@@ -592,6 +452,7 @@ func init() {
 	siteCmd.AddCommand(siteErrorsCmd)
 
 	// List flags
+	addListAllFlags(siteListCmd)
 
 	// Get flags
 	siteGetCmd.Flags().BoolVar(&siteWithSnippet, "with-snippet", false, "Include JavaScript installation snippet")
@@ -624,18 +485,6 @@ func init() {
 	siteErrorListCmd.Flags().String("site", "", "Filter by site key")
 }
 
-func siteOutputToTarget(content string) {
-	if siteOutput != "" {
-		if err := os.WriteFile(siteOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", siteOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", siteOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
-
 func splitAndTrimSite(s string) []string {
 	parts := strings.Split(s, ",")
 	result := make([]string, 0, len(parts))
@@ -650,15 +499,15 @@ func splitAndTrimSite(s string) []string {
 
 // parseFilters parses filter strings in format "dimension:operator:value"
 // e.g., "device_type:eq:desktop,country_code:eq:US"
-func parseFilters(filterStr string) []map[string]string {
-	filters := []map[string]string{}
+func parseFilters(filterStr string) []api.SiteQueryFilter {
+	filters := []api.SiteQueryFilter{}
 	for _, f := range splitAndTrimSite(filterStr) {
 		parts := strings.SplitN(f, ":", 3)
 		if len(parts) == 3 {
-			filters = append(filters, map[string]string{
-				"dimension": parts[0],
-				"operator":  parts[1],
-				"value":     parts[2],
+			filters = append(filters, api.SiteQueryFilter{
+				Dimension: parts[0],
+				Operator:  parts[1],
+				Value:     parts[2],
 			})
 		}
 	}
@@ -669,7 +518,7 @@ func parseFilters(filterStr string) []map[string]string {
 func renderQueryTable(body []byte, queryType string) {
 	var result map[string]interface{}
 	if err := json.Unmarshal(body, &result); err != nil {
-		siteOutputToTarget(FormatJSON(body))
+		writeOutput(siteOutput, FormatJSON(body))
 		return
 	}
 
@@ -683,7 +532,7 @@ func renderQueryTable(body []byte, queryType string) {
 	case "error_groups":
 		renderErrorGroupsTable(result)
 	default:
-		siteOutputToTarget(FormatJSON(body))
+		writeOutput(siteOutput, FormatJSON(body))
 	}
 }
 
@@ -702,7 +551,7 @@ func renderAggregationTable(result map[string]interface{}) {
 		table.Rows = append(table.Rows, []string{k, formatSiteValue(v)})
 	}
 
-	siteOutputToTarget(table.Render())
+	writeOutput(siteOutput, table.Render())
 }
 
 func renderBreakdownTable(result map[string]interface{}) {
@@ -741,7 +590,7 @@ func renderBreakdownTable(result map[string]interface{}) {
 		table.Rows = append(table.Rows, values)
 	}
 
-	siteOutputToTarget(table.Render())
+	writeOutput(siteOutput, table.Render())
 }
 
 func renderTimeseriesTable(result map[string]interface{}) {
@@ -789,7 +638,7 @@ func renderTimeseriesTable(result map[string]interface{}) {
 		table.Rows = append(table.Rows, values)
 	}
 
-	siteOutputToTarget(table.Render())
+	writeOutput(siteOutput, table.Render())
 }
 
 func renderErrorGroupsTable(result map[string]interface{}) {
@@ -821,7 +670,7 @@ func renderErrorGroupsTable(result map[string]interface{}) {
 		})
 	}
 
-	siteOutputToTarget(table.Render())
+	writeOutput(siteOutput, table.Render())
 }
 
 func formatSiteValue(v interface{}) string {
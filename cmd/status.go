@@ -1,28 +1,17 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-type StatusMonitor struct {
-	Name    string `json:"name"`
-	Key     string `json:"key"`
-	Passing bool   `json:"passing"`
-	Paused  bool   `json:"paused"`
-}
-
-type StatusMonitors struct {
-	Monitors []StatusMonitor `json:"monitors"`
-}
-
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "View monitor status",
@@ -35,6 +24,9 @@ Examples:
 
   View status of a single monitor:
   $ cronitor status d3x0c1
+
+  Keep watching and see changes as they happen:
+  $ cronitor watch
 `,
 
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -46,45 +38,30 @@ Examples:
 	},
 
 	Run: func(cmd *cobra.Command, args []string) {
-		url := getCronitorApi().Url()
-		if len(args) > 0 {
-			url = url + "/" + args[0]
-		}
-
-		// @todo refactor this to use GetMonitors
-		response, err := getCronitorApi().GetRawResponse(url)
-		if err != nil {
-			fatal(fmt.Sprintf("Request to %s failed: %s", url, err), 1)
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		buf := new(bytes.Buffer)
-		json.Indent(buf, response, "", "  ")
-		log("\nResponse:")
-		log(buf.String() + "\n")
-
-		// Unmarshall the API response into the StatusMonitor struct.
-		// If this is a detail response (with a monitor code argument) we need to unmarshal it directly into a StatusMonitor
-		responseMonitors := StatusMonitors{}
-		if len(args) == 0 {
-			if err = json.Unmarshal(response, &responseMonitors); err != nil {
-				fatal(fmt.Sprintf("Error %s from %s: %s", err.Error(), url, response), 1)
+		var monitors []api.Monitor
+		if len(args) > 0 {
+			monitor, err := client.Monitors.Get(cmd.Context(), args[0], nil)
+			if err != nil {
+				exitOnAPIError(err, "get monitor status", fmt.Sprintf("Monitor '%s' not found", args[0]))
 			}
+			monitors = append(monitors, *monitor)
 		} else {
-			singleMonitor := StatusMonitor{}
-			if err = json.Unmarshal(response, &singleMonitor); err != nil {
-				fatal(fmt.Sprintf("Error %s from %s: %s", err.Error(), url, response), 1)
+			for monitor, err := range client.Monitors.All(cmd.Context(), &api.MonitorListOptions{}) {
+				if err != nil {
+					exitOnAPIError(err, "get monitor status", "")
+				}
+				monitors = append(monitors, monitor)
 			}
-
-			responseMonitors.Monitors = []StatusMonitor{singleMonitor}
 		}
 
-		fmt.Println(url)
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Health", "Name", "Code", "Alerts"})
 		table.SetAutoWrapText(false)
 		table.SetHeaderAlignment(3)
 
-		for _, v := range responseMonitors.Monitors {
+		for _, v := range monitors {
 			state := "Ok"
 			if !v.Passing {
 				state = "Failing"
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -60,7 +61,7 @@ var (
 func init() {
 	RootCmd.AddCommand(statuspageCmd)
 	statuspageCmd.PersistentFlags().IntVar(&statuspagePage, "page", 1, "Page number")
-	statuspageCmd.PersistentFlags().StringVar(&statuspageFormat, "format", "", "Output format: json, table")
+	statuspageCmd.PersistentFlags().StringVar(&statuspageFormat, "format", "", "Output format: json, jsonl, yaml, csv, tsv, table or template='{{.key}}'")
 	statuspageCmd.PersistentFlags().StringVarP(&statuspageOutput, "output", "o", "", "Write output to file")
 }
 
@@ -75,65 +76,50 @@ Examples:
   cronitor statuspage list --with-status
   cronitor statuspage list --with-components`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-		if statuspagePage > 1 {
-			params["page"] = fmt.Sprintf("%d", statuspagePage)
-		}
-		if statuspageWithStatus {
-			params["withStatus"] = "true"
-		}
-		if statuspageWithComponents {
-			params["withComponents"] = "true"
+		client := lib.NewCronitorClient(dev, log)
+		opts := &api.StatusPageListOptions{
+			ListOptions: api.ListOptions{Page: statuspagePage, Concurrency: listConcurrency},
+			StatusPageOptions: api.StatusPageOptions{
+				WithStatus:     statuspageWithStatus,
+				WithComponents: statuspageWithComponents,
+			},
 		}
 
-		resp, err := client.GET("/statuspages", params)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to list status pages: %s", err))
-			os.Exit(1)
-		}
+		format := outputFormat(statuspageFormat, "table")
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+		if listAll || format == "jsonl" {
+			writeItems(listItems(cmd.Context(), opts, client.StatusPages.List, client.StatusPages.All), format, statuspageOutput, statuspageColumns)
+			return
 		}
 
-		var result struct {
-			StatusPages []struct {
-				Key       string `json:"key"`
-				Name      string `json:"name"`
-				Subdomain string `json:"hosted_subdomain"`
-				Status    string `json:"status"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
+		page, err := client.StatusPages.List(cmd.Context(), opts)
+		if err != nil {
+			exitOnAPIError(err, "list status pages", "")
 		}
 
-		format := statuspageFormat
-		if format == "" {
-			format = "table"
-		}
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: statuspageColumns.headers,
+			}
 
-		if format == "json" {
-			statuspageOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
+			for _, sp := range page.Items {
+				table.Rows = append(table.Rows, statuspageColumns.row(sp))
+			}
 
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "SUBDOMAIN", "STATUS"},
-		}
+			return table.Render()
+		}).print(format, statuspageOutput)
+	},
+}
 
-		for _, sp := range result.StatusPages {
-			status := successStyle.Render(sp.Status)
-			if sp.Status != "operational" {
-				status = warningStyle.Render(sp.Status)
-			}
-			table.Rows = append(table.Rows, []string{sp.Name, sp.Key, sp.Subdomain, status})
+var statuspageColumns = listColumns[api.StatusPage]{
+	noun:    "status pages",
+	headers: []string{"NAME", "KEY", "SUBDOMAIN", "STATUS"},
+	row: func(sp api.StatusPage) []string {
+		status := successStyle.Render(sp.Status)
+		if sp.Status != "operational" {
+			status = warningStyle.Render(sp.Status)
 		}
-
-		statuspageOutputToTarget(table.Render())
+		return []string{sp.Name, sp.Key, sp.HostedSubdomain, status}
 	},
 }
 
@@ -144,25 +130,17 @@ var statuspageGetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.GET(fmt.Sprintf("/statuspages/%s", key), nil)
+		page, err := client.StatusPages.Get(cmd.Context(), key, &api.StatusPageOptions{
+			WithStatus:     statuspageWithStatus,
+			WithComponents: statuspageWithComponents,
+		})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to get status page: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "get status page", fmt.Sprintf("Status page '%s' not found", key))
 		}
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Status page '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		statuspageOutputToTarget(FormatJSON(resp.Body))
+		output{raw: page.Raw()}.print(outputFormat(statuspageFormat, "json"), statuspageOutput)
 	},
 }
 
@@ -181,35 +159,20 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(statuspageData), &js); err != nil {
+		var page api.StatusPage
+		if err := json.Unmarshal([]byte(statuspageData), &page); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/statuspages", []byte(statuspageData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.StatusPages.Create(cmd.Context(), &page)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create status page: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created status page: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Status page created")
+			exitOnAPIError(err, "create status page", "")
 		}
 
-		statuspageOutputToTarget(FormatJSON(resp.Body))
+		Success(fmt.Sprintf("Created status page: %s (key: %s)", created.Name, created.Key))
+		output{raw: created.Raw()}.print(outputFormat(statuspageFormat, "json"), statuspageOutput)
 	},
 }
 
@@ -231,33 +194,20 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(statuspageData), &bodyMap); err != nil {
+		var page api.StatusPage
+		if err := json.Unmarshal([]byte(statuspageData), &page); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/statuspages/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.StatusPages.Update(cmd.Context(), key, &page)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update status page: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Status page '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update status page", fmt.Sprintf("Status page '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Status page '%s' updated", key))
-		statuspageOutputToTarget(FormatJSON(resp.Body))
+		output{raw: updated.Raw()}.print(outputFormat(statuspageFormat, "json"), statuspageOutput)
 	},
 }
 
@@ -268,25 +218,13 @@ var statuspageDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
-
-		resp, err := client.DELETE(fmt.Sprintf("/statuspages/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete status page: %s", err))
-			os.Exit(1)
-		}
+		client := lib.NewCronitorClient(dev, log)
 
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Status page '%s' not found", key))
-			os.Exit(1)
+		if err := client.StatusPages.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete status page", fmt.Sprintf("Status page '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Status page '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Status page '%s' deleted", key))
 	},
 }
 
@@ -301,6 +239,7 @@ func init() {
 	// List flags
 	statuspageListCmd.Flags().BoolVar(&statuspageWithStatus, "with-status", false, "Include current status")
 	statuspageListCmd.Flags().BoolVar(&statuspageWithComponents, "with-components", false, "Include component details")
+	addListAllFlags(statuspageListCmd)
 
 	// Get flags
 	statuspageGetCmd.Flags().BoolVar(&statuspageWithStatus, "with-status", false, "Include current status")
@@ -337,59 +276,30 @@ Examples:
   cronitor statuspage component list --statuspage my-page
   cronitor statuspage component list --statuspage my-page --with-status`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := lib.NewAPIClient(dev, log)
-		params := make(map[string]string)
-
-		if componentStatuspage != "" {
-			params["statuspage"] = componentStatuspage
-		}
-		if statuspageWithStatus {
-			params["withStatus"] = "true"
-		}
-
-		resp, err := client.GET("/statuspage_components", params)
+		client := lib.NewCronitorClient(dev, log)
+		page, err := client.Components.List(cmd.Context(), &api.ComponentListOptions{
+			StatusPage: componentStatuspage,
+			WithStatus: statuspageWithStatus,
+		})
 		if err != nil {
-			Error(fmt.Sprintf("Failed to list components: %s", err))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "list components", "")
 		}
 
-		if statuspageFormat == "json" {
-			statuspageOutputToTarget(FormatJSON(resp.Body))
-			return
-		}
-
-		var result struct {
-			Components []struct {
-				Key        string `json:"key"`
-				Name       string `json:"name"`
-				Type       string `json:"type"`
-				Statuspage string `json:"statuspage"`
-				Autopub    bool   `json:"autopublish"`
-			} `json:"data"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err != nil {
-			Error(fmt.Sprintf("Failed to parse response: %s", err))
-			os.Exit(1)
-		}
-
-		table := &UITable{
-			Headers: []string{"NAME", "KEY", "TYPE", "STATUSPAGE", "AUTOPUBLISH"},
-		}
+		pageOutput(page, func() string {
+			table := &UITable{
+				Headers: []string{"NAME", "KEY", "TYPE", "STATUSPAGE", "AUTOPUBLISH"},
+			}
 
-		for _, c := range result.Components {
-			autopub := "no"
-			if c.Autopub {
-				autopub = "yes"
+			for _, c := range page.Items {
+				autopub := "no"
+				if c.Autopublish {
+					autopub = "yes"
+				}
+				table.Rows = append(table.Rows, []string{c.Name, c.Key, c.Type, c.StatusPage, autopub})
 			}
-			table.Rows = append(table.Rows, []string{c.Name, c.Key, c.Type, c.Statuspage, autopub})
-		}
 
-		statuspageOutputToTarget(table.Render())
+			return table.Render()
+		}).print(outputFormat(statuspageFormat, "table"), statuspageOutput)
 	},
 }
 
@@ -407,33 +317,19 @@ Examples:
 			os.Exit(1)
 		}
 
-		var js json.RawMessage
-		if err := json.Unmarshal([]byte(componentData), &js); err != nil {
+		var component api.Component
+		if err := json.Unmarshal([]byte(componentData), &component); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.POST("/statuspage_components", []byte(componentData), nil)
+		client := lib.NewCronitorClient(dev, log)
+		created, err := client.Components.Create(cmd.Context(), &component)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to create component: %s", err))
-			os.Exit(1)
+			exitOnAPIError(err, "create component", "")
 		}
 
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
-
-		var result struct {
-			Key  string `json:"key"`
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			Success(fmt.Sprintf("Created component: %s (key: %s)", result.Name, result.Key))
-		} else {
-			Success("Component created")
-		}
+		Success(fmt.Sprintf("Created component: %s (key: %s)", created.Name, created.Key))
 	},
 }
 
@@ -457,33 +353,20 @@ Examples:
 			os.Exit(1)
 		}
 
-		var bodyMap map[string]interface{}
-		if err := json.Unmarshal([]byte(componentData), &bodyMap); err != nil {
+		var component api.Component
+		if err := json.Unmarshal([]byte(componentData), &component); err != nil {
 			Error(fmt.Sprintf("Invalid JSON: %s", err))
 			os.Exit(1)
 		}
-		bodyMap["key"] = key
-		body, _ := json.Marshal(bodyMap)
 
-		client := lib.NewAPIClient(dev, log)
-		resp, err := client.PUT(fmt.Sprintf("/statuspage_components/%s", key), body, nil)
+		client := lib.NewCronitorClient(dev, log)
+		updated, err := client.Components.Update(cmd.Context(), key, &component)
 		if err != nil {
-			Error(fmt.Sprintf("Failed to update component: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Component '%s' not found", key))
-			os.Exit(1)
-		}
-
-		if !resp.IsSuccess() {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
+			exitOnAPIError(err, "update component", fmt.Sprintf("Component '%s' not found", key))
 		}
 
 		Success(fmt.Sprintf("Component '%s' updated", key))
-		statuspageOutputToTarget(FormatJSON(resp.Body))
+		output{raw: updated.Raw()}.print(outputFormat(statuspageFormat, "json"), statuspageOutput)
 	},
 }
 
@@ -493,25 +376,13 @@ var componentDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		client := lib.NewAPIClient(dev, log)
+		client := lib.NewCronitorClient(dev, log)
 
-		resp, err := client.DELETE(fmt.Sprintf("/statuspage_components/%s", key), nil, nil)
-		if err != nil {
-			Error(fmt.Sprintf("Failed to delete component: %s", err))
-			os.Exit(1)
-		}
-
-		if resp.IsNotFound() {
-			Error(fmt.Sprintf("Component '%s' not found", key))
-			os.Exit(1)
+		if err := client.Components.Delete(cmd.Context(), key); err != nil {
+			exitOnAPIError(err, "delete component", fmt.Sprintf("Component '%s' not found", key))
 		}
 
-		if resp.IsSuccess() {
-			Success(fmt.Sprintf("Component '%s' deleted", key))
-		} else {
-			Error(fmt.Sprintf("API Error (%d): %s", resp.StatusCode, resp.ParseError()))
-			os.Exit(1)
-		}
+		Success(fmt.Sprintf("Component '%s' deleted", key))
 	},
 }
 
@@ -531,15 +402,3 @@ func init() {
 	// Component update flags
 	componentUpdateCmd.Flags().StringVarP(&componentData, "data", "d", "", "JSON payload")
 }
-
-func statuspageOutputToTarget(content string) {
-	if statuspageOutput != "" {
-		if err := os.WriteFile(statuspageOutput, []byte(content+"\n"), 0644); err != nil {
-			Error(fmt.Sprintf("Failed to write to %s: %s", statuspageOutput, err))
-			os.Exit(1)
-		}
-		Info(fmt.Sprintf("Output written to %s", statuspageOutput))
-	} else {
-		fmt.Println(content)
-	}
-}
@@ -0,0 +1,1017 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuiRefresh  time.Duration
+	tuiSelector string
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse monitors, issues and cron jobs in a full-screen terminal UI",
+	Long: `Browse monitors, issues and the cron jobs on this host in a full-screen terminal UI that refreshes itself.
+
+Keys:
+  tab, 1-3      Switch between the monitors, issues and jobs panes
+  ↑/↓, j/k      Move the selection
+  enter         Show details: a monitor's invocations and events, an issue, or a job's last run
+  /             Filter monitors with a selector such as 'tag=db,state=failing,group=production'
+  s             Cycle the monitor state filter: failing, paused, passing, all
+  p             Pause or unpause the selected monitor
+  r             Resolve the selected issue
+  l             Show the latest local run output of the selected monitor or job
+  x             Run the selected cron job now
+  R             Refresh now
+  ?             Show the keys
+  q             Quit
+
+Examples:
+  cronitor tui
+  cronitor tui --selector 'state=failing' --refresh 10s`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		filter, err := parseMonitorSelector(tuiSelector)
+		if err != nil {
+			Error(err.Error())
+			os.Exit(1)
+		}
+
+		model := newTUIModel(lib.NewCronitorClient(dev, log), tuiSelector, filter, tuiRefresh)
+		if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+			Error(fmt.Sprintf("Could not start the terminal UI: %s", err))
+			os.Exit(1)
+		}
+	},
+}
+
+type tuiPane int
+
+const (
+	tuiMonitorsPane tuiPane = iota
+	tuiIssuesPane
+	tuiJobsPane
+)
+
+var tuiPaneNames = []string{"Monitors", "Issues", "Jobs"}
+
+var (
+	tuiTabStyle       = lipgloss.NewStyle().Padding(0, 1).Foreground(mutedColor)
+	tuiActiveTabStyle = tableHeaderStyle
+	tuiCursorStyle    = lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	tuiStatusBarStyle = lipgloss.NewStyle().Foreground(mutedColor)
+)
+
+// tuiJob is a cron job found in a crontab on this host
+type tuiJob struct {
+	crontab *lib.Crontab
+	line    *lib.Line
+}
+
+func (j tuiJob) key() string {
+	return j.line.Key(j.crontab.CanonicalName())
+}
+
+// tuiDetail is a scrollable page of text shown over the panes
+type tuiDetail struct {
+	title  string
+	lines  []string
+	offset int
+}
+
+func newTUIDetail(title, body string) *tuiDetail {
+	return &tuiDetail{title: title, lines: strings.Split(strings.TrimRight(body, "\n"), "\n")}
+}
+
+// tuiConfirm asks before running a cron job
+type tuiConfirm struct {
+	prompt string
+	job    tuiJob
+}
+
+type (
+	tuiTickMsg     time.Time
+	tuiMonitorsMsg struct {
+		monitors []api.Monitor
+		err      error
+	}
+	tuiIssuesMsg struct {
+		issues []api.Issue
+		err    error
+	}
+	tuiJobsMsg struct {
+		jobs     []tuiJob
+		lastRuns map[string]lib.LastRun
+	}
+	tuiActionMsg struct {
+		status string
+		err    error
+		pane   tuiPane
+	}
+	tuiDetailMsg struct {
+		detail *tuiDetail
+		err    error
+	}
+	tuiRunMsg struct {
+		key string
+		run lib.RunRecord
+		err error
+	}
+)
+
+type tuiModel struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	client *api.Client
+
+	pane    tuiPane
+	cursors [3]int
+
+	monitors []api.Monitor
+	issues   []api.Issue
+	jobs     []tuiJob
+	lastRuns map[string]lib.LastRun
+	running  map[string]bool
+
+	selector  string
+	filter    *api.MonitorListOptions
+	input     textinput.Model
+	filtering bool
+
+	confirm *tuiConfirm
+	detail  *tuiDetail
+	help    bool
+
+	status    string
+	statusErr bool
+	loading   int
+	refresh   time.Duration
+	refreshed time.Time
+	now       time.Time
+	width     int
+	height    int
+}
+
+func newTUIModel(client *api.Client, selector string, filter *api.MonitorListOptions, refresh time.Duration) tuiModel {
+	ctx, cancel := context.WithCancel(context.Background())
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "tag=db,state=failing,group=production"
+	filter.Concurrency = 4
+
+	return tuiModel{
+		ctx:       ctx,
+		cancel:    cancel,
+		client:    client,
+		selector:  selector,
+		filter:    filter,
+		input:     input,
+		running:   map[string]bool{},
+		loading:   3,
+		refresh:   refresh,
+		now:       time.Now(),
+		refreshed: time.Now(),
+		width:     80,
+		height:    24,
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.loadMonitors(), m.loadIssues(), m.loadJobs(), tuiTick())
+}
+
+func tuiTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tuiTickMsg(t) })
+}
+
+// reload fetches every pane again
+func (m *tuiModel) reload() tea.Cmd {
+	m.loading = 3
+	m.refreshed = m.now
+	return tea.Batch(m.loadMonitors(), m.loadIssues(), m.loadJobs())
+}
+
+func (m tuiModel) loadMonitors() tea.Cmd {
+	ctx, client, filter := m.ctx, m.client, *m.filter
+	return func() tea.Msg {
+		var monitors []api.Monitor
+		for monitor, err := range client.Monitors.All(ctx, &filter) {
+			if err != nil {
+				return tuiMonitorsMsg{err: err}
+			}
+			monitors = append(monitors, monitor)
+		}
+		return tuiMonitorsMsg{monitors: monitors}
+	}
+}
+
+func (m tuiModel) loadIssues() tea.Cmd {
+	ctx, client := m.ctx, m.client
+	return func() tea.Msg {
+		page, err := client.Issues.List(ctx, &api.IssueListOptions{ListOptions: api.ListOptions{PageSize: 100}})
+		if err != nil {
+			return tuiIssuesMsg{err: err}
+		}
+		return tuiIssuesMsg{issues: page.Items}
+	}
+}
+
+func (m tuiModel) loadJobs() tea.Cmd {
+	return func() tea.Msg {
+		var jobs []tuiJob
+		for _, crontab := range gatherCrontabs(nil) {
+			for _, line := range jobLines(crontab) {
+				jobs = append(jobs, tuiJob{crontab: crontab, line: line})
+			}
+		}
+		lastRuns, _ := lib.ReadLastRuns(lastRunDir())
+		return tuiJobsMsg{jobs: jobs, lastRuns: lastRuns}
+	}
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.input.Width = msg.Width - 4
+		return m, nil
+
+	case tuiTickMsg:
+		m.now = time.Time(msg)
+		if m.refresh > 0 && m.loading == 0 && m.now.Sub(m.refreshed) >= m.refresh {
+			return m, tea.Batch(m.reload(), tuiTick())
+		}
+		return m, tuiTick()
+
+	case tuiMonitorsMsg:
+		m.loading--
+		if msg.err != nil {
+			m.setError("Could not load monitors", msg.err)
+			return m, nil
+		}
+		m.monitors = msg.monitors
+		m.clampCursor(tuiMonitorsPane, len(m.monitors))
+		return m, nil
+
+	case tuiIssuesMsg:
+		m.loading--
+		if msg.err != nil {
+			m.setError("Could not load issues", msg.err)
+			return m, nil
+		}
+		m.issues = msg.issues
+		m.clampCursor(tuiIssuesPane, len(m.issues))
+		return m, nil
+
+	case tuiJobsMsg:
+		m.loading--
+		m.jobs, m.lastRuns = msg.jobs, msg.lastRuns
+		m.clampCursor(tuiJobsPane, len(m.jobs))
+		return m, nil
+
+	case tuiActionMsg:
+		if msg.err != nil {
+			m.setError(msg.status, msg.err)
+			return m, nil
+		}
+		m.setStatus(msg.status)
+		m.loading++
+		if msg.pane == tuiIssuesPane {
+			return m, m.loadIssues()
+		}
+		return m, m.loadMonitors()
+
+	case tuiDetailMsg:
+		if msg.err != nil {
+			m.setError("Could not load details", msg.err)
+			return m, nil
+		}
+		m.detail = msg.detail
+		return m, nil
+
+	case tuiRunMsg:
+		delete(m.running, msg.key)
+		if msg.err != nil {
+			m.setError("Could not run the job", msg.err)
+			return m, nil
+		}
+		if msg.run.ExitCode == 0 {
+			m.setStatus(fmt.Sprintf("Finished in %s", msg.run.Duration().Round(time.Millisecond)))
+		} else {
+			m.setError(fmt.Sprintf("Failed after %s", msg.run.Duration().Round(time.Millisecond)), fmt.Errorf("exit code %d", msg.run.ExitCode))
+		}
+		m.detail = runDetail(msg.run)
+		m.loading++
+		return m, m.loadJobs()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.cancel()
+			return m, tea.Quit
+		}
+		return m.handleKey(msg)
+	}
+
+	if m.filtering {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	switch {
+	case m.filtering:
+		switch key {
+		case "enter":
+			selector := strings.TrimSpace(m.input.Value())
+			filter, err := parseMonitorSelector(selector)
+			if err != nil {
+				m.setError("Invalid filter", err)
+				return m, nil
+			}
+			m.filtering = false
+			m.input.Blur()
+			return m.applyFilter(selector, filter)
+		case "esc":
+			m.filtering = false
+			m.input.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+
+	case m.confirm != nil:
+		confirm := m.confirm
+		m.confirm = nil
+		if key == "y" || key == "enter" {
+			m.setStatus("Running " + confirm.job.line.CommandToRun)
+			return m, m.startJob(confirm.job)
+		}
+		m.setStatus("Cancelled")
+		return m, nil
+
+	case m.detail != nil:
+		visible := m.bodyHeight() - 1
+		switch key {
+		case "esc", "q", "backspace", "enter":
+			m.detail = nil
+		case "up", "k":
+			m.detail.offset--
+		case "down", "j":
+			m.detail.offset++
+		case "pgup":
+			m.detail.offset -= visible
+		case "pgdown", " ":
+			m.detail.offset += visible
+		case "home", "g":
+			m.detail.offset = 0
+		case "end", "G":
+			m.detail.offset = len(m.detail.lines)
+		}
+		if m.detail != nil {
+			m.detail.offset = max(0, min(m.detail.offset, len(m.detail.lines)-visible))
+		}
+		return m, nil
+
+	case m.help:
+		m.help = false
+		return m, nil
+	}
+
+	rows := m.rowCount()
+	cursor := &m.cursors[m.pane]
+
+	switch key {
+	case "q":
+		m.cancel()
+		return m, tea.Quit
+	case "?":
+		m.help = true
+	case "tab", "right":
+		m.pane = (m.pane + 1) % 3
+	case "shift+tab", "left":
+		m.pane = (m.pane + 2) % 3
+	case "1", "2", "3":
+		m.pane = tuiPane(key[0] - '1')
+	case "up", "k":
+		*cursor = max(0, *cursor-1)
+	case "down", "j":
+		*cursor = max(0, min(rows-1, *cursor+1))
+	case "pgup":
+		*cursor = max(0, *cursor-m.bodyHeight())
+	case "pgdown":
+		*cursor = max(0, min(rows-1, *cursor+m.bodyHeight()))
+	case "home", "g":
+		*cursor = 0
+	case "end", "G":
+		*cursor = max(0, rows-1)
+	case "R", "ctrl+r":
+		if m.loading == 0 {
+			m.setStatus("Refreshing")
+			return m, m.reload()
+		}
+	case "enter":
+		return m, m.showDetail()
+	case "l":
+		return m, m.showLogs()
+	case "/":
+		if m.pane != tuiMonitorsPane {
+			m.setStatus("Filters apply to the monitors pane")
+			return m, nil
+		}
+		m.filtering = true
+		m.input.SetValue(m.selector)
+		m.input.CursorEnd()
+		return m, m.input.Focus()
+	case "s":
+		if m.pane == tuiMonitorsPane {
+			selector := cycleSelectorState(m.selector)
+			filter, err := parseMonitorSelector(selector)
+			if err != nil {
+				m.setError("Invalid filter", err)
+				return m, nil
+			}
+			return m.applyFilter(selector, filter)
+		}
+	case "p":
+		if monitor, ok := m.selectedMonitor(); ok {
+			return m, m.togglePause(monitor)
+		}
+	case "r":
+		if issue, ok := m.selectedIssue(); ok {
+			return m, m.resolveIssue(issue)
+		}
+	case "x":
+		if job, ok := m.selectedJob(); ok {
+			if m.running[job.key()] {
+				m.setStatus("This job is already running")
+				return m, nil
+			}
+			m.confirm = &tuiConfirm{
+				prompt: fmt.Sprintf("Run %s now? (y/n)", job.line.CommandToRun),
+				job:    job,
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m tuiModel) applyFilter(selector string, filter *api.MonitorListOptions) (tea.Model, tea.Cmd) {
+	filter.Concurrency = m.filter.Concurrency
+	m.selector, m.filter = selector, filter
+	m.cursors[tuiMonitorsPane] = 0
+	m.loading++
+	return m, m.loadMonitors()
+}
+
+// cycleSelectorState moves the state in a selector to the next of failing, paused, passing and none
+func cycleSelectorState(selector string) string {
+	states := []string{"", "failing", "paused", "passing"}
+	current := ""
+	var terms []string
+	for _, term := range splitList(selector) {
+		if field, value, _ := strings.Cut(term, "="); strings.TrimSpace(field) == "state" {
+			current = strings.TrimSpace(value)
+			continue
+		}
+		terms = append(terms, term)
+	}
+
+	next := states[0]
+	for i, state := range states {
+		if state == current {
+			next = states[(i+1)%len(states)]
+		}
+	}
+	if next != "" {
+		terms = append(terms, "state="+next)
+	}
+	return strings.Join(terms, ",")
+}
+
+func (m *tuiModel) setStatus(status string) {
+	m.status, m.statusErr = status, false
+}
+
+func (m *tuiModel) setError(status string, err error) {
+	if errors.Is(err, api.ErrNotFound) {
+		err = errors.New("not found")
+	}
+	m.status, m.statusErr = fmt.Sprintf("%s: %s", status, err), true
+}
+
+func (m *tuiModel) clampCursor(pane tuiPane, rows int) {
+	m.cursors[pane] = max(0, min(m.cursors[pane], rows-1))
+}
+
+func (m tuiModel) rowCount() int {
+	switch m.pane {
+	case tuiMonitorsPane:
+		return len(m.monitors)
+	case tuiIssuesPane:
+		return len(m.issues)
+	}
+	return len(m.jobs)
+}
+
+func (m tuiModel) selectedMonitor() (api.Monitor, bool) {
+	if m.pane != tuiMonitorsPane || len(m.monitors) == 0 {
+		return api.Monitor{}, false
+	}
+	return m.monitors[m.cursors[tuiMonitorsPane]], true
+}
+
+func (m tuiModel) selectedIssue() (api.Issue, bool) {
+	if m.pane != tuiIssuesPane || len(m.issues) == 0 {
+		return api.Issue{}, false
+	}
+	return m.issues[m.cursors[tuiIssuesPane]], true
+}
+
+func (m tuiModel) selectedJob() (tuiJob, bool) {
+	if m.pane != tuiJobsPane || len(m.jobs) == 0 {
+		return tuiJob{}, false
+	}
+	return m.jobs[m.cursors[tuiJobsPane]], true
+}
+
+func (m tuiModel) togglePause(monitor api.Monitor) tea.Cmd {
+	ctx, client := m.ctx, m.client
+	return func() tea.Msg {
+		if monitor.Paused {
+			if err := client.Monitors.Unpause(ctx, monitor.Key); err != nil {
+				return tuiActionMsg{status: "Could not unpause " + monitor.Key, err: err}
+			}
+			return tuiActionMsg{status: "Unpaused " + monitor.Key}
+		}
+		if err := client.Monitors.Pause(ctx, monitor.Key, 0); err != nil {
+			return tuiActionMsg{status: "Could not pause " + monitor.Key, err: err}
+		}
+		return tuiActionMsg{status: "Paused " + monitor.Key}
+	}
+}
+
+func (m tuiModel) resolveIssue(issue api.Issue) tea.Cmd {
+	ctx, client := m.ctx, m.client
+	return func() tea.Msg {
+		if _, err := client.Issues.Resolve(ctx, issue.Key); err != nil {
+			return tuiActionMsg{status: "Could not resolve " + issue.Key, err: err, pane: tuiIssuesPane}
+		}
+		return tuiActionMsg{status: "Resolved " + issue.Key, pane: tuiIssuesPane}
+	}
+}
+
+func (m tuiModel) showDetail() tea.Cmd {
+	ctx, client := m.ctx, m.client
+	switch m.pane {
+	case tuiMonitorsPane:
+		selected, ok := m.selectedMonitor()
+		if !ok {
+			return nil
+		}
+		return func() tea.Msg {
+			monitor, err := client.Monitors.Get(ctx, selected.Key, &api.MonitorGetOptions{WithEvents: true, WithInvocations: true})
+			if err != nil {
+				return tuiDetailMsg{err: err}
+			}
+			return tuiDetailMsg{detail: monitorDetail(*monitor)}
+		}
+	case tuiIssuesPane:
+		selected, ok := m.selectedIssue()
+		if !ok {
+			return nil
+		}
+		return func() tea.Msg {
+			issue, err := client.Issues.Get(ctx, selected.Key, nil)
+			if err != nil {
+				return tuiDetailMsg{err: err}
+			}
+			return tuiDetailMsg{detail: issueDetail(*issue)}
+		}
+	}
+	return m.showLogs()
+}
+
+// showLogs shows the latest run of a job recorded on this host, found by its crontab line key or by
+// the monitor key it reports to
+func (m tuiModel) showLogs() tea.Cmd {
+	var filter lib.RunHistoryFilter
+	if monitor, ok := m.selectedMonitor(); ok {
+		filter.Code = monitor.Key
+	} else if job, ok := m.selectedJob(); ok {
+		filter.JobKey, filter.Code = job.key(), job.line.Code
+	} else {
+		return nil
+	}
+	filter.Limit = 1
+
+	return func() tea.Msg {
+		history, err := runHistory()
+		if err != nil {
+			return tuiDetailMsg{err: err}
+		}
+		defer closeRunHistory()
+
+		if err := history.IngestSpool(runSpoolDir()); err != nil {
+			log(fmt.Sprintf("Failed to read spooled runs: %v", err))
+		}
+		runs, err := history.List(filter)
+		if err != nil {
+			return tuiDetailMsg{err: err}
+		}
+		if len(runs) == 0 {
+			return tuiDetailMsg{err: errors.New("no runs recorded on this host")}
+		}
+		run, err := history.Get(runs[0].ID)
+		if err != nil || run == nil {
+			return tuiDetailMsg{err: fmt.Errorf("run %s is no longer in the history", runs[0].ID)}
+		}
+		return tuiDetailMsg{detail: runDetail(*run)}
+	}
+}
+
+// startJob runs a cron job the way cron would, with the crontab's shell and a minimal environment,
+// and hands the finished run to the dashboard's run history
+func (m *tuiModel) startJob(job tuiJob) tea.Cmd {
+	key := job.key()
+	m.running[key] = true
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		shell := job.crontab.Shell
+		if shell == "" {
+			shell = "/bin/sh"
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		defer cancel()
+
+		var output bytes.Buffer
+		cmd := exec.CommandContext(ctx, shell, "-c", job.line.CommandToRun)
+		cmd.Env = makeCronLikeEnv()
+		cmd.Stdin = strings.NewReader(job.line.Stdin)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		startedAt := time.Now()
+		err := cmd.Run()
+		exitCode := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else if err != nil {
+			return tuiRunMsg{key: key, err: err}
+		}
+
+		run := lib.NewRunRecord(lib.RunRecord{
+			JobKey:    key,
+			Code:      job.line.Code,
+			Command:   job.line.CommandToRun,
+			Trigger:   lib.RunTriggerTUI,
+			User:      lib.CurrentUsername(),
+			PID:       cmd.Process.Pid,
+			StartedAt: startedAt,
+			EndedAt:   time.Now(),
+			ExitCode:  exitCode,
+			Output:    output.String(),
+		})
+		spoolRun(run)
+		return tuiRunMsg{key: key, run: run}
+	}
+}
+
+func monitorDetail(monitor api.Monitor) *tuiDetail {
+	var b strings.Builder
+	for _, field := range [][2]string{
+		{"Key", monitor.Key},
+		{"Type", monitor.Type},
+		{"Status", StatusBadge(monitor.Passing, monitor.Paused)},
+		{"Schedule", monitor.Schedule},
+		{"Group", monitor.Group},
+		{"Tags", strings.Join(monitor.Tags, ", ")},
+		{"Notify", strings.Join(monitor.Notify, ", ")},
+	} {
+		if field[1] != "" {
+			b.WriteString(RenderKeyValue(field[0], field[1]) + "\n")
+		}
+	}
+
+	fields, _ := decodeJSON(monitor.Raw()).(map[string]any)
+	for _, section := range [][2]string{{"latest_invocations", "Recent invocations"}, {"latest_events", "Recent events"}} {
+		b.WriteString("\n" + boldStyle.Render(section[1]) + "\n")
+		b.WriteString(jsonItemsTable(fields[section[0]]) + "\n")
+	}
+
+	name := monitor.Name
+	if name == "" {
+		name = monitor.Key
+	}
+	return newTUIDetail(name, b.String())
+}
+
+func issueDetail(issue api.Issue) *tuiDetail {
+	var b strings.Builder
+	for _, field := range [][2]string{
+		{"Key", issue.Key},
+		{"State", issue.State},
+		{"Severity", issue.Severity},
+		{"Started", issue.Started},
+		{"Ended", issue.Ended},
+		{"Group", issue.Group},
+		{"Assigned to", issue.AssignedTo},
+		{"Monitors", strings.Join(issue.Monitors, ", ")},
+	} {
+		if field[1] != "" {
+			b.WriteString(RenderKeyValue(field[0], field[1]) + "\n")
+		}
+	}
+	if issue.Description != "" {
+		b.WriteString("\n" + issue.Description + "\n")
+	}
+	return newTUIDetail(issue.Name, b.String())
+}
+
+func runDetail(run lib.RunRecord) *tuiDetail {
+	var b strings.Builder
+	b.WriteString(RenderKeyValue("Command", run.Command) + "\n")
+	b.WriteString(RenderKeyValue("Started", run.StartedAt.Format(time.RFC3339)) + "\n")
+	b.WriteString(RenderKeyValue("Duration", run.Duration().Round(time.Millisecond).String()) + "\n")
+	exitCode := successStyle.Render("0")
+	if run.ExitCode != 0 {
+		exitCode = errorStyle.Render(fmt.Sprint(run.ExitCode))
+	}
+	b.WriteString(RenderKeyValue("Exit code", exitCode) + "\n")
+	if run.Trigger != "" {
+		b.WriteString(RenderKeyValue("Trigger", run.Trigger) + "\n")
+	}
+	if run.Truncated {
+		b.WriteString(mutedStyle.Render("Output was truncated to the last part") + "\n")
+	}
+	b.WriteString("\n")
+	if run.Output == "" {
+		b.WriteString(mutedStyle.Render("No output"))
+	} else {
+		b.WriteString(run.Output)
+	}
+	return newTUIDetail("Last run", b.String())
+}
+
+// jsonItemsTable renders a JSON array of objects, such as a monitor's latest events, as a table
+func jsonItemsTable(v any) string {
+	items, _ := v.([]any)
+	if len(items) == 0 {
+		return mutedStyle.Render("None")
+	}
+	columns := columnsOf(items[0])
+	table := &UITable{}
+	for _, column := range columns {
+		table.Headers = append(table.Headers, strings.ToUpper(column))
+	}
+	if columns == nil {
+		table.Headers = []string{"VALUE"}
+	}
+	for _, item := range items {
+		table.Rows = append(table.Rows, cellsOf(item, columns))
+	}
+	return strings.TrimRight(table.Render(), "\n")
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.tabsView() + "\n")
+
+	switch {
+	case m.help:
+		b.WriteString(m.helpView())
+	case m.detail != nil:
+		b.WriteString(m.detailView())
+	default:
+		b.WriteString(m.paneView())
+	}
+
+	return b.String() + "\n" + m.statusView()
+}
+
+func (m tuiModel) tabsView() string {
+	counts := []int{len(m.monitors), len(m.issues), len(m.jobs)}
+	var tabs []string
+	for i, name := range tuiPaneNames {
+		label := fmt.Sprintf("%d %s (%d)", i+1, name, counts[i])
+		if tuiPane(i) == m.pane {
+			tabs = append(tabs, tuiActiveTabStyle.Render(label))
+		} else {
+			tabs = append(tabs, tuiTabStyle.Render(label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// bodyHeight is the number of lines between the tabs and the status bar
+func (m tuiModel) bodyHeight() int {
+	return max(3, m.height-3)
+}
+
+func (m tuiModel) paneView() string {
+	var headers []string
+	var rows [][]string
+	var empty string
+
+	switch m.pane {
+	case tuiMonitorsPane:
+		headers, empty = monitorColumns.headers, "No monitors found"
+		if m.selector != "" {
+			empty = fmt.Sprintf("No monitors match %s", m.selector)
+		}
+		for _, monitor := range m.monitors {
+			rows = append(rows, monitorTableRow(monitor))
+		}
+	case tuiIssuesPane:
+		headers, empty = issueColumns.headers, "No issues"
+		for _, issue := range m.issues {
+			rows = append(rows, issueColumns.row(issue))
+		}
+	case tuiJobsPane:
+		headers, empty = []string{"SCHEDULE", "COMMAND", "MONITOR", "LAST RUN"}, "No cron jobs found on this host"
+		for _, job := range m.jobs {
+			rows = append(rows, m.jobRow(job))
+		}
+	}
+
+	height := m.bodyHeight()
+	if m.filtering {
+		height--
+	}
+
+	var lines []string
+	if len(rows) == 0 {
+		if m.loading > 0 {
+			empty = "Loading…"
+		}
+		lines = []string{"", mutedStyle.Render("  " + empty)}
+	} else {
+		cursor := m.cursors[m.pane]
+		for i := range rows {
+			marker := " "
+			if i == cursor {
+				marker = tuiCursorStyle.Render("›")
+			}
+			rows[i] = append([]string{marker}, rows[i]...)
+		}
+		table := &UITable{Headers: append([]string{""}, headers...), Rows: rows}
+		rendered := strings.Split(strings.TrimRight(table.Render(), "\n"), "\n")
+
+		// Keep the header and scroll the rows so the cursor stays in view
+		visible := height - 1
+		offset := max(0, cursor-visible+1)
+		end := min(len(rendered)-1, offset+visible)
+		lines = append([]string{rendered[0]}, rendered[1+offset:1+end]...)
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	if m.filtering {
+		lines = append(lines, m.input.View())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m tuiModel) jobRow(job tuiJob) []string {
+	monitor := mutedStyle.Render("-")
+	if job.line.Code != "" {
+		monitor = job.line.Code
+	}
+
+	lastRun := mutedStyle.Render("-")
+	if m.running[job.key()] {
+		lastRun = warningStyle.Render("running")
+	} else if run, ok := jobLastRun(m.lastRuns, job.crontab, job.line); ok {
+		ago := m.now.Sub(run.EndedAt).Round(time.Second)
+		if run.ExitCode == 0 {
+			lastRun = successStyle.Render(fmt.Sprintf("ok %s ago", ago))
+		} else {
+			lastRun = errorStyle.Render(fmt.Sprintf("exit %d %s ago", run.ExitCode, ago))
+		}
+	}
+
+	command := job.line.CommandToRun
+	if len(command) > 60 {
+		command = command[:57] + "..."
+	}
+	return []string{job.line.CronExpression, command, monitor, lastRun}
+}
+
+func (m tuiModel) detailView() string {
+	height := m.bodyHeight()
+	lines := []string{titleStyle.Render(m.detail.title) + mutedStyle.Render("  esc to go back")}
+
+	end := min(len(m.detail.lines), m.detail.offset+height-1)
+	lines = append(lines, m.detail.lines[min(m.detail.offset, end):end]...)
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m tuiModel) helpView() string {
+	keys := [][2]string{
+		{"tab, 1-3", "switch panes"},
+		{"↑/↓, j/k", "move the selection"},
+		{"enter", "details: invocations and events, an issue, or a job's last run"},
+		{"/", "filter monitors, e.g. tag=db,state=failing,group=production"},
+		{"s", "cycle the monitor state filter"},
+		{"p", "pause or unpause the selected monitor"},
+		{"r", "resolve the selected issue"},
+		{"l", "latest local run output of the selected monitor or job"},
+		{"x", "run the selected cron job now"},
+		{"R", "refresh now"},
+		{"q", "quit"},
+	}
+	lines := []string{titleStyle.Render("Keys"), ""}
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("  %s %s", boldStyle.Render(fmt.Sprintf("%-10s", key[0])), key[1]))
+	}
+	for len(lines) < m.bodyHeight() {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m tuiModel) statusView() string {
+	if m.confirm != nil {
+		return warningStyle.Render(m.confirm.prompt)
+	}
+
+	failing, paused := 0, 0
+	for _, monitor := range m.monitors {
+		if monitor.Paused {
+			paused++
+		} else if !monitor.Passing {
+			failing++
+		}
+	}
+	open := 0
+	for _, issue := range m.issues {
+		if issue.State != "resolved" {
+			open++
+		}
+	}
+
+	summary := []string{
+		errorStyle.Render(fmt.Sprintf("%d failing", failing)),
+		warningStyle.Render(fmt.Sprintf("%d paused", paused)),
+		fmt.Sprintf("%d open issues", open),
+	}
+	if m.selector != "" {
+		summary = append(summary, "filter "+m.selector)
+	}
+
+	var refresh string
+	switch {
+	case m.loading > 0:
+		refresh = "refreshing…"
+	case m.refresh > 0:
+		next := m.refresh - m.now.Sub(m.refreshed)
+		refresh = fmt.Sprintf("refreshed %s ago, next in %s", m.now.Sub(m.refreshed).Round(time.Second), max(0, next).Round(time.Second))
+	default:
+		refresh = fmt.Sprintf("refreshed %s ago", m.now.Sub(m.refreshed).Round(time.Second))
+	}
+	summary = append(summary, refresh, "? keys")
+
+	status := ""
+	if m.status != "" {
+		if m.statusErr {
+			status = errorStyle.Render(iconCross+" "+m.status) + "  "
+		} else {
+			status = successStyle.Render(iconCheck+" "+m.status) + "  "
+		}
+	}
+	return status + tuiStatusBarStyle.Render(strings.Join(summary, tuiStatusBarStyle.Render(" • ")))
+}
+
+func init() {
+	RootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().DurationVar(&tuiRefresh, "refresh", 30*time.Second, "How often to refresh, or 0 to refresh only with R")
+	tuiCmd.Flags().StringVar(&tuiSelector, "selector", "", "Show only matching monitors, e.g. 'tag=db,state=failing'")
+}
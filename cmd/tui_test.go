@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cronitorio/cronitor-cli/internal/testutil"
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+func newTestTUI(t *testing.T) (tuiModel, *testutil.MockAPI) {
+	t.Helper()
+	mock := testutil.NewMockAPI()
+	mock.On("GET", "/monitors", 200, testutil.LoadFixture("monitors_list.json"))
+	mock.On("GET", "/issues", 200, testutil.LoadFixture("issues_list.json"))
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	t.Cleanup(func() {
+		cleanup()
+		mock.Close()
+	})
+
+	m := newTUIModel(lib.NewCronitorClient(false, log), "", &api.MonitorListOptions{}, 0)
+	m = sendTUI(m, m.loadMonitors()())
+	m = sendTUI(m, m.loadIssues()())
+	return m, mock
+}
+
+func sendTUI(m tuiModel, msg tea.Msg) tuiModel {
+	updated, _ := m.Update(msg)
+	return updated.(tuiModel)
+}
+
+// pressTUI sends a key and returns the model with the command it started
+func pressTUI(m tuiModel, key string) (tuiModel, tea.Cmd) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(tuiModel), cmd
+}
+
+func TestTUI_ListsMonitorsAndIssues(t *testing.T) {
+	m, _ := newTestTUI(t)
+
+	view := m.View()
+	if !strings.Contains(view, "Monitors (3)") || !strings.Contains(view, "Issues (2)") {
+		t.Errorf("expected pane counts in the tabs, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Nightly Backup") || !strings.Contains(view, "1 failing") {
+		t.Errorf("expected monitors and a status summary, got:\n%s", view)
+	}
+
+	m, _ = pressTUI(m, "tab")
+	if view := m.View(); !strings.Contains(view, "Database connection timeout") {
+		t.Errorf("expected the issues pane, got:\n%s", view)
+	}
+}
+
+func TestTUI_PauseSelectedMonitor(t *testing.T) {
+	m, mock := newTestTUI(t)
+	mock.On("GET", "/monitors/def456/pause", 200, `{}`)
+
+	m, _ = pressTUI(m, "j")
+	m, cmd := pressTUI(m, "p")
+	if cmd == nil {
+		t.Fatal("expected p to pause the selected monitor")
+	}
+	msg := cmd()
+	if req := mock.LastRequest(); req.Path != "/monitors/def456/pause" {
+		t.Errorf("expected the second monitor to be paused, got %s %s", req.Method, req.Path)
+	}
+
+	m = sendTUI(m, msg)
+	if m.status != "Paused def456" || m.statusErr {
+		t.Errorf("expected a confirmation in the status bar, got %q", m.status)
+	}
+}
+
+func TestTUI_ResolveSelectedIssue(t *testing.T) {
+	m, mock := newTestTUI(t)
+	mock.On("GET", "/issues/issue-001", 200, `{"key":"issue-001","name":"Database connection timeout","state":"unresolved"}`)
+	mock.On("PUT", "/issues/issue-001", 200, `{"key":"issue-001","state":"resolved"}`)
+
+	m, _ = pressTUI(m, "2")
+	_, cmd := pressTUI(m, "r")
+	if cmd == nil {
+		t.Fatal("expected r to resolve the selected issue")
+	}
+	if msg := cmd().(tuiActionMsg); msg.err != nil {
+		t.Fatalf("unexpected error: %v", msg.err)
+	}
+	if req := mock.LastRequest(); req.Method != "PUT" || !strings.Contains(req.Body, `"state":"resolved"`) {
+		t.Errorf("expected the issue to be resolved, got %s %s %s", req.Method, req.Path, req.Body)
+	}
+}
+
+func TestTUI_FilterMonitors(t *testing.T) {
+	m, mock := newTestTUI(t)
+
+	m, _ = pressTUI(m, "/")
+	if !m.filtering {
+		t.Fatal("expected / to open the filter")
+	}
+	m, _ = pressTUI(m, "state=failing,tag=db")
+	m, cmd := pressTUI(m, "enter")
+	if m.filtering || m.selector != "state=failing,tag=db" || cmd == nil {
+		t.Fatalf("expected the filter to apply, got selector %q", m.selector)
+	}
+	cmd()
+	query := mock.LastRequest().QueryParams
+	if query.Get("state") != "failing" || query.Get("tag") != "db" {
+		t.Errorf("expected the selector to filter the request, got %v", query)
+	}
+
+	m, _ = pressTUI(m, "/")
+	m.input.SetValue("owner=me")
+	m, _ = pressTUI(m, "enter")
+	if !m.filtering || !m.statusErr {
+		t.Error("expected an invalid filter to be reported and kept open for editing")
+	}
+}
+
+func TestCycleSelectorState(t *testing.T) {
+	selector := "tag=db"
+	var seen []string
+	for i := 0; i < 4; i++ {
+		selector = cycleSelectorState(selector)
+		seen = append(seen, selector)
+	}
+	expected := []string{"tag=db,state=failing", "tag=db,state=paused", "tag=db,state=passing", "tag=db"}
+	if strings.Join(seen, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, seen)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/cronitorio/cronitor-cli/lib"
 )
 
 // Color palette
@@ -218,70 +217,6 @@ func FormatJSON(data []byte) string {
 	return prettyJSON.String()
 }
 
-// FetchAllPages fetches all pages from a paginated API endpoint.
-// It returns all response bodies as a slice, stopping when a page returns
-// an empty items array (identified by itemsKey in the JSON response).
-func FetchAllPages(client *lib.APIClient, endpoint string, params map[string]string, itemsKey string) ([][]byte, error) {
-	var bodies [][]byte
-	page := 1
-	for {
-		p := make(map[string]string)
-		for k, v := range params {
-			p[k] = v
-		}
-		p["page"] = fmt.Sprintf("%d", page)
-
-		resp, err := client.GET(endpoint, p)
-		if err != nil {
-			return bodies, err
-		}
-		if !resp.IsSuccess() {
-			return nil, fmt.Errorf("API Error (%d): %s", resp.StatusCode, resp.ParseError())
-		}
-		bodies = append(bodies, resp.Body)
-
-		// Check if there are items in this page
-		var raw map[string]json.RawMessage
-		if err := json.Unmarshal(resp.Body, &raw); err != nil {
-			break
-		}
-		if items, ok := raw[itemsKey]; ok {
-			var arr []json.RawMessage
-			if err := json.Unmarshal(items, &arr); err != nil || len(arr) == 0 {
-				break
-			}
-		} else {
-			break
-		}
-
-		page++
-		if page > 200 { // safety limit
-			break
-		}
-	}
-	return bodies, nil
-}
-
-// MergePagedJSON merges multiple paginated API responses into a single JSON array.
-// It extracts items from each page using the specified key and combines them.
-func MergePagedJSON(responses [][]byte, key string) []byte {
-	var allItems []json.RawMessage
-	for _, body := range responses {
-		var page map[string]json.RawMessage
-		if err := json.Unmarshal(body, &page); err != nil {
-			continue
-		}
-		if items, ok := page[key]; ok {
-			var arr []json.RawMessage
-			if err := json.Unmarshal(items, &arr); err == nil {
-				allItems = append(allItems, arr...)
-			}
-		}
-	}
-	result, _ := json.MarshalIndent(allItems, "", "  ")
-	return result
-}
-
 // RenderKeyValue renders a key-value pair
 func RenderKeyValue(key, value string) string {
 	return fmt.Sprintf("%s %s",
@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchTags         []string
+	watchGroup        string
+	watchInterval     time.Duration
+	watchMaxWait      time.Duration
+	watchBell         bool
+	watchOnChange     string
+	watchUntilPassing bool
+)
+
+// watchHistoryLength is how many recent changes the live board keeps on screen
+const watchHistoryLength = 10
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [key...]",
+	Short: "Watch monitors and issues live and report changes",
+	Long: `Watch the status of monitors and open issues, refreshing every --interval.
+
+In a terminal the status board is redrawn in place and recent changes are highlighted. When the output is
+piped, each change is printed on its own line instead.
+
+A change is a monitor starting to fail, recovering, being paused or unpaused, or an issue opening or
+being resolved. For each change --on-change runs a shell command with these environment variables:
+  CRONITOR_CHANGE_TYPE   monitor or issue
+  CRONITOR_CHANGE_KEY    the monitor or issue key
+  CRONITOR_CHANGE_NAME   the monitor or issue name
+  CRONITOR_CHANGE_FROM   the previous state, empty for a new issue
+  CRONITOR_CHANGE_TO     the new state
+
+With --until-passing, watch exits as soon as every watched monitor that is not paused is passing. It exits
+with status 1 if it is stopped or reaches --max-wait first. Watch exits with status 1 when no monitors match.
+
+Examples:
+  cronitor watch
+  cronitor watch --tag db --group production --interval 10s
+  cronitor watch --bell --on-change 'notify-send "$CRONITOR_CHANGE_NAME is $CRONITOR_CHANGE_TO"'
+  cronitor watch nightly-backup sync-users --until-passing --max-wait 15m`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if watchInterval < time.Second {
+			Error("--interval must be at least 1s")
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if watchMaxWait > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, watchMaxWait)
+			defer cancel()
+		}
+
+		stat, _ := os.Stdout.Stat()
+		w := &watcher{
+			client: lib.NewCronitorClient(dev, log),
+			opts:   &api.MonitorListOptions{Tags: watchTags, Group: watchGroup},
+			keys:   args,
+			live:   stat != nil && stat.Mode()&os.ModeCharDevice != 0,
+		}
+
+		var previous *watchSnapshot
+		for {
+			snapshot, err := w.fetch(ctx)
+			if err != nil && ctx.Err() == nil {
+				if previous == nil {
+					exitOnAPIError(err, "watch monitors", "")
+				}
+				w.render(previous, nil, err)
+			}
+
+			if err == nil && previous == nil {
+				if missing := snapshot.missing(args); len(missing) > 0 {
+					Error(fmt.Sprintf("Monitors not found: %s", strings.Join(missing, ", ")))
+					os.Exit(1)
+				}
+				// A typo in --tag or --group must not pass for a healthy selection
+				if len(snapshot.monitors) == 0 {
+					Error("No monitors match the given keys, tags and group")
+					os.Exit(1)
+				}
+			}
+
+			if err == nil {
+				changes := diffWatch(previous, snapshot, time.Now())
+				w.history = append(changes, w.history...)
+				if len(w.history) > watchHistoryLength {
+					w.history = w.history[:watchHistoryLength]
+				}
+				w.render(snapshot, changes, nil)
+				w.notify(changes)
+				previous = snapshot
+
+				if watchUntilPassing && len(snapshot.failing()) == 0 {
+					Success(fmt.Sprintf("All %d monitors are passing", len(snapshot.monitors)))
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				if watchUntilPassing {
+					failing := []string{}
+					if previous != nil {
+						failing = previous.failing()
+					}
+					Error(fmt.Sprintf("Stopped waiting with %d monitors failing: %s", len(failing), strings.Join(failing, ", ")))
+					os.Exit(1)
+				}
+				return
+			case <-time.After(watchInterval):
+			}
+		}
+	},
+}
+
+// watchSnapshot is the state of the watched monitors and open issues at one refresh
+type watchSnapshot struct {
+	at       time.Time
+	monitors []api.Monitor
+	issues   []api.Issue
+}
+
+// failing returns the keys of watched monitors that are neither passing nor paused
+func (s *watchSnapshot) failing() []string {
+	var keys []string
+	for _, m := range s.monitors {
+		if watchMonitorState(m) == "failing" {
+			keys = append(keys, m.Key)
+		}
+	}
+	return keys
+}
+
+// missing returns the keys that are not among the snapshot's monitors
+func (s *watchSnapshot) missing(keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if !slices.ContainsFunc(s.monitors, func(m api.Monitor) bool { return m.Key == key }) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// watchChange is a monitor or issue whose state changed between two refreshes
+type watchChange struct {
+	at   time.Time
+	kind string
+	key  string
+	name string
+	from string
+	to   string
+}
+
+// bad reports whether the change is something going wrong, which rings the bell
+func (c watchChange) bad() bool {
+	return c.to == "failing" || (c.kind == "issue" && c.from == "")
+}
+
+func (c watchChange) String() string {
+	from := c.from
+	if from == "" {
+		from = "new"
+	}
+	return fmt.Sprintf("%s %s: %s → %s", c.kind, c.name, from, c.to)
+}
+
+func watchMonitorState(m api.Monitor) string {
+	if m.Paused {
+		return "paused"
+	}
+	if m.Passing {
+		return "passing"
+	}
+	return "failing"
+}
+
+// diffWatch returns the changes from previous to next. The first snapshot sets the baseline and has none.
+func diffWatch(previous, next *watchSnapshot, at time.Time) []watchChange {
+	if previous == nil {
+		return nil
+	}
+
+	var changes []watchChange
+	before := map[string]string{}
+	for _, m := range previous.monitors {
+		before[m.Key] = watchMonitorState(m)
+	}
+	for _, m := range next.monitors {
+		state := watchMonitorState(m)
+		if from, ok := before[m.Key]; !ok || from != state {
+			changes = append(changes, watchChange{at: at, kind: "monitor", key: m.Key, name: watchName(m.Name, m.Key), from: from, to: state})
+		}
+	}
+
+	open := map[string]bool{}
+	for _, issue := range next.issues {
+		open[issue.Key] = true
+	}
+	wasOpen := map[string]string{}
+	for _, issue := range previous.issues {
+		wasOpen[issue.Key] = issue.State
+		if !open[issue.Key] {
+			changes = append(changes, watchChange{at: at, kind: "issue", key: issue.Key, name: watchName(issue.Name, issue.Key), from: issue.State, to: "resolved"})
+		}
+	}
+	for _, issue := range next.issues {
+		if from, ok := wasOpen[issue.Key]; !ok || from != issue.State {
+			changes = append(changes, watchChange{at: at, kind: "issue", key: issue.Key, name: watchName(issue.Name, issue.Key), from: from, to: issue.State})
+		}
+	}
+	return changes
+}
+
+func watchName(name, key string) string {
+	if name == "" {
+		return key
+	}
+	return name
+}
+
+// watcher fetches, draws and reports on the watched monitors
+type watcher struct {
+	client  *api.Client
+	opts    *api.MonitorListOptions
+	keys    []string
+	live    bool
+	started bool
+	history []watchChange
+}
+
+func (w *watcher) fetch(ctx context.Context) (*watchSnapshot, error) {
+	snapshot := &watchSnapshot{at: time.Now()}
+	watched := map[string]bool{}
+	for m, err := range w.client.Monitors.All(ctx, w.opts) {
+		if err != nil {
+			return nil, err
+		}
+		if len(w.keys) > 0 && !slices.Contains(w.keys, m.Key) {
+			continue
+		}
+		snapshot.monitors = append(snapshot.monitors, m)
+		watched[m.Key] = true
+	}
+
+	page, err := w.client.Issues.List(ctx, &api.IssueListOptions{ListOptions: api.ListOptions{PageSize: 100}, Group: w.opts.Group})
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range page.Items {
+		if issue.State == "resolved" {
+			continue
+		}
+		// Issues are only watched when they affect a watched monitor, unless every monitor is watched
+		filtered := len(w.keys) > 0 || len(w.opts.Tags) > 0
+		if filtered && !slices.ContainsFunc(issue.Monitors, func(key string) bool { return watched[key] }) {
+			continue
+		}
+		snapshot.issues = append(snapshot.issues, issue)
+	}
+	return snapshot, nil
+}
+
+// render redraws the board in a terminal, or prints the changes when the output is piped
+func (w *watcher) render(snapshot *watchSnapshot, changes []watchChange, fetchErr error) {
+	if !w.live {
+		if fetchErr != nil {
+			Warning(fmt.Sprintf("Refresh failed: %s", fetchErr))
+			return
+		}
+		if !w.started {
+			w.started = true
+			failing := snapshot.failing()
+			fmt.Printf("%s watching %d monitors, %d failing, %d open issues\n", snapshot.at.Format(time.TimeOnly), len(snapshot.monitors), len(failing), len(snapshot.issues))
+		}
+		for _, change := range changes {
+			line := fmt.Sprintf("%s %s", change.at.Format(time.TimeOnly), change)
+			if change.bad() {
+				line = errorStyle.Render(line)
+			} else {
+				line = successStyle.Render(line)
+			}
+			fmt.Println(line)
+		}
+		return
+	}
+
+	// Clear the screen and move to the top left before drawing the board again
+	var b strings.Builder
+	b.WriteString("\033[H\033[2J")
+
+	changed := map[string]watchChange{}
+	for _, change := range changes {
+		changed[change.kind+":"+change.key] = change
+	}
+
+	if snapshot != nil {
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Watching %d monitors", len(snapshot.monitors))))
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  every %s • updated %s • ctrl+c to stop", watchInterval, snapshot.at.Format(time.TimeOnly))) + "\n\n")
+
+		table := &UITable{Headers: []string{"NAME", "KEY", "TYPE", "STATUS", "CHANGED"}}
+		for _, m := range snapshot.monitors {
+			row := monitorTableRow(m)
+			change := ""
+			if c, ok := changed["monitor:"+m.Key]; ok && c.from != "" {
+				change = warningStyle.Render("was " + c.from)
+				row[0] = boldStyle.Render(row[0])
+			}
+			table.Rows = append(table.Rows, append(row, change))
+		}
+		b.WriteString(table.Render())
+
+		if len(snapshot.issues) > 0 {
+			b.WriteString("\n" + boldStyle.Render("Open issues") + "\n")
+			issues := &UITable{Headers: issueColumns.headers}
+			for _, issue := range snapshot.issues {
+				row := issueColumns.row(issue)
+				if _, ok := changed["issue:"+issue.Key]; ok {
+					row[0] = boldStyle.Render(row[0])
+				}
+				issues.Rows = append(issues.Rows, row)
+			}
+			b.WriteString(issues.Render())
+		}
+	}
+
+	if len(w.history) > 0 {
+		b.WriteString("\n" + boldStyle.Render("Recent changes") + "\n")
+		for _, change := range w.history {
+			line := fmt.Sprintf("  %s %s", change.at.Format(time.TimeOnly), change)
+			if change.bad() {
+				line = errorStyle.Render(line)
+			} else {
+				line = successStyle.Render(line)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if fetchErr != nil {
+		b.WriteString("\n" + warningStyle.Render(fmt.Sprintf("%s Refresh failed, retrying: %s", iconWarning, fetchErr)) + "\n")
+	}
+	fmt.Print(b.String())
+}
+
+// notify rings the bell for changes for the worse and runs the --on-change command for every change
+func (w *watcher) notify(changes []watchChange) {
+	if watchBell && slices.ContainsFunc(changes, watchChange.bad) {
+		fmt.Print("\a")
+	}
+	if watchOnChange == "" {
+		return
+	}
+
+	for _, change := range changes {
+		hook := makeSubcommandExec(watchOnChange)
+		hook.Env = append(os.Environ(),
+			"CRONITOR_CHANGE_TYPE="+change.kind,
+			"CRONITOR_CHANGE_KEY="+change.key,
+			"CRONITOR_CHANGE_NAME="+change.name,
+			"CRONITOR_CHANGE_FROM="+change.from,
+			"CRONITOR_CHANGE_TO="+change.to,
+		)
+		hook.Stdout = os.Stderr
+		hook.Stderr = os.Stderr
+		if err := hook.Run(); err != nil {
+			Warning(fmt.Sprintf("--on-change command failed for %s: %s", change.key, err))
+		}
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().StringSliceVar(&watchTags, "tag", nil, "Watch only monitors with this tag (repeatable)")
+	watchCmd.Flags().StringVar(&watchGroup, "group", "", "Watch only monitors in this group")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Second, "How often to refresh")
+	watchCmd.Flags().DurationVar(&watchMaxWait, "max-wait", 0, "Stop watching after this long, e.g. 15m")
+	watchCmd.Flags().BoolVar(&watchBell, "bell", false, "Ring the terminal bell when a monitor fails or an issue opens")
+	watchCmd.Flags().StringVar(&watchOnChange, "on-change", "", "Shell command to run for each change, with the change in CRONITOR_CHANGE_* variables")
+	watchCmd.Flags().BoolVar(&watchUntilPassing, "until-passing", false, "Exit once every watched monitor is passing, or with status 1 on --max-wait")
+}
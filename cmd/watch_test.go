@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/internal/testutil"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/viper"
+)
+
+func TestDiffWatch(t *testing.T) {
+	previous := &watchSnapshot{
+		monitors: []api.Monitor{{Key: "backup", Name: "Nightly backup", Passing: true}, {Key: "sync", Passing: false}},
+		issues:   []api.Issue{{Key: "issue-1", Name: "Sync failing", State: "unresolved"}},
+	}
+	if changes := diffWatch(nil, previous, time.Now()); changes != nil {
+		t.Errorf("expected the first snapshot to be the baseline, got %v", changes)
+	}
+
+	next := &watchSnapshot{
+		monitors: []api.Monitor{{Key: "backup", Name: "Nightly backup", Passing: false}, {Key: "sync", Passing: true}, {Key: "report", Paused: true}},
+		issues:   []api.Issue{{Key: "issue-2", Name: "Backup failing", State: "unresolved"}},
+	}
+	var got []string
+	for _, change := range diffWatch(previous, next, time.Now()) {
+		got = append(got, change.String())
+	}
+	expected := []string{
+		"monitor Nightly backup: passing → failing",
+		"monitor sync: failing → passing",
+		"monitor report: new → paused",
+		"issue Sync failing: unresolved → resolved",
+		"issue Backup failing: new → unresolved",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	if changes := diffWatch(next, next, time.Now()); len(changes) != 0 {
+		t.Errorf("expected no changes between identical snapshots, got %v", changes)
+	}
+}
+
+func TestWatchChange_Bad(t *testing.T) {
+	if !(watchChange{kind: "monitor", from: "passing", to: "failing"}).bad() {
+		t.Error("expected a failing monitor to be bad")
+	}
+	if !(watchChange{kind: "issue", to: "unresolved"}).bad() {
+		t.Error("expected a new issue to be bad")
+	}
+	if (watchChange{kind: "issue", from: "unresolved", to: "resolved"}).bad() {
+		t.Error("expected a resolved issue not to be bad")
+	}
+}
+
+func TestWatcher_OnChangeHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook is a POSIX shell command")
+	}
+	out := filepath.Join(t.TempDir(), "changes")
+	watchOnChange = `echo "$CRONITOR_CHANGE_TYPE $CRONITOR_CHANGE_KEY $CRONITOR_CHANGE_FROM $CRONITOR_CHANGE_TO" >> ` + out
+	defer func() { watchOnChange = "" }()
+
+	w := &watcher{}
+	w.notify([]watchChange{{kind: "monitor", key: "backup", from: "passing", to: "failing"}, {kind: "issue", key: "issue-1", to: "unresolved"}})
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("expected the hook to run: %v", err)
+	}
+	if string(data) != "monitor backup passing failing\nissue issue-1  unresolved\n" {
+		t.Errorf("unexpected hook output: %q", data)
+	}
+}
+
+func TestIntegration_Watch_UntilPassing(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, `{"monitors":[{"key":"backup","passing":true},{"key":"report","passing":false,"paused":true}],"page_info":{"page":1,"pageSize":50,"totalMonitorCount":2}}`)
+	mock.On("GET", "/issues", 200, `{"data":[]}`)
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+	defer func() { watchUntilPassing, watchTags = false, nil }()
+
+	output, err := executeCmd("watch", "--until-passing", "--tag", "db")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "watching 2 monitors, 0 failing, 0 open issues") || !strings.Contains(output, "All 2 monitors are passing") {
+		t.Errorf("expected a summary and an immediate exit, got:\n%s", output)
+	}
+	if got := mock.Requests[0].QueryParams.Get("tag"); got != "db" {
+		t.Errorf("expected monitors filtered by tag, got %q", got)
+	}
+}
+
+func TestIntegration_Watch_MaxWaitAndRequestTimeout(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, `{"monitors":[{"key":"backup","passing":true}],"page_info":{"page":1,"pageSize":50,"totalMonitorCount":1}}`)
+	mock.On("GET", "/issues", 200, `{"data":[]}`)
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+	defer func() {
+		watchUntilPassing, watchMaxWait = false, 0
+		watchCmd.Flags().Lookup("max-wait").Changed = false
+		RootCmd.PersistentFlags().Set("timeout", "0s")
+		RootCmd.PersistentFlags().Lookup("timeout").Changed = false
+	}()
+
+	if _, err := executeCmd("watch", "--until-passing", "--max-wait", "15m", "--timeout", "5s"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watchMaxWait != 15*time.Minute {
+		t.Errorf("expected --max-wait to set how long to watch, got %v", watchMaxWait)
+	}
+	if got := viper.GetDuration(varTimeout); got != 5*time.Second {
+		t.Errorf("expected --timeout to set the request timeout, got %v", got)
+	}
+}
+
+func TestIntegration_Status(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, testutil.LoadFixture("monitors_list.json"))
+
+	cleanup := setupIntegrationTest(mock.Server.URL)
+	defer cleanup()
+
+	output, err := executeCmd("status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"Health Check", "Failing", "Muted"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in the status table, got:\n%s", expected, output)
+		}
+	}
+}
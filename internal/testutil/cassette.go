@@ -0,0 +1,277 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// record switches cassettes from replaying testdata/cassettes to recording them from a real API:
+//
+//	CRONITOR_API_KEY=... go test ./cmd -run TestCassette -record
+//
+// Requests go to CRONITOR_RECORD_URL, https://cronitor.io/api by default. Point it at
+// `cronitor devserver` (http://localhost:8000/api) to record without an account.
+var record = flag.Bool("record", false, "record cassettes from the API at CRONITOR_RECORD_URL instead of replaying them")
+
+// scrubbedKey replaces API keys in recorded cassettes
+const scrubbedKey = "<API_KEY>"
+
+// T is the part of testing.T a cassette uses
+type T interface {
+	Helper()
+	Name() string
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Cleanup(func())
+}
+
+// Interaction is a recorded request and the response the API gave to it
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is what a request is matched on: method, path, query and body must all be equal
+type CassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response
+type CassetteResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// Cassette is a test HTTP server that replays the interactions recorded in testdata/cassettes/<test name>.json,
+// or records them when the tests run with -record. Like MockAPI, point the client at Server.URL.
+//
+// When replaying, a request that matches no unused interaction gets a 501 response, and both unmatched requests
+// and interactions that were never requested fail the test when it ends.
+type Cassette struct {
+	Server       *httptest.Server
+	Path         string
+	Interactions []Interaction
+
+	t         T
+	recording bool
+	upstream  string
+	apiKey    string
+	mu        sync.Mutex
+	used      []bool
+	unmatched []CassetteRequest
+}
+
+// CassettesDir returns the directory cassettes are kept in
+func CassettesDir() string {
+	return filepath.Join(TestdataDir(), "cassettes")
+}
+
+// NewCassette starts a cassette server for the test, named after it
+func NewCassette(t T) *Cassette {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	return newCassette(t, filepath.Join(CassettesDir(), name+".json"), *record)
+}
+
+func newCassette(t T, path string, recording bool) *Cassette {
+	t.Helper()
+	c := &Cassette{t: t, Path: path, recording: recording}
+
+	if recording {
+		c.upstream = strings.TrimRight(os.Getenv("CRONITOR_RECORD_URL"), "/")
+		if c.upstream == "" {
+			c.upstream = "https://cronitor.io/api"
+		}
+		c.apiKey = os.Getenv("CRONITOR_API_KEY")
+		if c.apiKey == "" {
+			t.Fatalf("recording %s requires CRONITOR_API_KEY", path)
+			return nil
+		}
+		c.Server = httptest.NewServer(http.HandlerFunc(c.recordHandler))
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("cannot load cassette, record it with -record: %v", err)
+			return nil
+		}
+		if err := json.Unmarshal(data, &c.Interactions); err != nil {
+			t.Fatalf("cannot read cassette %s: %v", path, err)
+			return nil
+		}
+		c.used = make([]bool, len(c.Interactions))
+		c.Server = httptest.NewServer(http.HandlerFunc(c.replayHandler))
+	}
+
+	t.Cleanup(c.finish)
+	return c
+}
+
+// Unused returns the recorded interactions that were not requested
+func (c *Cassette) Unused() []CassetteRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var unused []CassetteRequest
+	for i, used := range c.used {
+		if !used {
+			unused = append(unused, c.Interactions[i].Request)
+		}
+	}
+	return unused
+}
+
+// Unmatched returns the requests that matched no recorded interaction
+func (c *Cassette) Unmatched() []CassetteRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CassetteRequest(nil), c.unmatched...)
+}
+
+func (c *Cassette) finish() {
+	c.Server.Close()
+
+	if c.recording {
+		var data bytes.Buffer
+		encoder := json.NewEncoder(&data)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(c.Interactions)
+		if err == nil {
+			os.MkdirAll(filepath.Dir(c.Path), 0755)
+			err = os.WriteFile(c.Path, data.Bytes(), 0644)
+		}
+		if err != nil {
+			c.t.Errorf("cannot save cassette %s: %v", c.Path, err)
+		}
+		return
+	}
+
+	for _, request := range c.Unmatched() {
+		c.t.Errorf("cassette %s: no recorded interaction matches %s", filepath.Base(c.Path), request)
+	}
+	for _, request := range c.Unused() {
+		c.t.Errorf("cassette %s: recorded interaction was not requested: %s", filepath.Base(c.Path), request)
+	}
+}
+
+func (c *Cassette) replayHandler(w http.ResponseWriter, r *http.Request) {
+	request := c.readRequest(r)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.Interactions {
+		if !c.used[i] && interaction.Request == request {
+			c.used[i] = true
+			writeCassetteResponse(w, interaction.Response)
+			return
+		}
+	}
+
+	c.unmatched = append(c.unmatched, request)
+	http.Error(w, fmt.Sprintf("cassette: no recorded interaction matches %s", request), http.StatusNotImplemented)
+}
+
+func (c *Cassette) recordHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	request := c.readRequest(&http.Request{Method: r.Method, URL: r.URL, Header: r.Header, Body: io.NopCloser(bytes.NewReader(body))})
+
+	upstreamURL := c.upstream + r.URL.Path
+	if r.URL.RawQuery != "" {
+		upstreamURL += "?" + r.URL.RawQuery
+	}
+	upstreamRequest, _ := http.NewRequest(r.Method, upstreamURL, bytes.NewReader(body))
+	for _, header := range []string{"Content-Type", "Accept", "Cronitor-Version", "User-Agent"} {
+		if value := r.Header.Get(header); value != "" {
+			upstreamRequest.Header.Set(header, value)
+		}
+	}
+	upstreamRequest.SetBasicAuth(c.apiKey, "")
+
+	resp, err := http.DefaultClient.Do(upstreamRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	responseBody, _ := io.ReadAll(resp.Body)
+
+	response := CassetteResponse{
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        strings.ReplaceAll(string(responseBody), c.apiKey, scrubbedKey),
+	}
+
+	c.mu.Lock()
+	c.Interactions = append(c.Interactions, Interaction{Request: request, Response: response})
+	c.mu.Unlock()
+
+	w.Header().Set("Content-Type", response.ContentType)
+	w.WriteHeader(resp.StatusCode)
+	w.Write(responseBody)
+}
+
+// readRequest reads the parts of a request that are matched on, with API keys scrubbed, query parameters
+// sorted and JSON bodies in a canonical form so that equal requests compare equal
+func (c *Cassette) readRequest(r *http.Request) CassetteRequest {
+	body, _ := io.ReadAll(r.Body)
+	request := CassetteRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query().Encode(),
+		Body:   canonicalBody(body),
+	}
+
+	scrub := strings.NewReplacer()
+	if key, _, ok := r.BasicAuth(); ok && key != "" {
+		keys := []string{key, scrubbedKey, url.QueryEscape(key), scrubbedKey}
+		if c.apiKey != "" {
+			keys = append(keys, c.apiKey, scrubbedKey)
+		}
+		scrub = strings.NewReplacer(keys...)
+	}
+	request.Path = scrub.Replace(request.Path)
+	request.Query = scrub.Replace(request.Query)
+	request.Body = scrub.Replace(request.Body)
+	return request
+}
+
+func canonicalBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		canonical, _ := json.Marshal(v)
+		return string(canonical)
+	}
+	return string(body)
+}
+
+func writeCassetteResponse(w http.ResponseWriter, response CassetteResponse) {
+	if response.ContentType != "" {
+		w.Header().Set("Content-Type", response.ContentType)
+	}
+	w.WriteHeader(response.Status)
+	w.Write([]byte(response.Body))
+}
+
+func (r CassetteRequest) String() string {
+	s := r.Method + " " + r.Path
+	if r.Query != "" {
+		s += "?" + r.Query
+	}
+	if r.Body != "" {
+		s += " " + r.Body
+	}
+	return s
+}
@@ -0,0 +1,124 @@
+package testutil
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeT collects what a cassette reports, so failures can be asserted on
+type fakeT struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper()      {}
+func (f *fakeT) Name() string { return "fake" }
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+func (f *fakeT) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func send(t *testing.T, c *Cassette, method, path, body string) (int, string) {
+	t.Helper()
+	req, _ := http.NewRequest(method, c.Server.URL+path, strings.NewReader(body))
+	req.SetBasicAuth("local-test-key", "")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, _, _ := r.BasicAuth(); key != "live-secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"path":%q,"ping_url":"https://cronitor.link/p/live-secret-key/backup"}`, r.URL.Path)
+	}))
+	defer upstream.Close()
+	t.Setenv("CRONITOR_RECORD_URL", upstream.URL)
+	t.Setenv("CRONITOR_API_KEY", "live-secret-key")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := &fakeT{}
+	c := newCassette(recorder, path, true)
+	if status, body := send(t, c, "GET", "/monitors?page=1&tag=db", ""); status != 200 || !strings.Contains(body, `"path":"/monitors"`) {
+		t.Fatalf("expected the request to be proxied with the real key, got %d %s", status, body)
+	}
+	send(t, c, "PUT", "/monitors", `{"type":"job", "key":"backup"}`)
+	recorder.finish()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the cassette to be saved: %v", err)
+	}
+	if strings.Contains(string(data), "live-secret-key") || !strings.Contains(string(data), scrubbedKey) {
+		t.Errorf("expected API keys to be scrubbed from the cassette, got:\n%s", data)
+	}
+
+	replayer := &fakeT{}
+	c = newCassette(replayer, path, false)
+	if status, body := send(t, c, "PUT", "/monitors", `{"key":"backup","type":"job"}`); status != 200 || !strings.Contains(body, "/monitors") {
+		t.Errorf("expected an equal JSON body to match, got %d %s", status, body)
+	}
+	if status, _ := send(t, c, "GET", "/monitors?tag=web", ""); status != http.StatusNotImplemented {
+		t.Errorf("expected an unrecorded request to get a 501, got %d", status)
+	}
+	replayer.finish()
+
+	if len(replayer.errors) != 2 {
+		t.Fatalf("expected an unmatched and an unused interaction to be reported, got %v", replayer.errors)
+	}
+	if !strings.Contains(replayer.errors[0], "no recorded interaction matches GET /monitors?tag=web") {
+		t.Errorf("expected the unmatched request, got %q", replayer.errors[0])
+	}
+	if !strings.Contains(replayer.errors[1], "was not requested: GET /monitors?page=1&tag=db") {
+		t.Errorf("expected the unused interaction, got %q", replayer.errors[1])
+	}
+}
+
+func TestCassette_RepeatedRequestsReplayInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	os.WriteFile(path, []byte(`[
+  {"request": {"method": "GET", "path": "/monitors/backup"}, "response": {"status": 200, "body": "{\"paused\":false}"}},
+  {"request": {"method": "GET", "path": "/monitors/backup"}, "response": {"status": 200, "body": "{\"paused\":true}"}}
+]`), 0644)
+
+	replayer := &fakeT{}
+	c := newCassette(replayer, path, false)
+	_, first := send(t, c, "GET", "/monitors/backup", "")
+	_, second := send(t, c, "GET", "/monitors/backup", "")
+	replayer.finish()
+
+	if first != `{"paused":false}` || second != `{"paused":true}` || len(replayer.errors) != 0 {
+		t.Errorf("expected the recorded responses in order, got %s, %s, %v", first, second, replayer.errors)
+	}
+}
+
+func TestCassette_MissingCassette(t *testing.T) {
+	replayer := &fakeT{}
+	newCassette(replayer, filepath.Join(t.TempDir(), "missing.json"), false)
+	if len(replayer.errors) != 1 || !strings.Contains(replayer.errors[0], "record it with -record") {
+		t.Errorf("expected a hint to record the cassette, got %v", replayer.errors)
+	}
+}
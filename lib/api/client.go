@@ -0,0 +1,222 @@
+// Package api is a typed client for the Cronitor REST API.
+//
+// A Client exposes one service per resource, each returning models that keep the
+// JSON they were decoded from, so fields the SDK does not model yet survive a
+// round trip:
+//
+//	client := api.NewClient(os.Getenv("CRONITOR_API_KEY"))
+//	for monitor, err := range client.Monitors.All(ctx, &api.MonitorListOptions{States: []string{"failing"}}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(monitor.Key, monitor.Name)
+//	}
+//
+// Failed requests return an *Error, which can be matched with errors.Is against
+// ErrNotFound, ErrUnauthorized and ErrRateLimited.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the production Cronitor API
+	DefaultBaseURL = "https://cronitor.io/api"
+
+	// DevBaseURL is the Cronitor development API used by the CLI's --dev flag
+	DevBaseURL = "http://dev.cronitor.io/api"
+
+	defaultUserAgent = "cronitor-go"
+)
+
+// Client calls the Cronitor REST API. The zero value is not usable; create one with NewClient.
+type Client struct {
+	// BaseURL is the API root, without a trailing slash. Defaults to DefaultBaseURL.
+	BaseURL string
+
+	// APIKey authenticates every request
+	APIKey string
+
+	// APIVersion, when set, is sent as the Cronitor-Version header
+	APIVersion string
+
+	UserAgent  string
+	HTTPClient *http.Client
+
+	// Logger, when set, receives a line for every request and response
+	Logger func(string)
+
+	Monitors           *MonitorsService
+	Groups             *GroupsService
+	Issues             *IssuesService
+	Notifications      *NotificationsService
+	Environments       *EnvironmentsService
+	MaintenanceWindows *MaintenanceWindowsService
+	StatusPages        *StatusPagesService
+	Components         *ComponentsService
+	Sites              *SitesService
+	Metrics            *MetricsService
+}
+
+// NewClient creates a client for the production API authenticated with apiKey
+func NewClient(apiKey string) *Client {
+	c := &Client{
+		BaseURL:    DefaultBaseURL,
+		APIKey:     apiKey,
+		UserAgent:  defaultUserAgent,
+		HTTPClient: &http.Client{Timeout: 120 * time.Second, Transport: NewRetryTransport(nil)},
+	}
+
+	c.Monitors = &MonitorsService{client: c}
+	c.Groups = &GroupsService{client: c}
+	c.Issues = &IssuesService{client: c}
+	c.Notifications = &NotificationsService{client: c}
+	c.Environments = &EnvironmentsService{client: c}
+	c.MaintenanceWindows = &MaintenanceWindowsService{client: c}
+	c.StatusPages = &StatusPagesService{client: c}
+	c.Components = &ComponentsService{client: c}
+	c.Sites = &SitesService{client: c}
+	c.Metrics = &MetricsService{client: c}
+	return c
+}
+
+// Do sends a request to path, relative to BaseURL. A non-nil body is encoded as JSON unless it
+// is already a []byte or json.RawMessage. On success the response is decoded into out when out
+// is non-nil, and the raw body is returned either way. Non-2xx responses return an *Error.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body, out interface{}) ([]byte, error) {
+	return c.do(ctx, method, path, query, body, "application/json", out)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, contentType string, out interface{}) ([]byte, error) {
+	reqURL := strings.TrimRight(c.BaseURL, "/") + path
+	if encoded := query.Encode(); encoded != "" {
+		reqURL += "?" + encoded
+	}
+	c.log(fmt.Sprintf("API Request: %s %s", method, reqURL))
+
+	var bodyReader io.Reader
+	if body != nil {
+		var data []byte
+		switch b := body.(type) {
+		case []byte:
+			data = b
+		case json.RawMessage:
+			data = b
+		default:
+			var err error
+			if data, err = json.Marshal(body); err != nil {
+				return nil, fmt.Errorf("failed to encode request: %w", err)
+			}
+		}
+		bodyReader = bytes.NewReader(data)
+		c.log(fmt.Sprintf("Request Body: %s", data))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.APIKey, "")
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", c.UserAgent)
+	if c.APIVersion != "" {
+		req.Header.Set("Cronitor-Version", c.APIVersion)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	c.log(fmt.Sprintf("Response Status: %d", resp.StatusCode))
+	c.log(fmt.Sprintf("Response Body: %s", data))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return data, newError(resp, data)
+	}
+	if out != nil && len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return data, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return data, nil
+}
+
+func (c *Client) log(msg string) {
+	if c.Logger != nil {
+		c.Logger(msg)
+	}
+}
+
+// get, create, update and remove are the plumbing shared by the resource services
+
+func get[T any](ctx context.Context, c *Client, path string, query url.Values) (*T, error) {
+	out := new(T)
+	if _, err := c.Do(ctx, http.MethodGet, path, query, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func create[T any](ctx context.Context, c *Client, path string, body interface{}) (*T, error) {
+	out := new(T)
+	if _, err := c.Do(ctx, http.MethodPost, path, nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func update[T any](ctx context.Context, c *Client, path string, body interface{}) (*T, error) {
+	out := new(T)
+	if _, err := c.Do(ctx, http.MethodPut, path, nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func remove(ctx context.Context, c *Client, path string) error {
+	_, err := c.Do(ctx, http.MethodDelete, path, nil, nil, nil)
+	return err
+}
+
+// withKey returns body as a JSON object with "key" set, for endpoints that expect the key in the payload
+func withKey(body interface{}, key string) (map[string]interface{}, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	fields := map[string]interface{}{}
+	if err := unmarshalUseNumber(data, &fields); err != nil {
+		return nil, fmt.Errorf("request body must be a JSON object: %w", err)
+	}
+	fields["key"] = key
+	return fields, nil
+}
+
+func unmarshalUseNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func escape(key string) string {
+	return url.PathEscape(key)
+}
@@ -0,0 +1,192 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/internal/testutil"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+func newTestClient(serverURL string) *api.Client {
+	client := api.NewClient("test-api-key-1234567890")
+	client.BaseURL = serverURL
+	client.HTTPClient.Transport = fastRetries()
+	return client
+}
+
+func TestClient_SendsAuthAndVersion(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors/abc123", 200, testutil.LoadFixture("monitor_get.json"))
+
+	client := newTestClient(mock.Server.URL)
+	client.APIVersion = "2025-11-28"
+	if _, err := client.Monitors.Get(context.Background(), "abc123", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := mock.LastRequest()
+	if !strings.HasPrefix(req.Headers.Get("Authorization"), "Basic ") {
+		t.Errorf("expected basic auth, got %q", req.Headers.Get("Authorization"))
+	}
+	if req.Headers.Get("Cronitor-Version") != "2025-11-28" {
+		t.Errorf("expected Cronitor-Version header, got %q", req.Headers.Get("Cronitor-Version"))
+	}
+}
+
+func TestClient_TypedErrors(t *testing.T) {
+	tests := []struct {
+		status  int
+		fixture string
+		target  error
+		message string
+	}{
+		{404, "error_responses/404.json", api.ErrNotFound, ""},
+		{403, "error_responses/403.json", api.ErrUnauthorized, ""},
+		{429, "error_responses/429.json", api.ErrRateLimited, "Rate limit exceeded"},
+		{400, "error_responses/400.json", nil, "name is required; type must be one of: job, check, heartbeat, site"},
+	}
+
+	for _, tt := range tests {
+		mock := testutil.NewMockAPI()
+		mock.On("GET", "/monitors/abc123", tt.status, testutil.LoadFixture(tt.fixture))
+
+		_, err := newTestClient(mock.Server.URL).Monitors.Get(context.Background(), "abc123", nil)
+		mock.Close()
+
+		var apiErr *api.Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%d: expected *api.Error, got %v", tt.status, err)
+		}
+		if apiErr.StatusCode != tt.status {
+			t.Errorf("%d: expected status %d, got %d", tt.status, tt.status, apiErr.StatusCode)
+		}
+		if tt.target != nil && !errors.Is(err, tt.target) {
+			t.Errorf("%d: expected errors.Is(%v)", tt.status, tt.target)
+		}
+		if tt.message != "" && apiErr.Message != tt.message {
+			t.Errorf("%d: expected message %q, got %q", tt.status, tt.message, apiErr.Message)
+		}
+	}
+}
+
+func TestClient_ContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := newTestClient(server.URL).Monitors.Get(ctx, "abc123", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMonitors_ListEncodesOptions(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/monitors", 200, testutil.LoadFixture("monitors_list.json"))
+
+	page, err := newTestClient(mock.Server.URL).Monitors.List(context.Background(), &api.MonitorListOptions{
+		ListOptions: api.ListOptions{Page: 2, PageSize: 50},
+		Types:       []string{"job", "check"},
+		States:      []string{"failing"},
+		WithEvents:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query := mock.LastRequest().QueryParams
+	for param, want := range map[string]string{"page": "2", "pageSize": "50", "type": "job,check", "state": "failing", "withEvents": "true"} {
+		if got := query.Get(param); got != want {
+			t.Errorf("expected %s=%s, got %q", param, want, got)
+		}
+	}
+	if query.Has("withInvocations") || query.Has("group") {
+		t.Errorf("expected unset options to be omitted, got %v", query)
+	}
+
+	if len(page.Items) != 3 || page.Items[0].Key != "abc123" || page.Total != 3 || page.PageSize != 50 {
+		t.Errorf("unexpected page %+v", page)
+	}
+	if page.HasNext() {
+		t.Errorf("expected a single page")
+	}
+}
+
+func TestMonitors_DeleteManyReportsMissing(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("DELETE", "/monitors", 200, `{"deleted_count":1,"requested_count":2,"errors":{"missing":["nope"]}}`)
+
+	result, err := newTestClient(mock.Server.URL).Monitors.DeleteMany(context.Background(), []string{"abc123", "nope"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DeletedCount != 1 || len(result.Errors.Missing) != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if body := mock.LastRequest().Body; body != `{"monitors":["abc123","nope"]}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestIssues_ResolveSendsFullIssue(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/issues/issue-001", 200, `{"key":"issue-001","name":"Outage","state":"unresolved","severity":"outage","affected":["abc123"]}`)
+	mock.On("PUT", "/issues/issue-001", 200, `{"key":"issue-001","state":"resolved"}`)
+
+	issue, err := newTestClient(mock.Server.URL).Issues.Resolve(context.Background(), "issue-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issue.State != "resolved" {
+		t.Errorf("expected resolved issue, got %+v", issue)
+	}
+
+	body := mock.LastRequest().Body
+	for _, want := range []string{`"state":"resolved"`, `"severity":"outage"`, `"affected":["abc123"]`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in %s", want, body)
+		}
+	}
+}
+
+func TestMetrics_GetUsesTimeUnlessRangeIsSet(t *testing.T) {
+	mock := testutil.NewMockAPI()
+	defer mock.Close()
+	mock.On("GET", "/metrics", 200, testutil.LoadFixture("metrics_get.json"))
+
+	client := newTestClient(mock.Server.URL)
+	metrics, err := client.Metrics.Get(context.Background(), &api.MetricOptions{
+		Monitors: []string{"abc123"}, Fields: []string{"duration_p50", "success_rate"}, Time: "7d",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := mock.LastRequest().QueryParams
+	if query.Get("field") != "duration_p50,success_rate" || query.Get("time") != "7d" {
+		t.Errorf("unexpected query %v", query)
+	}
+	points := metrics.Monitors["abc123"]["production"]
+	if len(points) != 2 || points[0].Stamp() != 1710500000 {
+		t.Fatalf("unexpected points %v", points)
+	}
+	if value, ok := points[0].Value("duration_p50"); !ok || value != 1200.5 {
+		t.Errorf("expected duration_p50 of 1200.5, got %v", value)
+	}
+
+	client.Metrics.Aggregates(context.Background(), &api.MetricOptions{Monitors: []string{"abc123"}, Time: "7d", Start: 1710500000})
+	query = mock.LastRequest().QueryParams
+	if query.Has("time") || query.Get("start") != "1710500000" {
+		t.Errorf("expected start to replace time, got %v", query)
+	}
+}
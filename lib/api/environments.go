@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// Environment separates telemetry and alerting, such as staging from production
+type Environment struct {
+	resource
+	Key            string `json:"key"`
+	Name           string `json:"name"`
+	WithAlerts     bool   `json:"with_alerts"`
+	Default        bool   `json:"default"`
+	ActiveMonitors int    `json:"active_monitors"`
+}
+
+func (e *Environment) UnmarshalJSON(data []byte) error {
+	type plain Environment
+	return e.decode(data, (*plain)(e))
+}
+
+func (e Environment) MarshalJSON() ([]byte, error) {
+	type plain Environment
+	return e.encode(plain(e))
+}
+
+// EnvironmentsService manages environments
+type EnvironmentsService struct {
+	client *Client
+}
+
+// List fetches one page of environments
+func (s *EnvironmentsService) List(ctx context.Context, opts *ListOptions) (*Page[Environment], error) {
+	return list[Environment](ctx, s.client, "/environments", "environments", opts)
+}
+
+// All iterates every environment, starting from opts.Page
+func (s *EnvironmentsService) All(ctx context.Context, opts *ListOptions) iter.Seq2[Environment, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches an environment by key
+func (s *EnvironmentsService) Get(ctx context.Context, key string) (*Environment, error) {
+	return get[Environment](ctx, s.client, "/environments/"+escape(key), nil)
+}
+
+// Create creates an environment
+func (s *EnvironmentsService) Create(ctx context.Context, environment *Environment) (*Environment, error) {
+	return create[Environment](ctx, s.client, "/environments", environment)
+}
+
+// Update updates the environment with the given key
+func (s *EnvironmentsService) Update(ctx context.Context, key string, environment *Environment) (*Environment, error) {
+	body, err := withKey(environment, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[Environment](ctx, s.client, "/environments/"+escape(key), body)
+}
+
+// Delete deletes an environment
+func (s *EnvironmentsService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/environments/"+escape(key))
+}
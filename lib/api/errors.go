@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that an *Error matches with errors.Is, based on its status code
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Error is returned for any response outside the 2xx range
+type Error struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+	Header     http.Header
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("API Error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the error's status code corresponds to target
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+func newError(resp *http.Response, body []byte) *Error {
+	return &Error{
+		StatusCode: resp.StatusCode,
+		Message:    parseErrorMessage(body),
+		Body:       body,
+		Header:     resp.Header,
+	}
+}
+
+// parseErrorMessage extracts the message from the API's error shapes, falling back to the raw body
+func parseErrorMessage(body []byte) string {
+	var errResp struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		if errResp.Error != "" {
+			return errResp.Error
+		}
+		if errResp.Message != "" {
+			return errResp.Message
+		}
+		if len(errResp.Errors) > 0 {
+			var messages []string
+			for _, e := range errResp.Errors {
+				messages = append(messages, e.Message)
+			}
+			return strings.Join(messages, "; ")
+		}
+	}
+
+	return string(body)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+// Group is a named collection of monitors
+type Group struct {
+	resource
+	Key         string      `json:"key"`
+	Name        string      `json:"name"`
+	Monitors    []string    `json:"monitors"`
+	Created     string      `json:"created"`
+	LatestEvent *GroupEvent `json:"latest_event"`
+}
+
+// GroupEvent is the most recent state change of any monitor in a group
+type GroupEvent struct {
+	Stamp string `json:"stamp"`
+	State string `json:"state"`
+}
+
+func (g *Group) UnmarshalJSON(data []byte) error {
+	type plain Group
+	return g.decode(data, (*plain)(g))
+}
+
+func (g Group) MarshalJSON() ([]byte, error) {
+	type plain Group
+	return g.encode(plain(g))
+}
+
+// GroupListOptions filters GET /groups
+type GroupListOptions struct {
+	ListOptions
+	Env        string `url:"env"`
+	WithStatus bool   `url:"withStatus"`
+}
+
+// GroupGetOptions expands GET /groups/{key}
+type GroupGetOptions struct {
+	Env        string `url:"env"`
+	WithStatus bool   `url:"withStatus"`
+	Sort       string `url:"sort"`
+}
+
+// GroupsService manages monitor groups
+type GroupsService struct {
+	client *Client
+}
+
+// List fetches one page of groups
+func (s *GroupsService) List(ctx context.Context, opts *GroupListOptions) (*Page[Group], error) {
+	return list[Group](ctx, s.client, "/groups", "groups", opts)
+}
+
+// All iterates every group, starting from opts.Page
+func (s *GroupsService) All(ctx context.Context, opts *GroupListOptions) iter.Seq2[Group, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches a group by key
+func (s *GroupsService) Get(ctx context.Context, key string, opts *GroupGetOptions) (*Group, error) {
+	return get[Group](ctx, s.client, "/groups/"+escape(key), encodeQuery(opts))
+}
+
+// Create creates a group
+func (s *GroupsService) Create(ctx context.Context, group *Group) (*Group, error) {
+	return create[Group](ctx, s.client, "/groups", group)
+}
+
+// Update updates the group with the given key
+func (s *GroupsService) Update(ctx context.Context, key string, group *Group) (*Group, error) {
+	body, err := withKey(group, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[Group](ctx, s.client, "/groups/"+escape(key), body)
+}
+
+// Delete deletes a group. The monitors in it are not deleted.
+func (s *GroupsService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/groups/"+escape(key))
+}
+
+// Pause stops alerts for every monitor in the group for the given number of hours
+func (s *GroupsService) Pause(ctx context.Context, key string, hours int) error {
+	_, err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/groups/%s/pause/%d", escape(key), hours), nil, nil, nil)
+	return err
+}
+
+// Resume resumes alerts for every monitor in the group
+func (s *GroupsService) Resume(ctx context.Context, key string) error {
+	return s.Pause(ctx, key, 0)
+}
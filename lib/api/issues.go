@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+)
+
+// Issue is an incident affecting one or more monitors
+type Issue struct {
+	resource
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	Severity    string   `json:"severity"`
+	Started     string   `json:"started"`
+	Ended       string   `json:"ended"`
+	Monitors    []string `json:"monitors"`
+	Group       string   `json:"group"`
+	AssignedTo  string   `json:"assigned_to"`
+}
+
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	type plain Issue
+	return i.decode(data, (*plain)(i))
+}
+
+func (i Issue) MarshalJSON() ([]byte, error) {
+	type plain Issue
+	return i.encode(plain(i))
+}
+
+// IssueExpansions include related objects in issue responses
+type IssueExpansions struct {
+	WithStatusPageDetails bool `url:"withStatusPageDetails"`
+	WithMonitorDetails    bool `url:"withMonitorDetails"`
+	WithAlertDetails      bool `url:"withAlertDetails"`
+	WithComponentDetails  bool `url:"withComponentDetails"`
+}
+
+// IssueListOptions filters GET /issues
+type IssueListOptions struct {
+	ListOptions
+	IssueExpansions
+	State    string `url:"state"`
+	Severity string `url:"severity"`
+	Monitor  string `url:"job"`
+	Group    string `url:"group"`
+	Tag      string `url:"tag"`
+	Env      string `url:"env"`
+	Search   string `url:"search"`
+	Time     string `url:"time"`
+	OrderBy  string `url:"orderBy"`
+}
+
+// IssueBulkRequest applies one action to several issues: delete, change_state or assign_to
+type IssueBulkRequest struct {
+	Action   string   `json:"action"`
+	Issues   []string `json:"issues"`
+	State    string   `json:"state,omitempty"`
+	AssignTo string   `json:"assign_to,omitempty"`
+}
+
+// IssuesService manages issues
+type IssuesService struct {
+	client *Client
+}
+
+// List fetches one page of issues
+func (s *IssuesService) List(ctx context.Context, opts *IssueListOptions) (*Page[Issue], error) {
+	return list[Issue](ctx, s.client, "/issues", "data", opts)
+}
+
+// All iterates every issue matching opts, starting from opts.Page
+func (s *IssuesService) All(ctx context.Context, opts *IssueListOptions) iter.Seq2[Issue, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches an issue by key
+func (s *IssuesService) Get(ctx context.Context, key string, opts *IssueExpansions) (*Issue, error) {
+	return get[Issue](ctx, s.client, "/issues/"+escape(key), encodeQuery(opts))
+}
+
+// Create opens an issue
+func (s *IssuesService) Create(ctx context.Context, issue *Issue) (*Issue, error) {
+	return create[Issue](ctx, s.client, "/issues", issue)
+}
+
+// Update updates the issue with the given key
+func (s *IssuesService) Update(ctx context.Context, key string, issue *Issue) (*Issue, error) {
+	body, err := withKey(issue, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[Issue](ctx, s.client, "/issues/"+escape(key), body)
+}
+
+// Resolve marks an issue resolved. The API requires the full issue on update, so it is fetched first.
+func (s *IssuesService) Resolve(ctx context.Context, key string) (*Issue, error) {
+	issue, err := s.Get(ctx, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	issue.State = "resolved"
+	return update[Issue](ctx, s.client, "/issues/"+escape(key), issue)
+}
+
+// Delete deletes an issue
+func (s *IssuesService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/issues/"+escape(key))
+}
+
+// Bulk applies an action to several issues at once and returns the API's summary
+func (s *IssuesService) Bulk(ctx context.Context, req *IssueBulkRequest) (json.RawMessage, error) {
+	return s.client.Do(ctx, http.MethodPost, "/issues/bulk", nil, req, nil)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// MaintenanceWindow suppresses alerts for its monitors between Start and End
+type MaintenanceWindow struct {
+	resource
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	State       string   `json:"state"`
+	Duration    int      `json:"duration"`
+	Monitors    []string `json:"monitors"`
+	Groups      []string `json:"groups"`
+	Tags        []string `json:"tags"`
+	StatusPages []string `json:"statuspages"`
+}
+
+func (w *MaintenanceWindow) UnmarshalJSON(data []byte) error {
+	type plain MaintenanceWindow
+	return w.decode(data, (*plain)(w))
+}
+
+func (w MaintenanceWindow) MarshalJSON() ([]byte, error) {
+	type plain MaintenanceWindow
+	return w.encode(plain(w))
+}
+
+// MaintenanceWindowListOptions filters GET /maintenance_windows. By default only ongoing and
+// upcoming windows are listed.
+type MaintenanceWindowListOptions struct {
+	ListOptions
+	Past                    bool   `url:"past"`
+	Ongoing                 bool   `url:"ongoing"`
+	Upcoming                bool   `url:"upcoming"`
+	StatusPage              string `url:"statuspage"`
+	Env                     string `url:"env"`
+	WithAllAffectedMonitors bool   `url:"withAllAffectedMonitors"`
+}
+
+// MaintenanceWindowGetOptions expands GET /maintenance_windows/{key}
+type MaintenanceWindowGetOptions struct {
+	WithAllAffectedMonitors bool `url:"withAllAffectedMonitors"`
+}
+
+// MaintenanceWindowsService manages maintenance windows
+type MaintenanceWindowsService struct {
+	client *Client
+}
+
+// List fetches one page of maintenance windows
+func (s *MaintenanceWindowsService) List(ctx context.Context, opts *MaintenanceWindowListOptions) (*Page[MaintenanceWindow], error) {
+	return list[MaintenanceWindow](ctx, s.client, "/maintenance_windows", "data", opts)
+}
+
+// All iterates every maintenance window matching opts, starting from opts.Page
+func (s *MaintenanceWindowsService) All(ctx context.Context, opts *MaintenanceWindowListOptions) iter.Seq2[MaintenanceWindow, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches a maintenance window by key
+func (s *MaintenanceWindowsService) Get(ctx context.Context, key string, opts *MaintenanceWindowGetOptions) (*MaintenanceWindow, error) {
+	return get[MaintenanceWindow](ctx, s.client, "/maintenance_windows/"+escape(key), encodeQuery(opts))
+}
+
+// Create schedules a maintenance window
+func (s *MaintenanceWindowsService) Create(ctx context.Context, window *MaintenanceWindow) (*MaintenanceWindow, error) {
+	return create[MaintenanceWindow](ctx, s.client, "/maintenance_windows", window)
+}
+
+// Update updates the maintenance window with the given key
+func (s *MaintenanceWindowsService) Update(ctx context.Context, key string, window *MaintenanceWindow) (*MaintenanceWindow, error) {
+	body, err := withKey(window, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[MaintenanceWindow](ctx, s.client, "/maintenance_windows/"+escape(key), body)
+}
+
+// Delete deletes a maintenance window
+func (s *MaintenanceWindowsService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/maintenance_windows/"+escape(key))
+}
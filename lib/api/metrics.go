@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"net/url"
+)
+
+// Metric is one data point or aggregate: "stamp" plus a value per requested field. Values are
+// float64, or nil when the API reports no data.
+type Metric map[string]interface{}
+
+// Stamp returns the data point's Unix timestamp, or 0 for aggregates
+func (m Metric) Stamp() int64 {
+	stamp, _ := m["stamp"].(float64)
+	return int64(stamp)
+}
+
+// Value returns a field's value and whether the API reported one
+func (m Metric) Value(field string) (float64, bool) {
+	value, ok := m[field].(float64)
+	return value, ok
+}
+
+// Metrics are time series keyed by monitor, then environment
+type Metrics struct {
+	resource
+	Monitors map[string]map[string][]Metric `json:"monitors"`
+}
+
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	type plain Metrics
+	return m.decode(data, (*plain)(m))
+}
+
+// Aggregates summarize a time range, keyed by monitor, then environment
+type Aggregates struct {
+	resource
+	Monitors map[string]map[string]Metric `json:"monitors"`
+}
+
+func (a *Aggregates) UnmarshalJSON(data []byte) error {
+	type plain Aggregates
+	return a.decode(data, (*plain)(a))
+}
+
+// MetricOptions selects the monitors and time range to report on. At least one of Monitors,
+// Groups or Tags is required. Time is ignored when Start or End is set.
+type MetricOptions struct {
+	Monitors  []string `url:"monitor"`
+	Groups    []string `url:"group"`
+	Tags      []string `url:"tag"`
+	Types     []string `url:"type"`
+	Time      string   `url:"time"`
+	Start     int64    `url:"start"`
+	End       int64    `url:"end"`
+	Env       string   `url:"env"`
+	Regions   []string `url:"region"`
+	WithNulls bool     `url:"withNulls"`
+
+	// Fields are the series to return from Get, such as duration_p50 or success_rate
+	Fields []string `url:"field"`
+}
+
+// MetricsService queries monitor metrics
+type MetricsService struct {
+	client *Client
+}
+
+// Get fetches time series for opts.Fields
+func (s *MetricsService) Get(ctx context.Context, opts *MetricOptions) (*Metrics, error) {
+	return get[Metrics](ctx, s.client, "/metrics", metricQuery(opts, true))
+}
+
+// Aggregates fetches summary statistics such as success rate, mean duration and run counts
+func (s *MetricsService) Aggregates(ctx context.Context, opts *MetricOptions) (*Aggregates, error) {
+	return get[Aggregates](ctx, s.client, "/aggregates", metricQuery(opts, false))
+}
+
+func metricQuery(opts *MetricOptions, withFields bool) url.Values {
+	query := encodeQuery(opts)
+	if opts != nil && (opts.Start > 0 || opts.End > 0) {
+		query.Del("time")
+	}
+	if !withFields {
+		query.Del("field")
+	}
+	return query
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+// Monitor is a job, check, heartbeat or site monitor
+type Monitor struct {
+	resource
+	Key               string   `json:"key"`
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Schedule          string   `json:"schedule"`
+	Timezone          string   `json:"timezone"`
+	Group             string   `json:"group"`
+	Platform          string   `json:"platform"`
+	Note              string   `json:"note"`
+	Tags              []string `json:"tags"`
+	Environments      []string `json:"environments"`
+	Notify            []string `json:"notify"`
+	Assertions        []string `json:"assertions"`
+	GraceSeconds      int      `json:"grace_seconds"`
+	ScheduleTolerance int      `json:"schedule_tolerance"`
+	Passing           bool     `json:"passing"`
+	Paused            bool     `json:"paused"`
+	Disabled          bool     `json:"disabled"`
+	Created           string   `json:"created"`
+}
+
+func (m *Monitor) UnmarshalJSON(data []byte) error {
+	type plain Monitor
+	return m.decode(data, (*plain)(m))
+}
+
+func (m Monitor) MarshalJSON() ([]byte, error) {
+	type plain Monitor
+	return m.encode(plain(m))
+}
+
+// MonitorListOptions filters GET /monitors
+type MonitorListOptions struct {
+	ListOptions
+	Env             string   `url:"env"`
+	Group           string   `url:"group"`
+	Search          string   `url:"search"`
+	Sort            string   `url:"sort"`
+	Types           []string `url:"type"`
+	Tags            []string `url:"tag"`
+	States          []string `url:"state"`
+	WithEvents      bool     `url:"withEvents"`
+	WithInvocations bool     `url:"withInvocations"`
+}
+
+// MonitorGetOptions expands GET /monitors/{key}
+type MonitorGetOptions struct {
+	WithEvents      bool `url:"withEvents"`
+	WithInvocations bool `url:"withInvocations"`
+}
+
+// BulkDeleteResult reports which monitors a bulk delete removed
+type BulkDeleteResult struct {
+	DeletedCount   int `json:"deleted_count"`
+	RequestedCount int `json:"requested_count"`
+	Errors         struct {
+		Missing []string `json:"missing"`
+	} `json:"errors"`
+}
+
+// MonitorsService manages monitors
+type MonitorsService struct {
+	client *Client
+}
+
+// List fetches one page of monitors
+func (s *MonitorsService) List(ctx context.Context, opts *MonitorListOptions) (*Page[Monitor], error) {
+	return list[Monitor](ctx, s.client, "/monitors", "monitors", opts)
+}
+
+// All iterates every monitor matching opts, starting from opts.Page
+func (s *MonitorsService) All(ctx context.Context, opts *MonitorListOptions) iter.Seq2[Monitor, error] {
+	return all(ctx, opts, s.List)
+}
+
+// ListYAML fetches one page of monitors as a YAML config document
+func (s *MonitorsService) ListYAML(ctx context.Context, opts *MonitorListOptions) ([]byte, error) {
+	query := encodeQuery(opts)
+	query.Set("format", "yaml")
+	return s.client.Do(ctx, http.MethodGet, "/monitors", query, nil, nil)
+}
+
+// ExportYAML iterates every monitor matching opts as YAML config documents, one per page. The
+// page count comes from a JSON listing, since YAML responses do not report it.
+func (s *MonitorsService) ExportYAML(ctx context.Context, opts *MonitorListOptions) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		base := MonitorListOptions{}
+		if opts != nil {
+			base = *opts
+		}
+		withPage := func(page int) *MonitorListOptions {
+			current := base
+			current.Page = page
+			return &current
+		}
+
+		first, err := s.List(ctx, withPage(1))
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		paginator := &Paginator[[]byte]{
+			Concurrency: base.Concurrency,
+			Fetch: func(ctx context.Context, page int) (*Page[[]byte], error) {
+				config, err := s.ListYAML(ctx, withPage(page))
+				if err != nil {
+					return nil, err
+				}
+				result := &Page[[]byte]{Page: page, PageSize: first.PageSize, Total: first.Total}
+				if len(bytes.TrimSpace(config)) > 0 {
+					result.Items = [][]byte{config}
+				}
+				return result, nil
+			},
+		}
+		for config, err := range paginator.Items(ctx) {
+			if !yield(config, err) {
+				return
+			}
+		}
+	}
+}
+
+// Search fetches one page of monitors matching query, which supports scopes such as
+// "job:backup", "group:production" and "tag:critical"
+func (s *MonitorsService) Search(ctx context.Context, query string, opts *ListOptions) (*Page[Monitor], error) {
+	return list[Monitor](ctx, s.client, "/search", "monitors", searchQuery{query, opts})
+}
+
+// SearchYAML is Search returning a YAML config document
+func (s *MonitorsService) SearchYAML(ctx context.Context, query string, opts *ListOptions) ([]byte, error) {
+	params := encodeQuery(searchQuery{query, opts})
+	params.Set("format", "yaml")
+	return s.client.Do(ctx, http.MethodGet, "/search", params, nil, nil)
+}
+
+type searchQuery struct {
+	Query string `url:"query"`
+	*ListOptions
+}
+
+// Get fetches a monitor by key
+func (s *MonitorsService) Get(ctx context.Context, key string, opts *MonitorGetOptions) (*Monitor, error) {
+	return get[Monitor](ctx, s.client, "/monitors/"+escape(key), encodeQuery(opts))
+}
+
+// Create creates a monitor
+func (s *MonitorsService) Create(ctx context.Context, monitor *Monitor) (*Monitor, error) {
+	return create[Monitor](ctx, s.client, "/monitors", monitor)
+}
+
+// Upsert creates or updates monitors by key in a single request
+func (s *MonitorsService) Upsert(ctx context.Context, monitors []*Monitor) ([]Monitor, error) {
+	var out []Monitor
+	if _, err := s.client.Do(ctx, http.MethodPut, "/monitors", nil, monitors, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Update updates the monitor with the given key. Only the fields set on monitor are changed.
+func (s *MonitorsService) Update(ctx context.Context, key string, monitor *Monitor) (*Monitor, error) {
+	body, err := withKey(monitor, key)
+	if err != nil {
+		return nil, err
+	}
+	var updated []Monitor
+	if _, err := s.client.Do(ctx, http.MethodPut, "/monitors", nil, []interface{}{body}, &updated); err != nil {
+		return nil, err
+	}
+	if len(updated) == 0 {
+		return nil, fmt.Errorf("monitor %s was not returned by the API", key)
+	}
+	return &updated[0], nil
+}
+
+// ApplyYAML creates or updates the monitors described by a YAML config document
+func (s *MonitorsService) ApplyYAML(ctx context.Context, config []byte) ([]byte, error) {
+	return s.client.do(ctx, http.MethodPut, "/monitors", nil, config, "application/yaml", nil)
+}
+
+// Delete deletes a monitor
+func (s *MonitorsService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/monitors/"+escape(key))
+}
+
+// DeleteMany deletes several monitors in one request. Keys that do not exist are reported in the
+// result rather than failing the request.
+func (s *MonitorsService) DeleteMany(ctx context.Context, keys []string) (*BulkDeleteResult, error) {
+	result := &BulkDeleteResult{}
+	body := map[string][]string{"monitors": keys}
+	if _, err := s.client.Do(ctx, http.MethodDelete, "/monitors", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Clone copies a monitor, optionally giving the copy a new name
+func (s *MonitorsService) Clone(ctx context.Context, key, name string) (*Monitor, error) {
+	body := map[string]string{"key": key}
+	if name != "" {
+		body["name"] = name
+	}
+	return create[Monitor](ctx, s.client, "/monitors/clone", body)
+}
+
+// Pause stops alerts for a monitor for the given number of hours, or indefinitely when hours is 0
+func (s *MonitorsService) Pause(ctx context.Context, key string, hours int) error {
+	path := "/monitors/" + escape(key) + "/pause"
+	if hours > 0 {
+		path = fmt.Sprintf("%s/%d", path, hours)
+	}
+	_, err := s.client.Do(ctx, http.MethodGet, path, nil, nil, nil)
+	return err
+}
+
+// Unpause resumes alerts for a paused monitor
+func (s *MonitorsService) Unpause(ctx context.Context, key string) error {
+	_, err := s.client.Do(ctx, http.MethodGet, "/monitors/"+escape(key)+"/pause/0", nil, nil, nil)
+	return err
+}
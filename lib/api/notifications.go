@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// NotificationList is a reusable set of alert destinations that monitors notify
+type NotificationList struct {
+	resource
+	Key           string               `json:"key"`
+	Name          string               `json:"name"`
+	Notifications NotificationChannels `json:"notifications"`
+	Monitors      []string             `json:"monitors"`
+}
+
+// NotificationChannels are the destinations of a notification list
+type NotificationChannels struct {
+	Emails    []string `json:"emails,omitempty"`
+	Slack     []string `json:"slack,omitempty"`
+	Pagerduty []string `json:"pagerduty,omitempty"`
+	Phones    []string `json:"phones,omitempty"`
+	Webhooks  []string `json:"webhooks,omitempty"`
+}
+
+func (n *NotificationList) UnmarshalJSON(data []byte) error {
+	type plain NotificationList
+	return n.decode(data, (*plain)(n))
+}
+
+func (n NotificationList) MarshalJSON() ([]byte, error) {
+	type plain NotificationList
+	return n.encode(plain(n))
+}
+
+// NotificationsService manages notification lists
+type NotificationsService struct {
+	client *Client
+}
+
+// List fetches one page of notification lists
+func (s *NotificationsService) List(ctx context.Context, opts *ListOptions) (*Page[NotificationList], error) {
+	return list[NotificationList](ctx, s.client, "/notifications", "templates", opts)
+}
+
+// All iterates every notification list, starting from opts.Page
+func (s *NotificationsService) All(ctx context.Context, opts *ListOptions) iter.Seq2[NotificationList, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches a notification list by key
+func (s *NotificationsService) Get(ctx context.Context, key string) (*NotificationList, error) {
+	return get[NotificationList](ctx, s.client, "/notifications/"+escape(key), nil)
+}
+
+// Create creates a notification list
+func (s *NotificationsService) Create(ctx context.Context, notifications *NotificationList) (*NotificationList, error) {
+	return create[NotificationList](ctx, s.client, "/notifications", notifications)
+}
+
+// Update updates the notification list with the given key
+func (s *NotificationsService) Update(ctx context.Context, key string, notifications *NotificationList) (*NotificationList, error) {
+	body, err := withKey(notifications, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[NotificationList](ctx, s.client, "/notifications/"+escape(key), body)
+}
+
+// Delete deletes a notification list
+func (s *NotificationsService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/notifications/"+escape(key))
+}
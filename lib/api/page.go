@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ListOptions are the pagination options shared by every list endpoint. Resource specific
+// options embed it.
+type ListOptions struct {
+	// Page is the 1-based page to fetch; 0 means the first page
+	Page int `url:"page"`
+
+	// PageSize is the number of items per page; 0 uses the API's default
+	PageSize int `url:"pageSize"`
+
+	// Concurrency is the number of pages All fetches at once when the endpoint reports its
+	// total. 0 and 1 fetch one page at a time.
+	Concurrency int `url:"-"`
+}
+
+func (o *ListOptions) listOptions() *ListOptions {
+	return o
+}
+
+type pageable interface {
+	listOptions() *ListOptions
+}
+
+// Page is one page of a list endpoint
+type Page[T any] struct {
+	resource
+	Items    []T
+	Page     int
+	PageSize int
+
+	// Total is the number of items across all pages, or 0 when the endpoint does not report it
+	Total int
+}
+
+// HasNext reports whether another page follows this one, according to the pagination fields the
+// endpoint returned. It is false when the endpoint reported none; see Paginator.
+func (p *Page[T]) HasNext() bool {
+	if p.Total > 0 && p.PageSize > 0 {
+		return p.Page*p.PageSize < p.Total
+	}
+	return p.PageSize > 0 && len(p.Items) >= p.PageSize
+}
+
+// decodePage reads the items under itemsKey along with whichever pagination fields the endpoint
+// reports: page, page_size and total_count at the top level, or a page_info object.
+func decodePage[T any](data []byte, itemsKey string) (*Page[T], error) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	items, err := decodeItems[T](body[itemsKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", itemsKey, err)
+	}
+	page := &Page[T]{Items: items}
+	page.raw = data
+
+	var info struct {
+		Page       int `json:"page"`
+		PageSize   int `json:"page_size"`
+		TotalCount int `json:"total_count"`
+		PageInfo   struct {
+			Page              int `json:"page"`
+			PageSize          int `json:"pageSize"`
+			TotalMonitorCount int `json:"totalMonitorCount"`
+		} `json:"page_info"`
+	}
+	json.Unmarshal(data, &info)
+	page.Page, page.PageSize, page.Total = info.Page, info.PageSize, info.TotalCount
+	if info.PageInfo.Page > 0 {
+		page.Page, page.PageSize, page.Total = info.PageInfo.Page, info.PageInfo.PageSize, info.PageInfo.TotalMonitorCount
+	}
+	if page.Page == 0 {
+		page.Page = 1
+	}
+	return page, nil
+}
+
+func list[T any](ctx context.Context, c *Client, path, itemsKey string, opts interface{}) (*Page[T], error) {
+	data, err := c.Do(ctx, http.MethodGet, path, encodeQuery(opts), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodePage[T](data, itemsKey)
+}
+
+// all iterates every item from the page in opts onwards, fetching pages as the loop advances.
+// The caller's options are not modified.
+func all[T any, O any, P interface {
+	*O
+	pageable
+}](ctx context.Context, opts P, fetch func(context.Context, P) (*Page[T], error)) iter.Seq2[T, error] {
+	base := P(new(O))
+	if opts != nil {
+		*base = *opts
+	}
+	paginator := &Paginator[T]{
+		Start:       base.listOptions().Page,
+		Concurrency: base.listOptions().Concurrency,
+		Fetch: func(ctx context.Context, page int) (*Page[T], error) {
+			current := P(new(O))
+			*current = *base
+			current.listOptions().Page = page
+			return fetch(ctx, current)
+		},
+	}
+	return paginator.Items(ctx)
+}
+
+// encodeQuery converts an options struct to query parameters using its `url` tags. Zero values
+// are skipped, booleans are sent as "true" and string slices are joined with commas. Embedded
+// structs are flattened.
+func encodeQuery(opts interface{}) url.Values {
+	values := url.Values{}
+	if opts == nil {
+		return values
+	}
+	v := reflect.ValueOf(opts)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return values
+		}
+		v = v.Elem()
+	}
+	encodeStruct(v, values)
+	return values
+}
+
+func encodeStruct(v reflect.Value, values url.Values) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.Anonymous {
+			if value.Kind() == reflect.Pointer && !value.IsNil() {
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				encodeStruct(value, values)
+			}
+			continue
+		}
+		name := field.Tag.Get("url")
+		if name == "" || name == "-" || value.IsZero() {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.String:
+			values.Set(name, value.String())
+		case reflect.Bool:
+			values.Set(name, "true")
+		case reflect.Int, reflect.Int64:
+			values.Set(name, strconv.FormatInt(value.Int(), 10))
+		case reflect.Slice:
+			if items, ok := value.Interface().([]string); ok && len(items) > 0 {
+				values.Set(name, strings.Join(items, ","))
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+// pagedServer serves total items under itemsKey, pageSize at a time, reporting pagination the
+// way the groups endpoint does
+func pagedServer(t *testing.T, total, pageSize int, requests *[]string, mu *sync.Mutex) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*requests = append(*requests, r.URL.RawQuery)
+		mu.Unlock()
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+
+		items := ""
+		for i := (page-1)*pageSize + 1; i <= total && i <= page*pageSize; i++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"key":"group-%d"}`, i)
+		}
+		fmt.Fprintf(w, `{"groups":[%s],"page":%d,"page_size":%d,"total_count":%d}`, items, page, pageSize, total)
+	}))
+}
+
+func TestAll_FetchesEveryPage(t *testing.T) {
+	var requests []string
+	server := pagedServer(t, 5, 2, &requests, &sync.Mutex{})
+	defer server.Close()
+
+	opts := &api.GroupListOptions{Env: "production"}
+	var keys []string
+	for group, err := range newTestClient(server.URL).Groups.All(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		keys = append(keys, group.Key)
+	}
+
+	if len(keys) != 5 || keys[4] != "group-5" {
+		t.Errorf("expected 5 groups, got %v", keys)
+	}
+	if len(requests) != 3 || requests[2] != "env=production&page=3" {
+		t.Errorf("expected 3 page requests, got %v", requests)
+	}
+	if opts.Page != 0 {
+		t.Errorf("expected the caller's options to be left alone, got page %d", opts.Page)
+	}
+}
+
+func TestAll_StopsWhenLoopBreaks(t *testing.T) {
+	var requests []string
+	server := pagedServer(t, 10, 2, &requests, &sync.Mutex{})
+	defer server.Close()
+
+	for group := range newTestClient(server.URL).Groups.All(context.Background(), nil) {
+		if group.Key == "group-3" {
+			break
+		}
+	}
+	if len(requests) != 2 {
+		t.Errorf("expected iteration to stop after the second page, got %d requests", len(requests))
+	}
+}
+
+func TestAll_YieldsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	count := 0
+	for _, err := range newTestClient(server.URL).Environments.All(context.Background(), nil) {
+		count++
+		if !errors.Is(err, api.ErrUnauthorized) {
+			t.Errorf("expected ErrUnauthorized, got %v", err)
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected a single error, got %d results", count)
+	}
+}
+
+func TestAll_FetchesPagesConcurrentlyInOrder(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := pagedServer(t, 250, 1, &requests, &mu)
+	defer server.Close()
+
+	opts := &api.GroupListOptions{ListOptions: api.ListOptions{Concurrency: 8}}
+	count := 0
+	for group, err := range newTestClient(server.URL).Groups.All(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if expected := fmt.Sprintf("group-%d", count); group.Key != expected {
+			t.Fatalf("expected %s, got %s", expected, group.Key)
+		}
+	}
+	if count != 250 || len(requests) != 250 {
+		t.Errorf("expected 250 groups from 250 requests with no page limit, got %d from %d", count, len(requests))
+	}
+}
+
+func TestAll_UnpaginatedEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    []string
+		expected int
+		requests int
+	}{
+		{"reads until an empty page", []string{`[{"key":"a"},{"key":"b"}]`, `[{"key":"c"}]`, `[]`}, 3, 3},
+		{"stops when the page parameter is ignored", []string{`[{"key":"a"}]`, `[{"key":"a"}]`}, 1, 2},
+	}
+
+	for _, tt := range tests {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			page := tt.pages[len(tt.pages)-1]
+			if requests <= len(tt.pages) {
+				page = tt.pages[requests-1]
+			}
+			fmt.Fprintf(w, `{"environments":%s}`, page)
+		}))
+
+		count := 0
+		for _, err := range newTestClient(server.URL).Environments.All(context.Background(), nil) {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			count++
+		}
+		server.Close()
+
+		if count != tt.expected || requests != tt.requests {
+			t.Errorf("%s: expected %d environments from %d requests, got %d from %d", tt.name, tt.expected, tt.requests, count, requests)
+		}
+	}
+}
+
+func TestExportYAML_OneDocumentPerPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if r.URL.Query().Get("format") == "yaml" {
+			fmt.Fprintf(w, "jobs:\n  job-%s: {}\n", page)
+			return
+		}
+		fmt.Fprint(w, `{"monitors":[{"key":"job-1"}],"page_info":{"page":1,"pageSize":1,"totalMonitorCount":3}}`)
+	}))
+	defer server.Close()
+
+	var docs []string
+	for config, err := range newTestClient(server.URL).Monitors.ExportYAML(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		docs = append(docs, string(config))
+	}
+	if len(docs) != 3 || docs[2] != "jobs:\n  job-3: {}\n" {
+		t.Errorf("expected 3 YAML documents, got %q", docs)
+	}
+}
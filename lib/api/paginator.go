@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"iter"
+)
+
+// Paginator walks the pages of a list endpoint lazily, fetching the next page only when the
+// loop reaches it. There is no page limit.
+//
+// When a page reports its total, iteration ends on the last page and up to Concurrency of the
+// remaining pages are fetched at once; items are still yielded in order. Endpoints that report
+// nothing are read until a page comes back empty, or repeats the one before it because the
+// endpoint ignored the page parameter.
+type Paginator[T any] struct {
+	// Fetch returns the given 1-based page
+	Fetch func(ctx context.Context, page int) (*Page[T], error)
+
+	// Start is the first page to fetch; 0 means the first page
+	Start int
+
+	// Concurrency bounds the pages fetched at once; 0 and 1 fetch one page at a time
+	Concurrency int
+}
+
+// Pages iterates the pages themselves. Iteration stops after the first error.
+func (p *Paginator[T]) Pages(ctx context.Context) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		number := p.Start
+		if number < 1 {
+			number = 1
+		}
+		var previous *Page[T]
+		for {
+			if previous != nil && p.Concurrency > 1 && previous.Total > 0 && previous.PageSize > 0 {
+				last := (previous.Total + previous.PageSize - 1) / previous.PageSize
+				if number <= last {
+					var ok bool
+					if previous, ok = p.fetchConcurrently(ctx, number, last, yield); !ok {
+						return
+					}
+					number = last + 1
+					if !previous.HasNext() {
+						return
+					}
+					continue
+				}
+			}
+
+			page, err := p.Fetch(ctx, number)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if previous != nil && !paginated(page) && bytes.Equal(previous.Raw(), page.Raw()) {
+				// The endpoint ignored the page parameter
+				return
+			}
+			if !yield(page, nil) || !hasNext(page) {
+				return
+			}
+			previous = page
+			number++
+		}
+	}
+}
+
+// Items iterates the items of every page. Iteration stops after the first error.
+func (p *Paginator[T]) Items(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range p.Pages(ctx) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// fetchConcurrently fetches pages first through last with at most Concurrency requests in flight,
+// yielding them in order. It returns the last page yielded and whether iteration should go on.
+func (p *Paginator[T]) fetchConcurrently(ctx context.Context, first, last int, yield func(*Page[T], error) bool) (*Page[T], bool) {
+	type result struct {
+		page *Page[T]
+		err  error
+	}
+
+	var pending []chan result
+	next := first
+	start := func() {
+		done := make(chan result, 1)
+		pending = append(pending, done)
+		go func(number int) {
+			page, err := p.Fetch(ctx, number)
+			done <- result{page, err}
+		}(next)
+		next++
+	}
+	for next <= last && len(pending) < p.Concurrency {
+		start()
+	}
+
+	var page *Page[T]
+	for len(pending) > 0 {
+		r := <-pending[0]
+		pending = pending[1:]
+		if r.err != nil {
+			yield(nil, r.err)
+			return nil, false
+		}
+		if !yield(r.page, nil) {
+			return nil, false
+		}
+		page = r.page
+		if next <= last {
+			start()
+		}
+	}
+	return page, true
+}
+
+// hasNext decides whether to fetch the page after page. Pages that report their pagination are
+// trusted; otherwise iteration goes on until a page comes back empty.
+func hasNext[T any](page *Page[T]) bool {
+	if len(page.Items) == 0 {
+		return false
+	}
+	return !paginated(page) || page.HasNext()
+}
+
+func paginated[T any](page *Page[T]) bool {
+	return page.PageSize > 0 || page.Total > 0
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// resource is embedded in every model. It keeps the JSON the model was decoded from so that
+// encoding it again preserves fields the model does not declare.
+type resource struct {
+	raw json.RawMessage
+}
+
+// Raw returns the JSON the model was decoded from, or nil for a model built in code
+func (r resource) Raw() json.RawMessage {
+	return r.raw
+}
+
+func (r *resource) decode(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	r.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// encode marshals v, a model's fields, on top of the JSON the model was decoded from. Zero values
+// are left out unless the decoded JSON had the key, so that building a model in code only sends
+// the fields that were set while clearing a decoded field still reaches the API.
+func (r *resource) encode(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := unmarshalUseNumber(data, &fields); err != nil {
+		return nil, err
+	}
+
+	merged := map[string]interface{}{}
+	if len(r.raw) > 0 {
+		if err := unmarshalUseNumber(r.raw, &merged); err != nil {
+			// Not an object; the declared fields are all there is
+			merged = map[string]interface{}{}
+		}
+	}
+	for key, value := range fields {
+		if _, decoded := merged[key]; decoded || !isZero(value) {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// decodeItems decodes a JSON array, keeping each element's JSON on models that embed resource
+func decodeItems[T any](data json.RawMessage) ([]T, error) {
+	if len(bytes.TrimSpace(data)) == 0 || string(bytes.TrimSpace(data)) == "null" {
+		return []T{}, nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,45 @@
+package api_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+func TestModels_PreserveUnknownFields(t *testing.T) {
+	source := `{"key":"abc123","name":"Nightly Backup","schedule":"0 2 * * *","paused":true,"request":{"url":"https://example.com"},"grace_seconds":9007199254740993}`
+
+	var monitor api.Monitor
+	if err := json.Unmarshal([]byte(source), &monitor); err != nil {
+		t.Fatal(err)
+	}
+	if monitor.Key != "abc123" || !monitor.Paused || string(monitor.Raw()) != source {
+		t.Fatalf("unexpected monitor %+v", monitor)
+	}
+
+	monitor.Name = "Renamed"
+	monitor.Paused = false
+	data, err := json.Marshal(monitor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := string(data)
+	for _, want := range []string{`"name":"Renamed"`, `"paused":false`, `"request":{"url":"https://example.com"}`, `"grace_seconds":9007199254740993`} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("expected %s in %s", want, encoded)
+		}
+	}
+}
+
+func TestModels_OmitUnsetFields(t *testing.T) {
+	data, err := json.Marshal(&api.Monitor{Key: "abc123", Type: "job", Tags: []string{"critical"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"key":"abc123","tags":["critical"],"type":"job"}` {
+		t.Errorf("expected only the set fields, got %s", data)
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryTransport retries requests that were rate limited or hit a server error, waiting with
+// exponential backoff and full jitter between attempts. A Retry-After header on the response
+// replaces the computed wait; when it asks for longer than MaxBackoff, or longer than the
+// request's deadline allows, the response is returned instead so the caller can decide. Waits
+// end early when the request's context is done.
+//
+// 429 responses are retried for every method because the API did not process the request.
+// Server errors and network failures are only retried for idempotent methods, so a POST that
+// may have been applied is never sent twice.
+type RetryTransport struct {
+	// Base performs the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+
+	// MaxRetries is the number of attempts after the first
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff bound the wait before each retry
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Logger, when set, receives a line before every retry
+	Logger func(string)
+}
+
+// NewRetryTransport wraps base with the default retry policy: three retries, backing off from
+// half a second up to 10 seconds
+func NewRetryTransport(base http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		Base:       base,
+		MaxRetries: 3,
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 10 * time.Second,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := base.RoundTrip(req)
+		if attempt >= t.MaxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if t.MaxBackoff > 0 && after > t.MaxBackoff {
+					return resp, err
+				}
+				wait = after
+			}
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+
+		if resp != nil {
+			t.log("Retrying %s %s after %s in %s", req.Method, req.URL.Redacted(), resp.Status, wait)
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		} else {
+			t.log("Retrying %s %s after %s in %s", req.Method, req.URL.Redacted(), err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *RetryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(req.Method)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented && idempotent(req.Method)
+}
+
+// backoff returns a random wait between zero and MinBackoff doubled once per previous attempt,
+// capped at MaxBackoff
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	ceiling := t.MinBackoff << attempt
+	if ceiling <= 0 || (t.MaxBackoff > 0 && ceiling > t.MaxBackoff) {
+		ceiling = t.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)) + 1)
+}
+
+func (t *RetryTransport) log(format string, args ...interface{}) {
+	if t.Logger != nil {
+		t.Logger(fmt.Sprintf(format, args...))
+	}
+}
+
+// retryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
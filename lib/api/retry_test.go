@@ -0,0 +1,132 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+)
+
+// flakyServer answers with the given statuses in order, then 200, recording each request body
+func flakyServer(t *testing.T, headers map[string]string, statuses ...int) (*httptest.Server, *int32, *[]string) {
+	t.Helper()
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		n := atomic.AddInt32(&calls, 1)
+		if int(n) <= len(statuses) {
+			for k, v := range headers {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Write([]byte(`{"key":"abc123"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, &bodies
+}
+
+func fastRetries() *api.RetryTransport {
+	transport := api.NewRetryTransport(nil)
+	transport.MinBackoff = time.Millisecond
+	transport.MaxBackoff = 5 * time.Millisecond
+	return transport
+}
+
+func TestRetryTransport_RetriesServerErrors(t *testing.T) {
+	server, calls, _ := flakyServer(t, nil, 502, 503)
+	client := &http.Client{Transport: fastRetries()}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 || *calls != 3 {
+		t.Errorf("expected 200 after 3 calls, got %d after %d", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	server, calls, _ := flakyServer(t, nil, 500, 500, 500, 500, 500)
+	client := &http.Client{Transport: fastRetries()}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 500 || *calls != 4 {
+		t.Errorf("expected the 500 after 4 calls, got %d after %d", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransport_PostOnlyRetriedWhenRateLimited(t *testing.T) {
+	server, calls, _ := flakyServer(t, nil, 500)
+	client := &http.Client{Transport: fastRetries()}
+
+	resp, _ := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	resp.Body.Close()
+	if resp.StatusCode != 500 || *calls != 1 {
+		t.Errorf("expected a failed POST not to be retried, got %d after %d calls", resp.StatusCode, *calls)
+	}
+
+	server, calls, bodies := flakyServer(t, map[string]string{"Retry-After": "0"}, 429)
+	resp, _ = client.Post(server.URL, "application/json", strings.NewReader(`{"name":"backup"}`))
+	resp.Body.Close()
+	if resp.StatusCode != 200 || *calls != 2 {
+		t.Errorf("expected a rate limited POST to be retried, got %d after %d calls", resp.StatusCode, *calls)
+	}
+	for i, body := range *bodies {
+		if body != `{"name":"backup"}` {
+			t.Errorf("attempt %d sent body %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransport_LongRetryAfterReturnsResponse(t *testing.T) {
+	server, calls, _ := flakyServer(t, map[string]string{"Retry-After": "3600"}, 429)
+	client := &http.Client{Transport: fastRetries()}
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 429 || *calls != 1 {
+		t.Errorf("expected the 429 without retrying, got %d after %d calls", resp.StatusCode, *calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected no wait, took %s", time.Since(start))
+	}
+}
+
+func TestRetryTransport_StopsWaitingWhenContextDone(t *testing.T) {
+	server, _, _ := flakyServer(t, nil, 503, 503, 503, 503)
+	transport := api.NewRetryTransport(nil)
+	transport.MinBackoff = time.Minute
+	transport.MaxBackoff = time.Minute
+	client := &http.Client{Transport: transport}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	start := time.Now()
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error once the context was cancelled")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the wait to end with the context, took %s", time.Since(start))
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+)
+
+// Site is a Real User Monitoring site collecting web vitals and JavaScript errors
+type Site struct {
+	resource
+	Key              string `json:"key"`
+	Name             string `json:"name"`
+	ClientKey        string `json:"client_key"`
+	WebVitalsEnabled bool   `json:"webvitals_enabled"`
+	ErrorsEnabled    bool   `json:"errors_enabled"`
+	Sampling         int    `json:"sampling"`
+	Snippet          string `json:"snippet"`
+}
+
+func (s *Site) UnmarshalJSON(data []byte) error {
+	type plain Site
+	return s.decode(data, (*plain)(s))
+}
+
+func (s Site) MarshalJSON() ([]byte, error) {
+	type plain Site
+	return s.encode(plain(s))
+}
+
+// SiteError is a JavaScript error collected from a site
+type SiteError struct {
+	resource
+	Key       string `json:"key"`
+	Site      string `json:"site"`
+	Message   string `json:"message"`
+	ErrorType string `json:"error_type"`
+	Filename  string `json:"filename"`
+	Count     int    `json:"count"`
+}
+
+func (e *SiteError) UnmarshalJSON(data []byte) error {
+	type plain SiteError
+	return e.decode(data, (*plain)(e))
+}
+
+func (e SiteError) MarshalJSON() ([]byte, error) {
+	type plain SiteError
+	return e.encode(plain(e))
+}
+
+// SiteGetOptions expands GET /sites/{key}
+type SiteGetOptions struct {
+	WithSnippet bool `url:"withSnippet"`
+}
+
+// SiteErrorListOptions filters GET /site_errors
+type SiteErrorListOptions struct {
+	ListOptions
+	Site string `url:"site"`
+}
+
+// SiteQuery is an analytics query against POST /sites/query
+type SiteQuery struct {
+	Site       string            `json:"site"`
+	Type       string            `json:"type"`
+	Time       string            `json:"time,omitempty"`
+	Start      string            `json:"start,omitempty"`
+	End        string            `json:"end,omitempty"`
+	Timezone   string            `json:"timezone,omitempty"`
+	Metrics    []string          `json:"metrics,omitempty"`
+	Dimensions []string          `json:"dimensions,omitempty"`
+	TimeBucket string            `json:"time_bucket,omitempty"`
+	Filters    []SiteQueryFilter `json:"filters,omitempty"`
+	OrderBy    []string          `json:"order_by,omitempty"`
+	Compare    string            `json:"compare,omitempty"`
+	Page       int               `json:"page,omitempty"`
+	PageSize   int               `json:"page_size,omitempty"`
+}
+
+// SiteQueryFilter restricts a site query to rows where Dimension compares to Value with Operator
+type SiteQueryFilter struct {
+	Dimension string `json:"dimension"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+}
+
+// SitesService manages RUM sites and their errors
+type SitesService struct {
+	client *Client
+}
+
+// List fetches one page of sites
+func (s *SitesService) List(ctx context.Context, opts *ListOptions) (*Page[Site], error) {
+	return list[Site](ctx, s.client, "/sites", "data", opts)
+}
+
+// All iterates every site, starting from opts.Page
+func (s *SitesService) All(ctx context.Context, opts *ListOptions) iter.Seq2[Site, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches a site by key
+func (s *SitesService) Get(ctx context.Context, key string, opts *SiteGetOptions) (*Site, error) {
+	return get[Site](ctx, s.client, "/sites/"+escape(key), encodeQuery(opts))
+}
+
+// Create creates a site
+func (s *SitesService) Create(ctx context.Context, site *Site) (*Site, error) {
+	return create[Site](ctx, s.client, "/sites", site)
+}
+
+// Update updates the site with the given key
+func (s *SitesService) Update(ctx context.Context, key string, site *Site) (*Site, error) {
+	body, err := withKey(site, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[Site](ctx, s.client, "/sites/"+escape(key), body)
+}
+
+// Delete deletes a site
+func (s *SitesService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/sites/"+escape(key))
+}
+
+// Query runs an analytics query. The shape of the result depends on the query type.
+func (s *SitesService) Query(ctx context.Context, query *SiteQuery) (json.RawMessage, error) {
+	return s.client.Do(ctx, http.MethodPost, "/sites/query", nil, query, nil)
+}
+
+// ListErrors fetches one page of JavaScript errors
+func (s *SitesService) ListErrors(ctx context.Context, opts *SiteErrorListOptions) (*Page[SiteError], error) {
+	return list[SiteError](ctx, s.client, "/site_errors", "data", opts)
+}
+
+// AllErrors iterates every JavaScript error matching opts, starting from opts.Page
+func (s *SitesService) AllErrors(ctx context.Context, opts *SiteErrorListOptions) iter.Seq2[SiteError, error] {
+	return all(ctx, opts, s.ListErrors)
+}
+
+// GetError fetches a JavaScript error by key
+func (s *SitesService) GetError(ctx context.Context, key string) (*SiteError, error) {
+	return get[SiteError](ctx, s.client, "/site_errors/"+escape(key), nil)
+}
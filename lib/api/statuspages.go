@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// StatusPage publishes the health of monitors and groups to users
+type StatusPage struct {
+	resource
+	Key             string      `json:"key"`
+	Name            string      `json:"name"`
+	HostedSubdomain string      `json:"hosted_subdomain"`
+	Status          string      `json:"status"`
+	Components      []Component `json:"components"`
+}
+
+func (p *StatusPage) UnmarshalJSON(data []byte) error {
+	type plain StatusPage
+	return p.decode(data, (*plain)(p))
+}
+
+func (p StatusPage) MarshalJSON() ([]byte, error) {
+	type plain StatusPage
+	return p.encode(plain(p))
+}
+
+// Component is one monitor or group displayed on a status page
+type Component struct {
+	resource
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	StatusPage  string `json:"statuspage"`
+	Monitor     string `json:"monitor"`
+	Group       string `json:"group"`
+	Autopublish bool   `json:"autopublish"`
+}
+
+func (c *Component) UnmarshalJSON(data []byte) error {
+	type plain Component
+	return c.decode(data, (*plain)(c))
+}
+
+func (c Component) MarshalJSON() ([]byte, error) {
+	type plain Component
+	return c.encode(plain(c))
+}
+
+// StatusPageOptions expands status page responses
+type StatusPageOptions struct {
+	WithStatus     bool `url:"withStatus"`
+	WithComponents bool `url:"withComponents"`
+}
+
+// StatusPageListOptions filters GET /statuspages
+type StatusPageListOptions struct {
+	ListOptions
+	StatusPageOptions
+}
+
+// ComponentListOptions filters GET /statuspage_components
+type ComponentListOptions struct {
+	ListOptions
+	StatusPage string `url:"statuspage"`
+	WithStatus bool   `url:"withStatus"`
+}
+
+// StatusPagesService manages status pages
+type StatusPagesService struct {
+	client *Client
+}
+
+// List fetches one page of status pages
+func (s *StatusPagesService) List(ctx context.Context, opts *StatusPageListOptions) (*Page[StatusPage], error) {
+	return list[StatusPage](ctx, s.client, "/statuspages", "data", opts)
+}
+
+// All iterates every status page, starting from opts.Page
+func (s *StatusPagesService) All(ctx context.Context, opts *StatusPageListOptions) iter.Seq2[StatusPage, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Get fetches a status page by key
+func (s *StatusPagesService) Get(ctx context.Context, key string, opts *StatusPageOptions) (*StatusPage, error) {
+	return get[StatusPage](ctx, s.client, "/statuspages/"+escape(key), encodeQuery(opts))
+}
+
+// Create creates a status page
+func (s *StatusPagesService) Create(ctx context.Context, page *StatusPage) (*StatusPage, error) {
+	return create[StatusPage](ctx, s.client, "/statuspages", page)
+}
+
+// Update updates the status page with the given key
+func (s *StatusPagesService) Update(ctx context.Context, key string, page *StatusPage) (*StatusPage, error) {
+	body, err := withKey(page, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[StatusPage](ctx, s.client, "/statuspages/"+escape(key), body)
+}
+
+// Delete deletes a status page
+func (s *StatusPagesService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/statuspages/"+escape(key))
+}
+
+// ComponentsService manages status page components
+type ComponentsService struct {
+	client *Client
+}
+
+// List fetches one page of components
+func (s *ComponentsService) List(ctx context.Context, opts *ComponentListOptions) (*Page[Component], error) {
+	return list[Component](ctx, s.client, "/statuspage_components", "data", opts)
+}
+
+// All iterates every component matching opts, starting from opts.Page
+func (s *ComponentsService) All(ctx context.Context, opts *ComponentListOptions) iter.Seq2[Component, error] {
+	return all(ctx, opts, s.List)
+}
+
+// Create adds a component to a status page
+func (s *ComponentsService) Create(ctx context.Context, component *Component) (*Component, error) {
+	return create[Component](ctx, s.client, "/statuspage_components", component)
+}
+
+// Update updates the component with the given key
+func (s *ComponentsService) Update(ctx context.Context, key string, component *Component) (*Component, error) {
+	body, err := withKey(component, key)
+	if err != nil {
+		return nil, err
+	}
+	return update[Component](ctx, s.client, "/statuspage_components/"+escape(key), body)
+}
+
+// Delete removes a component from its status page
+func (s *ComponentsService) Delete(ctx context.Context, key string) error {
+	return remove(ctx, s.client, "/statuspage_components/"+escape(key))
+}
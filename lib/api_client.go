@@ -2,14 +2,15 @@ package lib
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
+	"github.com/cronitorio/cronitor-cli/lib/api"
 	"github.com/spf13/viper"
 )
 
@@ -17,6 +18,12 @@ import (
 // When non-empty, NewAPIClient uses this instead of the default base URL.
 var BaseURLOverride string
 
+// ServerURL is the CRONITOR_URL setting: the address of a stand-in for Cronitor, such as
+// `cronitor devserver`, that pings, API requests and logs are sent to instead. It is empty unless set.
+func ServerURL() string {
+	return strings.TrimRight(viper.GetString("CRONITOR_URL"), "/")
+}
+
 // APIClient provides a generic interface for Cronitor API operations
 type APIClient struct {
 	BaseURL   string
@@ -24,6 +31,9 @@ type APIClient struct {
 	UserAgent string
 	IsDev     bool
 	Logger    func(string)
+
+	// HTTPClient sends the requests. Defaults to a retrying client on the shared transport.
+	HTTPClient *http.Client
 }
 
 // APIResponse wraps the raw response with metadata
@@ -46,21 +56,29 @@ func NewAPIClient(isDev bool, logger func(string)) *APIClient {
 	baseURL := "https://cronitor.io/api"
 	if BaseURLOverride != "" {
 		baseURL = BaseURLOverride
+	} else if server := ServerURL(); server != "" {
+		baseURL = server + "/api"
 	} else if isDev {
 		baseURL = "http://dev.cronitor.io/api"
 	}
 
 	return &APIClient{
-		BaseURL:   baseURL,
-		ApiKey:    viper.GetString("CRONITOR_API_KEY"),
-		UserAgent: "CronitorCLI",
-		IsDev:     isDev,
-		Logger:    logger,
+		BaseURL:    baseURL,
+		ApiKey:     viper.GetString("CRONITOR_API_KEY"),
+		UserAgent:  "CronitorCLI",
+		IsDev:      isDev,
+		Logger:     logger,
+		HTTPClient: NewHTTPClient(DefaultHTTPTimeout, logger),
 	}
 }
 
 // Request makes a generic API request
 func (c *APIClient) Request(method, endpoint string, body []byte, queryParams map[string]string) (*APIResponse, error) {
+	return c.RequestContext(context.Background(), method, endpoint, body, queryParams)
+}
+
+// RequestContext makes a generic API request that is abandoned when ctx is done
+func (c *APIClient) RequestContext(ctx context.Context, method, endpoint string, body []byte, queryParams map[string]string) (*APIResponse, error) {
 	// Build URL with query parameters
 	reqURL := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
 	if len(queryParams) > 0 {
@@ -83,7 +101,7 @@ func (c *APIClient) Request(method, endpoint string, body []byte, queryParams ma
 		c.log(fmt.Sprintf("Request Body: %s", string(body)))
 	}
 
-	req, err := http.NewRequest(method, reqURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -102,8 +120,9 @@ func (c *APIClient) Request(method, endpoint string, body []byte, queryParams ma
 		req.Header.Set("Cronitor-Version", apiVersion)
 	}
 
-	client := &http.Client{
-		Timeout: 120 * time.Second,
+	client := c.HTTPClient
+	if client == nil {
+		client = NewHTTPClient(DefaultHTTPTimeout, c.Logger)
 	}
 
 	resp, err := client.Do(req)
@@ -207,3 +226,16 @@ func (r *APIResponse) ParseError() string {
 	// Fall back to raw body
 	return string(r.Body)
 }
+
+// NewCronitorClient creates a typed API client configured the same way as NewAPIClient
+func NewCronitorClient(isDev bool, logger func(string)) *api.Client {
+	legacy := NewAPIClient(isDev, logger)
+
+	client := api.NewClient(legacy.ApiKey)
+	client.BaseURL = legacy.BaseURL
+	client.APIVersion = viper.GetString("CRONITOR_API_VERSION")
+	client.UserAgent = legacy.UserAgent
+	client.HTTPClient = legacy.HTTPClient
+	client.Logger = logger
+	return client
+}
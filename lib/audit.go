@@ -0,0 +1,134 @@
+package lib
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditClientHeader tells the dashboard which client made a request, e.g. "mcp"
+const AuditClientHeader = "X-Cronitor-Client"
+
+// AuditEntry is one mutating action taken through the dashboard or MCP server
+type AuditEntry struct {
+	Time     time.Time       `json:"time"`
+	User     string          `json:"user,omitempty"`
+	ClientIP string          `json:"client_ip,omitempty"`
+	Source   string          `json:"source"`
+	Action   string          `json:"action"`
+	Crontab  string          `json:"crontab,omitempty"`
+	Line     int             `json:"line,omitempty"`
+	Key      string          `json:"key,omitempty"`
+	Status   int             `json:"status"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Before   string          `json:"before,omitempty"`
+	After    string          `json:"after,omitempty"`
+}
+
+// Succeeded reports whether the action was carried out
+func (e AuditEntry) Succeeded() bool {
+	return e.Status > 0 && e.Status < 400
+}
+
+// AuditFilter selects entries returned by AuditLog.Read
+type AuditFilter struct {
+	Since   time.Time
+	User    string
+	Action  string
+	Crontab string
+	Limit   int
+}
+
+func (f AuditFilter) matches(entry AuditEntry) bool {
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if f.User != "" && entry.User != f.User {
+		return false
+	}
+	if f.Action != "" && entry.Action != f.Action && !strings.HasPrefix(entry.Action, f.Action+".") {
+		return false
+	}
+	if f.Crontab != "" && entry.Crontab != f.Crontab {
+		return false
+	}
+	return true
+}
+
+// AuditLog is an append-only JSON lines file, optionally copied to syslog
+type AuditLog struct {
+	Path   string
+	Syslog bool
+	mu     sync.Mutex
+}
+
+// Append writes an entry to the end of the log. The file is only ever opened for appending.
+func (a *AuditLog) Append(entry AuditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(a.Path), 0755); err != nil {
+		return fmt.Errorf("cannot create audit log directory: %v", err)
+	}
+
+	file, err := os.OpenFile(a.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot open audit log %s: %v", a.Path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if a.Syslog {
+		return writeAuditSyslog(entry, data)
+	}
+	return nil
+}
+
+// Read returns matching entries, oldest first. With a limit, only the newest entries are returned.
+func (a *AuditLog) Read(filter AuditFilter) ([]AuditEntry, error) {
+	entries := []AuditEntry{}
+	file, err := os.Open(a.Path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Entries hold before and after copies of a crontab, so allow for long lines
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries, nil
+}
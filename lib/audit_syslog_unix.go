@@ -0,0 +1,26 @@
+//go:build !windows
+// +build !windows
+
+package lib
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// writeAuditSyslog copies an audit entry to the local syslog, at warning level when the action failed
+func writeAuditSyslog(entry AuditEntry, data []byte) error {
+	priority := syslog.LOG_AUTHPRIV | syslog.LOG_INFO
+	if !entry.Succeeded() {
+		priority = syslog.LOG_AUTHPRIV | syslog.LOG_WARNING
+	}
+
+	writer, err := syslog.New(priority, "cronitor")
+	if err != nil {
+		return fmt.Errorf("cannot write audit entry to syslog: %v", err)
+	}
+	defer writer.Close()
+
+	_, err = writer.Write(data)
+	return err
+}
@@ -0,0 +1,10 @@
+//go:build windows
+// +build windows
+
+package lib
+
+import "errors"
+
+func writeAuditSyslog(entry AuditEntry, data []byte) error {
+	return errors.New("syslog is not available on Windows")
+}
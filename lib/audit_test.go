@@ -0,0 +1,56 @@
+package lib
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditLogAppendAndRead(t *testing.T) {
+	log := &AuditLog{Path: filepath.Join(t.TempDir(), "audit", "audit.log")}
+	start := time.Now().Add(-time.Hour)
+
+	entries := []AuditEntry{
+		{Time: start, User: "alice", Source: "dashboard", Action: "job.update", Crontab: "/etc/crontab", Status: 200},
+		{Time: start.Add(time.Minute), User: "bob", Source: "mcp", Action: "job.run", Crontab: "/etc/crontab", Status: 403},
+		{Time: start.Add(2 * time.Minute), User: "alice", Source: "dashboard", Action: "crontab.update", Crontab: "user:deploy", Status: 200},
+		{Time: start.Add(3 * time.Minute), User: "alice", Source: "dashboard", Action: "settings.update", Status: 200},
+	}
+	for _, entry := range entries {
+		if err := log.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := log.Read(AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 || all[0].Action != "job.update" || all[3].Action != "settings.update" {
+		t.Errorf("expected all entries oldest first, got %+v", all)
+	}
+	if all[1].Succeeded() || !all[0].Succeeded() {
+		t.Errorf("expected only the denied request to be unsuccessful")
+	}
+
+	if jobs, _ := log.Read(AuditFilter{Action: "job"}); len(jobs) != 2 {
+		t.Errorf("expected the job action group to match job.update and job.run, got %+v", jobs)
+	}
+	if alice, _ := log.Read(AuditFilter{User: "alice", Crontab: "/etc/crontab"}); len(alice) != 1 {
+		t.Errorf("expected one entry by alice in /etc/crontab, got %+v", alice)
+	}
+	if recent, _ := log.Read(AuditFilter{Since: start.Add(90 * time.Second)}); len(recent) != 2 {
+		t.Errorf("expected two entries since the cutoff, got %+v", recent)
+	}
+	if newest, _ := log.Read(AuditFilter{Limit: 1}); len(newest) != 1 || newest[0].Action != "settings.update" {
+		t.Errorf("expected the limit to keep the newest entry, got %+v", newest)
+	}
+}
+
+func TestAuditLogReadMissingFile(t *testing.T) {
+	log := &AuditLog{Path: filepath.Join(t.TempDir(), "missing.log")}
+	entries, err := log.Read(AuditFilter{})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected no entries and no error, got %v, %v", entries, err)
+	}
+}
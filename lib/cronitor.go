@@ -10,7 +10,6 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/getsentry/raven-go"
 	"github.com/pkg/errors"
@@ -293,7 +292,9 @@ func (api CronitorApi) GetRawResponse(url string) ([]byte, error) {
 }
 
 func (api CronitorApi) Url() string {
-	if api.IsDev {
+	if server := ServerURL(); server != "" {
+		return server + "/api/monitors"
+	} else if api.IsDev {
 		return "http://dev.cronitor.io/api/monitors"
 	} else {
 		return "https://cronitor.io/api/monitors"
@@ -301,49 +302,14 @@ func (api CronitorApi) Url() string {
 }
 
 func (api CronitorApi) send(method string, url string, body string) ([]byte, error, int) {
-	client := &http.Client{
-		Timeout: 120 * time.Second,
-	}
-	request, err := http.NewRequest(method, url, strings.NewReader(body))
-
-	// Always fetch the latest API key from viper to pick up settings changes
-	currentApiKey := viper.GetString("CRONITOR_API_KEY")
-	if currentApiKey == "" {
-		// Fallback to the API key stored in the struct if viper doesn't have it
-		currentApiKey = api.ApiKey
-	}
-	request.SetBasicAuth(currentApiKey, "")
-
+	contentType := "application/json"
 	if strings.HasSuffix(url, "/signup") || strings.HasSuffix(url, "/sign-up") {
-		request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		request.Header.Add("Content-Type", "application/json")
+		contentType = "application/x-www-form-urlencoded"
 	}
-
-	request.Header.Add("User-Agent", api.UserAgent)
-	if apiVersion := viper.GetString("CRONITOR_API_VERSION"); apiVersion != "" {
-		request.Header.Add("Cronitor-Version", apiVersion)
-	}
-	request.ContentLength = int64(len(body))
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, err, 0
-	}
-
-	defer response.Body.Close()
-	contents, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		raven.CaptureErrorAndWait(err, nil)
-		return nil, err, 0
-	}
-
-	return contents, nil, response.StatusCode
+	return api.sendWithContentType(method, url, body, contentType)
 }
 
 func (api CronitorApi) sendWithContentType(method string, url string, body string, contentType string) ([]byte, error, int) {
-	client := &http.Client{
-		Timeout: 120 * time.Second,
-	}
 	request, err := http.NewRequest(method, url, strings.NewReader(body))
 	if err != nil {
 		return nil, err, 0
@@ -362,8 +328,7 @@ func (api CronitorApi) sendWithContentType(method string, url string, body strin
 	if apiVersion := viper.GetString("CRONITOR_API_VERSION"); apiVersion != "" {
 		request.Header.Add("Cronitor-Version", apiVersion)
 	}
-	request.ContentLength = int64(len(body))
-	response, err := client.Do(request)
+	response, err := NewHTTPClient(DefaultHTTPTimeout, api.Logger).Do(request)
 	if err != nil {
 		return nil, err, 0
 	}
@@ -398,6 +363,9 @@ func gzipLogData(logData string) *bytes.Buffer {
 
 func getPresignedUrl(apiKey string, postBody []byte) ([]byte, error) {
 	url := "https://cronitor.io/api/logs/presign"
+	if server := ServerURL(); server != "" {
+		url = server + "/api/logs/presign"
+	}
 
 	api := CronitorApi{
 		ApiKey:    apiKey,
@@ -441,10 +409,7 @@ func SendLogData(apiKey string, monitorKey string, seriesID string, outputLogs s
 	if err != nil {
 		return nil, err
 	}
-	client := &http.Client{
-		Timeout: 120 * time.Second,
-	}
-	resp, err := client.Do(req)
+	resp, err := NewHTTPClient(DefaultHTTPTimeout, nil).Do(req)
 	if err != nil {
 		return nil, err
 	}
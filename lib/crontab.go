@@ -5,13 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"os/exec"
-	"os/user"
 	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 
@@ -33,6 +29,7 @@ type Crontab struct {
 	TimezoneLocationName    *TimezoneLocationName `json:"timezone,omitempty"`
 	Shell                   string                `json:"-"`
 	UsesSixFieldExpressions bool                  `json:"-"`
+	Dialect                 Dialect               `json:"dialect,omitempty"`
 }
 
 // isExampleCronLine checks if a line contains obvious placeholder/example text
@@ -111,15 +108,17 @@ func (c *Crontab) Parse(noAutoDiscover bool) (error, int) {
 		panic("Cannot read into non-empty crontab struct")
 	}
 
+	// The directive stays in the crontab as an ordinary comment, so it is written back when the crontab is saved
+	if dialect, ok := dialectDirective(lines); ok {
+		c.Dialect = dialect
+	}
+
 	var autoDiscoverLine *Line
 	var name string
 	var ignored bool
 
 	for lineIndex, fullLine := range lines {
 		lineNumber := lineIndex + 1 // Convert to 1-indexed
-		var cronExpression string
-		var command []string
-		var runAs string
 
 		fullLine = strings.TrimSpace(fullLine)
 		isComment := false
@@ -169,73 +168,26 @@ func (c *Crontab) Parse(noAutoDiscover bool) (error, int) {
 			}
 		}
 
-		splitLine := strings.Fields(fullLine)
-		splitLineLen := len(splitLine)
-		if splitLineLen == 1 && strings.Contains(splitLine[0], "=") {
+		var line *Line
+		if key, value, ok := c.Dialect.ParseEnvAssignment(fullLine); ok && !isComment {
 			// Handling for environment variables... we're looking for timezone declarations and shell specifications
-			if splitExport := strings.Split(splitLine[0], "="); splitExport[0] == "TZ" || splitExport[0] == "CRON_TZ" {
-				c.TimezoneLocationName = &TimezoneLocationName{splitExport[1]}
-			} else if splitExport[0] == "SHELL" {
-				c.Shell = splitExport[1]
+			if key == "TZ" || key == "CRON_TZ" {
+				c.TimezoneLocationName = &TimezoneLocationName{value}
+			} else if key == "SHELL" {
+				c.Shell = value
 			}
-		} else if splitLineLen > 0 && strings.HasPrefix(splitLine[0], "@") {
-			// Handling for special cron @keyword
-			cronExpression = splitLine[0]
-			command = splitLine[1:]
-		} else if splitLineLen >= 6 {
-			// Handling for javacron-style 6 item cron expressions
-			c.UsesSixFieldExpressions = splitLineLen >= 7 && isSixFieldCronExpression(splitLine)
-
-			if c.UsesSixFieldExpressions {
-				cronExpression = strings.Join(splitLine[0:6], " ")
-				command = splitLine[6:]
-			} else {
-				cronExpression = strings.Join(splitLine[0:5], " ")
-				command = splitLine[5:]
-			}
-		}
-
-		// Try to determine if the command begins with a "run as" user designation. This is required for system-level crontabs.
-		// Basically, just see if the first word of the command is a valid user name. This is how vixie cron does it.
-		// https://github.com/rhuitl/uClinux/blob/master/user/vixie-cron/entry.c#L224
-		if runtime.GOOS != "windows" && len(command) > 1 && c.IsRoot() {
-			idOrError, _ := exec.Command("id", "-u", command[0]).CombinedOutput()
-			if _, err := strconv.Atoi(strings.TrimSpace(string(idOrError))); err == nil {
-				runAs = command[0]
-				command = command[1:]
-			}
-		}
-
-		// Create a Line struct with details for this line so we can re-create it later
-		line := Line{
-			IsComment:      isComment,
-			IsJob:          len(command) > 0 && len(cronExpression) > 0,
-			Name:           name,
-			CronExpression: cronExpression,
-			FullLine:       fullLine,
-			LineNumber:     lineNumber,
-			RunAs:          runAs,
-			Ignored:        ignored,
-			Crontab:        c.lightweightCopy(),
+			line = &Line{FullLine: fullLine, Crontab: c.lightweightCopy()}
+		} else {
+			line = c.ParseEntry(fullLine)
 		}
 
-		// If this job is already being wrapped by the Cronitor client, read current code.
-		// Expects a wrapped command to look like: cronitor exec d3x0 /path/to/cmd.sh
-		if len(command) > 1 && strings.HasSuffix(command[0], "cronitor") && command[1] == "exec" {
-			line.Code = command[2]
-			command = command[3:]
-		}
-
-		line.CommandToRun = strings.Join(command, " ")
-
-		// If the command appears to be quoted (starts and ends with quotes), unquote it
-		if strings.HasPrefix(line.CommandToRun, "\"") && strings.HasSuffix(line.CommandToRun, "\"") {
-			line.CommandToRun = strings.Trim(line.CommandToRun, "\"")
-			line.CommandToRun = strings.Replace(line.CommandToRun, "\\\"", "\"", -1)
-		}
+		line.IsComment = isComment
+		line.Name = name
+		line.LineNumber = lineNumber
+		line.Ignored = ignored
 
 		if line.IsAutoDiscoverCommand() {
-			autoDiscoverLine = &line
+			autoDiscoverLine = line
 			if noAutoDiscover {
 				continue // remove the auto-discover line from the crontab if --no-auto-discover flag is passed
 			}
@@ -247,7 +199,7 @@ func (c *Crontab) Parse(noAutoDiscover bool) (error, int) {
 			ignored = false
 		}
 
-		c.Lines = append(c.Lines, &line)
+		c.Lines = append(c.Lines, line)
 	}
 
 	// If we do not have an auto-discover line but we should, add one now
@@ -258,6 +210,130 @@ func (c *Crontab) Parse(noAutoDiscover bool) (error, int) {
 	return nil, 0
 }
 
+// ParseEntry parses a single uncommented cron entry. Lines that are not cron entries are
+// returned with IsJob false and only FullLine set.
+func (c *Crontab) ParseEntry(fullLine string) *Line {
+	var cronExpression string
+	var command []string
+	var runAs string
+	var noSyslog bool
+
+	fullLine = strings.TrimSpace(fullLine)
+	splitLine := strings.Fields(fullLine)
+	splitLineLen := len(splitLine)
+
+	// Cronie allows a leading '-' on the entry to disable syslog logging for the job
+	if splitLineLen > 0 && c.Dialect.AllowsNoSyslogPrefix() && len(splitLine[0]) > 1 && strings.HasPrefix(splitLine[0], "-") {
+		noSyslog = true
+		splitLine[0] = strings.TrimPrefix(splitLine[0], "-")
+	}
+
+	if splitLineLen > 0 && strings.HasPrefix(splitLine[0], "@") {
+		// Handling for special cron @keyword
+		cronExpression = splitLine[0]
+		command = splitLine[1:]
+	} else if splitLineLen >= 6 {
+		// Handling for javacron-style 6 item cron expressions
+		c.UsesSixFieldExpressions = splitLineLen >= 7 && isSixFieldCronExpression(splitLine)
+
+		if c.UsesSixFieldExpressions {
+			cronExpression = strings.Join(splitLine[0:6], " ")
+			command = splitLine[6:]
+		} else {
+			cronExpression = strings.Join(splitLine[0:5], " ")
+			command = splitLine[5:]
+		}
+	}
+
+	// Try to determine if the command begins with a "run as" user designation. This is required for system-level crontabs.
+	// Basically, just see if the first word of the command is a valid user name. This is how vixie cron does it.
+	// https://github.com/rhuitl/uClinux/blob/master/user/vixie-cron/entry.c#L224
+	if !isLocalWindows() && len(command) > 1 && c.IsRoot() {
+		idOrError, _ := ActiveTransport.Run(nil, nil, "id", "-u", command[0])
+		if _, err := strconv.Atoi(strings.TrimSpace(string(idOrError))); err == nil {
+			runAs = command[0]
+			command = command[1:]
+		}
+	}
+
+	// Everything after the first unescaped '%' is fed to the command on stdin
+	commandText, stdin := c.Dialect.SplitCommand(strings.Join(command, " "))
+	command = strings.Fields(commandText)
+
+	line := &Line{
+		IsJob:          len(command) > 0 && len(cronExpression) > 0,
+		CronExpression: cronExpression,
+		FullLine:       fullLine,
+		RunAs:          runAs,
+		Stdin:          stdin,
+		NoSyslog:       noSyslog,
+		Crontab:        c.lightweightCopy(),
+	}
+
+	// If this job is already being wrapped by the Cronitor client, read current code.
+	// Expects a wrapped command to look like: cronitor [--profile X] [--env X] [--no-stdout] exec d3x0 /path/to/cmd.sh
+	if code, profile, wrapped, noStdout, ok := parseExecWrapper(command); ok {
+		line.Code = code
+		line.Profile = profile
+		line.Mon.NoStdoutPassthru = noStdout
+		line.CommandToRun = unquoteCommandArgument(strings.Join(wrapped, " "))
+	} else {
+		line.CommandToRun = strings.Join(command, " ")
+	}
+
+	return line
+}
+
+// parseExecWrapper extracts the monitor code, pinned profile and wrapped command from a `cronitor exec` invocation.
+func parseExecWrapper(command []string) (code string, profile string, wrapped []string, noStdout bool, ok bool) {
+	if len(command) < 3 || !strings.HasSuffix(command[0], "cronitor") {
+		return "", "", nil, false, false
+	}
+
+	for i := 1; i < len(command); i++ {
+		switch command[i] {
+		case "exec":
+			if i+1 >= len(command) {
+				return "", "", nil, false, false
+			}
+			return command[i+1], profile, command[i+2:], noStdout, true
+		case "--no-stdout":
+			noStdout = true
+		case "--env":
+			i++
+		case "--profile":
+			// The profile name is quoted and can contain spaces, so read up to the closing quote
+			i++
+			start := i
+			if i < len(command) && strings.HasPrefix(command[start], "\"") {
+				for i < len(command) && !isQuotedArgument(strings.Join(command[start:i+1], " ")) {
+					i++
+				}
+			}
+			if i >= len(command) {
+				return "", "", nil, false, false
+			}
+			profile = unquoteCommandArgument(strings.Join(command[start:i+1], " "))
+		default:
+			return "", "", nil, false, false
+		}
+	}
+
+	return "", "", nil, false, false
+}
+
+// isQuotedArgument reports whether s is a complete argument written by quoteCommandArgument
+func isQuotedArgument(s string) bool {
+	if len(s) < 2 || !strings.HasPrefix(s, "\"") || !strings.HasSuffix(s, "\"") {
+		return false
+	}
+	backslashes := 0
+	for i := len(s) - 2; i > 0 && s[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 0
+}
+
 func (c Crontab) Write() string {
 	var cl []string
 	for _, line := range c.Lines {
@@ -273,18 +349,13 @@ func (c Crontab) Write() string {
 
 func (c *Crontab) Save(crontabLines string) error {
 	if c.IsUserCrontab {
-		cmd := c.buildCrontabCommand("-")
-
 		// crontab will use whatever $EDITOR is set. Temporarily just cat it out.
-		cmd.Env = []string{"EDITOR=/bin/cat"}
-		cmdStdin, _ := cmd.StdinPipe()
-		cmdStdin.Write([]byte(crontabLines))
-		cmdStdin.Close()
-		if output, err := cmd.CombinedOutput(); err != nil {
+		env := []string{"EDITOR=/bin/cat"}
+		if output, err := ActiveTransport.Run(strings.NewReader(crontabLines), env, "crontab", c.crontabArgs("-")...); err != nil {
 			return errors.New("cannot write user crontab: " + err.Error() + " " + string(output))
 		}
 	} else {
-		if ioutil.WriteFile(c.Filename, []byte(crontabLines), 0644) != nil {
+		if ActiveTransport.WriteFile(c.Filename, []byte(crontabLines)) != nil {
 			return errors.New(fmt.Sprintf("cannot write crontab at %s; check permissions and try again", c.Filename))
 		}
 	}
@@ -321,12 +392,7 @@ func (c Crontab) IsWritable() bool {
 		return true
 	}
 
-	file, err := os.OpenFile(c.Filename, os.O_WRONLY, 0666)
-	defer file.Close()
-	if err != nil {
-		return false
-	}
-	return true
+	return ActiveTransport.IsWritable(c.Filename)
 }
 
 func (c Crontab) IsRoot() bool {
@@ -336,12 +402,11 @@ func (c Crontab) IsRoot() bool {
 func (c Crontab) Exists() bool {
 
 	if c.IsUserCrontab {
-		cmd := c.buildCrontabCommand("-l")
-		if _, err := cmd.CombinedOutput(); err != nil {
+		if _, err := ActiveTransport.Run(nil, nil, "crontab", c.crontabArgs("-l")...); err != nil {
 			return false
 		}
 	} else {
-		if _, err := os.Stat(c.Filename); os.IsNotExist(err) {
+		if !ActiveTransport.Exists(c.Filename) {
 			return false
 		}
 	}
@@ -354,12 +419,11 @@ func (c Crontab) load() ([]string, int, error) {
 	var crontabBytes []byte
 
 	if c.IsUserCrontab {
-		if runtime.GOOS == "windows" {
+		if isLocalWindows() {
 			return nil, 126, errors.New("on Windows, a crontab path argument is required")
 		}
 
-		cmd := c.buildCrontabCommand("-l")
-		if b, err := cmd.CombinedOutput(); err == nil {
+		if b, err := ActiveTransport.Run(nil, nil, "crontab", c.crontabArgs("-l")...); err == nil {
 			crontabBytes = b
 		} else {
 			if strings.Contains(string(b), "no crontab") {
@@ -369,11 +433,11 @@ func (c Crontab) load() ([]string, int, error) {
 			}
 		}
 	} else {
-		if _, err := os.Stat(c.Filename); os.IsNotExist(err) {
+		if !ActiveTransport.Exists(c.Filename) {
 			return nil, 66, errors.New(fmt.Sprintf("the file %s does not exist", c.Filename))
 		}
 
-		if b, err := ioutil.ReadFile(c.Filename); err == nil {
+		if b, err := ActiveTransport.ReadFile(c.Filename); err == nil {
 			crontabBytes = b
 		} else {
 			return nil, 126, errors.New(fmt.Sprintf("the crontab file at %s could not be read; check permissions and try again", c.Filename))
@@ -418,7 +482,10 @@ type Line struct {
 	CronExpression string
 	CommandToRun   string
 	Code           string
+	Profile        string
 	RunAs          string
+	Stdin          string
+	NoSyslog       bool
 	Ignored        bool
 	Mon            Monitor
 	Crontab        Crontab
@@ -482,7 +549,11 @@ func (l Line) Write() string {
 			lineParts = append(lineParts, "#")
 		}
 
-		lineParts = append(lineParts, l.CronExpression)
+		if l.NoSyslog {
+			lineParts = append(lineParts, "-"+l.CronExpression)
+		} else {
+			lineParts = append(lineParts, l.CronExpression)
+		}
 
 		if !l.Crontab.IsUserCrontab {
 			lineParts = append(lineParts, l.RunAs)
@@ -491,6 +562,12 @@ func (l Line) Write() string {
 		if code := l.GetCode(); code != "" {
 			lineParts = append(lineParts, "cronitor")
 
+			// Lines discovered with a profile keep reporting to its account
+			if l.Profile != "" {
+				lineParts = append(lineParts, "--profile")
+				lineParts = append(lineParts, quoteCommandArgument(l.Profile))
+			}
+
 			// Add the --env flag if environment is set
 			if env := viper.GetString("CRONITOR_ENV"); env != "" {
 				lineParts = append(lineParts, "--env")
@@ -503,15 +580,15 @@ func (l Line) Write() string {
 			lineParts = append(lineParts, "exec")
 			lineParts = append(lineParts, code)
 
-			if len(l.CommandToRun) > 0 {
+			if len(l.CommandToRun) > 0 || len(l.Stdin) > 0 {
+				commandToRun := l.CommandToRun
 				if l.CommandIsComplex() {
-					lineParts = append(lineParts, "\""+strings.Replace(l.CommandToRun, "\"", "\\\"", -1)+"\"")
-				} else {
-					lineParts = append(lineParts, l.CommandToRun)
+					commandToRun = quoteCommandArgument(commandToRun)
 				}
+				lineParts = append(lineParts, l.Crontab.Dialect.JoinCommand(commandToRun, l.Stdin))
 			}
 		} else {
-			lineParts = append(lineParts, l.CommandToRun)
+			lineParts = append(lineParts, l.Crontab.Dialect.JoinCommand(l.CommandToRun, l.Stdin))
 		}
 	}
 
@@ -527,13 +604,14 @@ func (l Line) Key(CanonicalPath string) string {
 		RunAs = ""
 		CronExpression = ""
 	} else {
-		CommandToRun = l.CommandToRun
+		// Key on the command as cron sees it so monitors keep their key when stdin is split out
+		CommandToRun = l.Crontab.Dialect.JoinCommand(l.CommandToRun, l.Stdin)
 		RunAs = l.RunAs
 		CronExpression = l.CronExpression
 	}
 
-	// Always use os.Hostname when creating a key so the key does not change when a user modifies their hostname using param/var
-	hostname, _ := os.Hostname()
+	// Always use the real hostname when creating a key so the key does not change when a user modifies their hostname using param/var
+	hostname, _ := ActiveTransport.Hostname()
 	data := []byte(fmt.Sprintf("%s-%s-%s-%s", hostname, CommandToRun, CronExpression, RunAs))
 	return fmt.Sprintf("%x", sha1.Sum(data))
 }
@@ -569,6 +647,8 @@ func (l Line) MarshalJSON() ([]byte, error) {
 		CommandToRun    string `json:"command_to_run"`
 		Code            string `json:"code"`
 		RunAs           string `json:"run_as"`
+		Stdin           string `json:"stdin,omitempty"`
+		NoSyslog        bool   `json:"no_syslog,omitempty"`
 		Ignored         bool   `json:"ignored"`
 		EnvVarKey       string `json:"env_var_key,omitempty"`
 		EnvVarValue     string `json:"env_var_value,omitempty"`
@@ -587,6 +667,8 @@ func (l Line) MarshalJSON() ([]byte, error) {
 		CommandToRun:   l.CommandToRun,
 		Code:           l.Code,
 		RunAs:          l.RunAs,
+		Stdin:          l.Stdin,
+		NoSyslog:       l.NoSyslog,
 		Ignored:        l.Ignored,
 		EnvVarKey:      l.GetEnvVarKey(),
 		EnvVarValue:    l.GetEnvVarValue(),
@@ -612,7 +694,7 @@ func (l Line) MarshalJSON() ([]byte, error) {
 		}
 
 		// Limit the visible hostname portion to 21 chars
-		hostname, _ := os.Hostname()
+		hostname, _ := ActiveTransport.Hostname()
 		formattedHostname := ""
 		if hostname != "" {
 			if len(hostname) > 21 {
@@ -727,6 +809,8 @@ func (l Line) MarshalJSON() ([]byte, error) {
 			CommandToRun    string `json:"command_to_run"`
 			Code            string `json:"code"`
 			RunAs           string `json:"run_as"`
+			Stdin           string `json:"stdin,omitempty"`
+			NoSyslog        bool   `json:"no_syslog,omitempty"`
 			Ignored         bool   `json:"ignored"`
 			EnvVarKey       string `json:"env_var_key,omitempty"`
 			EnvVarValue     string `json:"env_var_value,omitempty"`
@@ -745,6 +829,8 @@ func (l Line) MarshalJSON() ([]byte, error) {
 			CommandToRun:    base.CommandToRun,
 			Code:            base.Code,
 			RunAs:           base.RunAs,
+			Stdin:           base.Stdin,
+			NoSyslog:        base.NoSyslog,
 			Ignored:         base.Ignored,
 			EnvVarKey:       base.EnvVarKey,
 			EnvVarValue:     base.EnvVarValue,
@@ -761,7 +847,11 @@ func (l Line) MarshalJSON() ([]byte, error) {
 
 // IsEnvVar checks if the line is an environment variable declaration
 func (l Line) IsEnvVar() bool {
-	return !l.IsJob && strings.Contains(l.FullLine, "=")
+	if l.IsJob || l.IsComment {
+		return false
+	}
+	_, _, ok := l.Crontab.Dialect.ParseEnvAssignment(l.FullLine)
+	return ok
 }
 
 // GetEnvVarKey extracts the key from an environment variable line
@@ -769,23 +859,17 @@ func (l Line) GetEnvVarKey() string {
 	if !l.IsEnvVar() {
 		return ""
 	}
-	parts := strings.SplitN(l.FullLine, "=", 2)
-	if len(parts) > 0 {
-		return strings.TrimSpace(parts[0])
-	}
-	return ""
+	key, _, _ := l.Crontab.Dialect.ParseEnvAssignment(l.FullLine)
+	return key
 }
 
-// GetEnvVarValue extracts the value from an environment variable line
+// GetEnvVarValue extracts the value from an environment variable line, with any surrounding quotes removed
 func (l Line) GetEnvVarValue() string {
 	if !l.IsEnvVar() {
 		return ""
 	}
-	parts := strings.SplitN(l.FullLine, "=", 2)
-	if len(parts) > 1 {
-		return strings.TrimSpace(parts[1])
-	}
-	return ""
+	_, value, _ := l.Crontab.Dialect.ParseEnvAssignment(l.FullLine)
+	return value
 }
 
 func createAutoDiscoverLine(crontab *Crontab) *Line {
@@ -824,8 +908,8 @@ func isSixFieldCronExpression(splitLine []string) bool {
 }
 
 func CurrentUserCrontab() string {
-	if u, err := user.Current(); err == nil {
-		return fmt.Sprintf("user:%s", u.Username)
+	if username := CurrentUsername(); username != "" {
+		return fmt.Sprintf("user:%s", username)
 	}
 	return ""
 }
@@ -836,6 +920,7 @@ func CrontabFactory(username, filename string) *Crontab {
 		IsUserCrontab: strings.HasPrefix(filename, "user:"),
 		Filename:      filename,
 		Shell:         "/bin/sh", // Default shell if none is specified
+		Dialect:       DetectDialect(),
 	}
 }
 
@@ -853,7 +938,7 @@ func ReadCrontabsInDirectory(username, directory string, crontabs []*Crontab) []
 }
 
 func ReadCrontabFromFile(username, filename string, crontabs []*Crontab) []*Crontab {
-	if _, err := os.Stat(filename); !strings.HasPrefix(filename, "user:") && os.IsNotExist(err) {
+	if !strings.HasPrefix(filename, "user:") && !ActiveTransport.Exists(filename) {
 		return crontabs
 	}
 
@@ -868,10 +953,7 @@ func GetAllCrontabs(users []string) ([]*Crontab, error) {
 	var crontabs []*Crontab
 
 	// Get current user for system crontab operations
-	var currentUser string
-	if u, err := user.Current(); err == nil {
-		currentUser = u.Username
-	}
+	currentUser := CurrentUsername()
 
 	// If no users specified, default to current user
 	if len(users) == 0 {
@@ -902,8 +984,8 @@ func GetCrontab(filename string) (*Crontab, error) {
 
 	if strings.HasPrefix(filename, "user:") {
 		username = strings.TrimPrefix(filename, "user:")
-	} else if u, err := user.Current(); err == nil {
-		username = u.Username
+	} else {
+		username = CurrentUsername()
 	}
 
 	crontabs := ReadCrontabFromFile(username, filename, []*Crontab{})
@@ -923,20 +1005,14 @@ func (c *Crontab) lightweightCopy() Crontab {
 		TimezoneLocationName:    c.TimezoneLocationName,
 		Shell:                   c.Shell,
 		UsesSixFieldExpressions: c.UsesSixFieldExpressions,
+		Dialect:                 c.Dialect,
 	}
 }
 
-// buildCrontabCommand builds a crontab command with appropriate user flag if needed
-func (c Crontab) buildCrontabCommand(args ...string) *exec.Cmd {
-	if c.User != "" {
-		// Check if we need to add -u flag (when accessing another user's crontab)
-		if currentUser, err := user.Current(); err == nil && currentUser.Username != c.User {
-			// Insert -u flag and username before other arguments
-			cmdArgs := []string{"-u", c.User}
-			cmdArgs = append(cmdArgs, args...)
-			return exec.Command("crontab", cmdArgs...)
-		}
+// crontabArgs builds the arguments for the crontab command, with the -u flag when accessing another user's crontab
+func (c Crontab) crontabArgs(args ...string) []string {
+	if c.User != "" && c.User != CurrentUsername() {
+		return append([]string{"-u", c.User}, args...)
 	}
-	// Default case: run crontab command without -u flag (for current user)
-	return exec.Command("crontab", args...)
+	return args
 }
@@ -0,0 +1,232 @@
+package lib
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Dialect identifies the cron implementation whose crontab syntax a file is written in.
+// The dialects differ in how they treat '%' in commands, how environment assignments
+// are parsed, and which line prefixes they accept.
+type Dialect string
+
+const (
+	// DialectVixie is ISC/Vixie cron as shipped by Debian, Ubuntu and the BSDs.
+	DialectVixie Dialect = "vixie"
+	// DialectCronie is the Vixie fork used by RHEL, Fedora and Arch. It adds CRON_TZ
+	// and the '-' entry prefix that suppresses syslog logging.
+	DialectCronie Dialect = "cronie"
+	// DialectBusybox is the minimal crond bundled with busybox (e.g. Alpine).
+	// Commands are passed to the shell verbatim and env values are never unquoted.
+	DialectBusybox Dialect = "busybox"
+)
+
+var varCronDialect = "CRONITOR_CRON_DIALECT"
+
+// envAssignmentRegex matches a Vixie-style "NAME = value" line: a name that contains no
+// whitespace or '=', optionally surrounded by whitespace, followed by '='.
+var envAssignmentRegex = regexp.MustCompile(`^([^\s=#]+)\s*=\s*(.*)$`)
+
+// busyboxEnvAssignmentRegex matches the stricter "NAME=value" form busybox accepts.
+var busyboxEnvAssignmentRegex = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+// dialectDirectiveRegex matches the "# cronitor: dialect=cronie" comment that sets the dialect of a single crontab.
+var dialectDirectiveRegex = regexp.MustCompile(`^#\s*cronitor:\s*dialect\s*=\s*(\S+)\s*$`)
+
+// ParseDialect converts a user supplied dialect name into a Dialect. Unknown names return false.
+func ParseDialect(name string) (Dialect, bool) {
+	switch Dialect(strings.ToLower(strings.TrimSpace(name))) {
+	case DialectVixie, "isc", "debian":
+		return DialectVixie, true
+	case DialectCronie:
+		return DialectCronie, true
+	case DialectBusybox:
+		return DialectBusybox, true
+	}
+	return "", false
+}
+
+// DetectDialect returns the dialect configured with CRONITOR_CRON_DIALECT, or makes a best
+// guess based on the cron daemon installed on the host whose crontabs are read. The setting
+// describes this host, so it is not applied to a remote host managed with --host. Vixie is the
+// fallback because its syntax is the common subset the other dialects extend.
+func DetectDialect() Dialect {
+	if !ActiveTransport.IsRemote() {
+		if dialect, ok := ParseDialect(viper.GetString(varCronDialect)); ok {
+			return dialect
+		}
+	}
+
+	if ActiveTransport.Exists("/etc/alpine-release") {
+		return DialectBusybox
+	}
+
+	for _, path := range []string{"/etc/redhat-release", "/etc/fedora-release", "/etc/arch-release"} {
+		if ActiveTransport.Exists(path) {
+			return DialectCronie
+		}
+	}
+
+	return DialectVixie
+}
+
+// dialectDirective returns the dialect named by a "# cronitor: dialect=..." comment in a crontab.
+// The comment applies to the whole file wherever it appears, and names that are not a known dialect are ignored.
+func dialectDirective(lines []string) (Dialect, bool) {
+	for _, line := range lines {
+		if match := dialectDirectiveRegex.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			return ParseDialect(match[1])
+		}
+	}
+	return "", false
+}
+
+// ProcessesPercent reports whether the dialect treats an unescaped '%' in a command as the
+// start of stdin, with each further '%' becoming a newline.
+func (d Dialect) ProcessesPercent() bool {
+	return d != DialectBusybox
+}
+
+// ParseEnvAssignment returns the name and value of an environment assignment line. Quoted
+// values have their surrounding quotes removed, except in the busybox dialect which uses
+// the value verbatim.
+func (d Dialect) ParseEnvAssignment(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if d == DialectBusybox {
+		match := busyboxEnvAssignmentRegex.FindStringSubmatch(line)
+		if match == nil {
+			return "", "", false
+		}
+		return match[1], match[2], true
+	}
+
+	match := envAssignmentRegex.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", false
+	}
+
+	value := strings.TrimSpace(match[2])
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return match[1], value, true
+}
+
+// SplitCommand separates a raw crontab command into the command passed to the shell and
+// the text cron feeds to its stdin, undoing cron's '%' encoding.
+func (d Dialect) SplitCommand(raw string) (command string, stdin string) {
+	if !d.ProcessesPercent() {
+		return raw, ""
+	}
+
+	var b strings.Builder
+	escaped := false
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		if escaped {
+			if ch != '%' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(ch)
+			escaped = false
+			continue
+		}
+
+		switch ch {
+		case '\\':
+			escaped = true
+		case '%':
+			return b.String(), decodeCronStdin(raw[i+1:])
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	if escaped {
+		b.WriteByte('\\')
+	}
+	return b.String(), ""
+}
+
+// JoinCommand is the inverse of SplitCommand: it escapes literal '%' characters in the
+// command and appends stdin using cron's '%' newline encoding.
+func (d Dialect) JoinCommand(command string, stdin string) string {
+	if !d.ProcessesPercent() {
+		return command
+	}
+
+	encoded := strings.Replace(command, "%", "\\%", -1)
+	if stdin != "" {
+		stdin = strings.Replace(stdin, "%", "\\%", -1)
+		encoded += "%" + strings.Replace(strings.TrimSuffix(stdin, "\n"), "\n", "%", -1)
+	}
+	return encoded
+}
+
+// AllowsNoSyslogPrefix reports whether an entry may begin with '-' to suppress syslog logging.
+func (d Dialect) AllowsNoSyslogPrefix() bool {
+	return d == DialectCronie
+}
+
+func decodeCronStdin(raw string) string {
+	var b strings.Builder
+	escaped := false
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		if escaped {
+			if ch != '%' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(ch)
+			escaped = false
+			continue
+		}
+
+		switch ch {
+		case '\\':
+			escaped = true
+		case '%':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	if escaped {
+		b.WriteByte('\\')
+	}
+
+	// Like cron, terminate non-empty input with a newline
+	decoded := b.String()
+	if decoded != "" && !strings.HasSuffix(decoded, "\n") {
+		decoded += "\n"
+	}
+	return decoded
+}
+
+// quoteCommandArgument wraps a command in double quotes so it survives the shell that cron
+// uses to start `cronitor exec`. Characters that remain special inside double quotes are escaped.
+func quoteCommandArgument(command string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`")
+	return "\"" + replacer.Replace(command) + "\""
+}
+
+// unquoteCommandArgument reverses quoteCommandArgument. Lines written by older versions only
+// escaped double quotes, which this also handles.
+func unquoteCommandArgument(command string) string {
+	if len(command) < 2 || !strings.HasPrefix(command, "\"") || !strings.HasSuffix(command, "\"") {
+		return command
+	}
+
+	inner := command[1 : len(command)-1]
+	var b strings.Builder
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) && strings.ContainsRune("\\\"$`", rune(inner[i+1])) {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String()
+}
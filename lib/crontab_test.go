@@ -1,6 +1,10 @@
 package lib
 
 import (
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -268,3 +272,203 @@ func TestLineWriteWithNoStdoutAndEnv(t *testing.T) {
 	// Restore original viper value
 	viper.Set("CRONITOR_ENV", originalEnv)
 }
+
+func TestLineWriteWithProfile(t *testing.T) {
+	viper.Set("CRONITOR_ENV", "staging")
+	defer viper.Set("CRONITOR_ENV", "")
+
+	line := Line{
+		IsJob:          true,
+		CronExpression: "0 0 * * *",
+		CommandToRun:   "/bin/daily-task",
+		Code:           "test123",
+		Profile:        "staging",
+		Mon:            Monitor{Code: "test123"},
+	}
+
+	if output := line.Write(); !strings.Contains(output, `cronitor --profile "staging" --env staging exec test123`) {
+		t.Errorf("Expected the profile to be pinned in the crontab line but got: %s", output)
+	}
+
+	line.Profile = ""
+	if output := line.Write(); strings.Contains(output, "--profile") {
+		t.Errorf("Expected no --profile without a pinned profile but got: %s", output)
+	}
+}
+
+func TestParseExecWrapperWithProfile(t *testing.T) {
+	for _, profile := range []string{"staging", "ops team"} {
+		crontab := parseCrontabContent(t, DialectVixie, "0 0 * * * /bin/daily-task\n")
+		line := crontab.Lines[0]
+		line.Code = "test123"
+		line.Profile = profile
+		line.Mon = Monitor{Code: "test123", NoStdoutPassthru: true}
+
+		parsed := crontab.ParseEntry(line.Write())
+		if parsed.Code != "test123" || parsed.Profile != profile || parsed.CommandToRun != "/bin/daily-task" || !parsed.Mon.NoStdoutPassthru {
+			t.Errorf("Expected the line to round-trip with profile %q, got code=%q profile=%q command=%q", profile, parsed.Code, parsed.Profile, parsed.CommandToRun)
+		}
+	}
+}
+
+func parseCrontabContent(t *testing.T, dialect Dialect, content string) *Crontab {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "crontab")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write crontab: %v", err)
+	}
+
+	crontab := CrontabFactory("", filename)
+	crontab.Dialect = dialect
+	if err, _ := crontab.Parse(true); err != nil {
+		t.Fatalf("failed to parse crontab: %v", err)
+	}
+	return crontab
+}
+
+func TestParsePercentAsStdin(t *testing.T) {
+	crontab := parseCrontabContent(t, DialectVixie, "0 * * * * date +\\%Y-\\%m | mail -s report root%first line%second line\n")
+
+	line := crontab.Lines[0]
+	if !line.IsJob {
+		t.Fatal("expected line to be a job")
+	}
+	if line.CommandToRun != "date +%Y-%m | mail -s report root" {
+		t.Errorf("unexpected command: %q", line.CommandToRun)
+	}
+	if line.Stdin != "first line\nsecond line\n" {
+		t.Errorf("unexpected stdin: %q", line.Stdin)
+	}
+
+	written := line.Write()
+	if written != "0 * * * * date +\\%Y-\\%m | mail -s report root%first line%second line" {
+		t.Errorf("line did not round-trip: %q", written)
+	}
+}
+
+func TestPercentKeyIsStable(t *testing.T) {
+	raw := "date +\\%Y%stdin"
+	crontab := parseCrontabContent(t, DialectVixie, "0 * * * * "+raw+"\n")
+
+	// Keys were historically a hash of the raw command text, before stdin was split out
+	hostname, _ := os.Hostname()
+	legacy := fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("%s-%s-%s-%s", hostname, raw, "0 * * * *", ""))))
+	if crontab.Lines[0].Key("path") != legacy {
+		t.Error("expected key to be computed from the raw crontab command")
+	}
+}
+
+func TestWrappedCommandRoundTrip(t *testing.T) {
+	viper.Set("CRONITOR_ENV", "")
+	defer viper.Set("CRONITOR_ENV", "")
+
+	line := Line{
+		IsJob:          true,
+		CronExpression: "*/5 * * * *",
+		CommandToRun:   `echo "$HOME" | grep -c "a\b" && printf '%s'`,
+		Code:           "abc123",
+		Mon:            Monitor{NoStdoutPassthru: true},
+		Crontab:        Crontab{IsUserCrontab: true, Dialect: DialectVixie},
+	}
+
+	written := line.Write()
+	crontab := parseCrontabContent(t, DialectVixie, written+"\n")
+	parsed := crontab.Lines[0]
+
+	if parsed.Code != "abc123" {
+		t.Errorf("expected code abc123, got %q from %q", parsed.Code, written)
+	}
+	if parsed.CommandToRun != line.CommandToRun {
+		t.Errorf("command did not round-trip:\n got  %q\n want %q\n line %q", parsed.CommandToRun, line.CommandToRun, written)
+	}
+	if !parsed.Mon.NoStdoutPassthru {
+		t.Error("expected --no-stdout to be preserved")
+	}
+}
+
+func TestEnvAssignments(t *testing.T) {
+	crontab := parseCrontabContent(t, DialectVixie, "TZ = \"America/New_York\"\nSHELL='/bin/bash'\nGREETING=hello world\n0 * * * * FOO=bar /usr/bin/job\n")
+
+	if crontab.TimezoneLocationName == nil || crontab.TimezoneLocationName.Name != "America/New_York" {
+		t.Errorf("unexpected timezone: %+v", crontab.TimezoneLocationName)
+	}
+	if crontab.Shell != "/bin/bash" {
+		t.Errorf("unexpected shell: %q", crontab.Shell)
+	}
+	if crontab.Lines[2].GetEnvVarKey() != "GREETING" || crontab.Lines[2].GetEnvVarValue() != "hello world" {
+		t.Errorf("unexpected env var: %q=%q", crontab.Lines[2].GetEnvVarKey(), crontab.Lines[2].GetEnvVarValue())
+	}
+	if !crontab.Lines[3].IsJob || crontab.Lines[3].IsEnvVar() {
+		t.Error("expected job with an inline assignment to be parsed as a job")
+	}
+}
+
+func TestCronieNoSyslogPrefix(t *testing.T) {
+	crontab := parseCrontabContent(t, DialectCronie, "-*/5 * * * * /usr/bin/job\n")
+
+	line := crontab.Lines[0]
+	if !line.IsJob || !line.NoSyslog || line.CronExpression != "*/5 * * * *" {
+		t.Fatalf("unexpected parse: %+v", line)
+	}
+	if written := line.Write(); written != "-*/5 * * * * /usr/bin/job" {
+		t.Errorf("unexpected write: %q", written)
+	}
+}
+
+func TestBusyboxCommandsAreVerbatim(t *testing.T) {
+	crontab := parseCrontabContent(t, DialectBusybox, "FOO=\"quoted\"\n0 * * * * date +%Y\n")
+
+	if crontab.Lines[0].GetEnvVarValue() != "\"quoted\"" {
+		t.Errorf("expected busybox to keep quotes, got %q", crontab.Lines[0].GetEnvVarValue())
+	}
+	line := crontab.Lines[1]
+	if line.CommandToRun != "date +%Y" || line.Stdin != "" {
+		t.Errorf("unexpected command %q stdin %q", line.CommandToRun, line.Stdin)
+	}
+	if written := line.Write(); written != "0 * * * * date +%Y" {
+		t.Errorf("unexpected write: %q", written)
+	}
+}
+
+func TestDialectDirective(t *testing.T) {
+	crontab := parseCrontabContent(t, DialectVixie, "# cronitor: dialect=cronie\n-*/5 * * * * /usr/bin/job\n")
+
+	if crontab.Dialect != DialectCronie {
+		t.Fatalf("expected the directive to set the dialect, got %q", crontab.Dialect)
+	}
+	if line := crontab.Lines[1]; !line.IsJob || !line.NoSyslog {
+		t.Errorf("expected the job to be parsed as cronie, got %+v", line)
+	}
+	if !strings.Contains(crontab.Write(), "# cronitor: dialect=cronie\n") {
+		t.Errorf("expected the directive to be written back, got %q", crontab.Write())
+	}
+
+	if crontab := parseCrontabContent(t, DialectVixie, "# cronitor: dialect=fcron\n0 * * * * date\n"); crontab.Dialect != DialectVixie {
+		t.Errorf("expected an unknown dialect to be ignored, got %q", crontab.Dialect)
+	}
+}
+
+// remoteTestTransport is a remote host that has only the given files
+type remoteTestTransport struct {
+	LocalTransport
+	files map[string]bool
+}
+
+func (remoteTestTransport) IsRemote() bool { return true }
+
+func (t remoteTestTransport) Exists(path string) bool { return t.files[path] }
+
+func TestDetectDialectSettingOnlyAppliesToThisHost(t *testing.T) {
+	viper.Set(varCronDialect, "busybox")
+	defer viper.Set(varCronDialect, "")
+
+	if dialect := DetectDialect(); dialect != DialectBusybox {
+		t.Errorf("expected the setting to apply on this host, got %q", dialect)
+	}
+
+	UseTransport(remoteTestTransport{files: map[string]bool{"/etc/redhat-release": true}})
+	defer UseTransport(nil)
+	if dialect := DetectDialect(); dialect != DialectCronie {
+		t.Errorf("expected the remote host's dialect to be detected on that host, got %q", dialect)
+	}
+}
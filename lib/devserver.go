@@ -0,0 +1,767 @@
+package lib
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	devServerMaxPings  = 1000
+	devServerMaxLogs   = 200
+	devServerMaxEvents = 10
+	devServerPageSize  = 50
+)
+
+// devServerTypes maps monitor types to the sections of a YAML config document
+var devServerTypes = map[string]string{"job": "jobs", "check": "checks", "heartbeat": "heartbeats", "site": "sites"}
+
+// DevServer is a local stand-in for the parts of Cronitor the CLI talks to: the telemetry endpoints
+// pings are sent to, the monitors API, and the presigned upload of job logs. It keeps what it receives
+// in memory, and in a JSON file when one is given, so integrations can be tested without an account.
+type DevServer struct {
+	// APIKey, when set, is the only key accepted for API requests and authenticated pings
+	APIKey string
+
+	// Logger, when set, is called with a line for every request that changes the state
+	Logger func(string)
+
+	mu    sync.Mutex
+	path  string
+	state DevServerState
+	mux   *http.ServeMux
+}
+
+// DevServerState is everything the dev server has received
+type DevServerState struct {
+	Monitors []map[string]any `json:"monitors"`
+	Pings    []DevPing        `json:"pings"`
+	Logs     []DevLog         `json:"logs"`
+}
+
+// DevPing is a telemetry event received by the dev server
+type DevPing struct {
+	Received time.Time         `json:"received"`
+	Monitor  string            `json:"monitor"`
+	State    string            `json:"state"`
+	Params   map[string]string `json:"params,omitempty"`
+}
+
+// DevLog is job output uploaded to the dev server through a presigned URL
+type DevLog struct {
+	ID       string     `json:"id"`
+	Monitor  string     `json:"monitor"`
+	Series   string     `json:"series,omitempty"`
+	Received *time.Time `json:"received,omitempty"`
+	Output   string     `json:"output"`
+}
+
+// NewDevServer returns a dev server that saves its state to path, or only keeps it in memory when
+// path is empty. State saved by an earlier run is loaded.
+func NewDevServer(path string) (*DevServer, error) {
+	s := &DevServer{path: path}
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			if err := json.Unmarshal(data, &s.state); err != nil {
+				return nil, fmt.Errorf("cannot read dev server state %s: %v", path, err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	s.state.init()
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/", s.handleRoot)
+	s.mux.HandleFunc("/ping/", s.handleAuthenticatedPing)
+	s.mux.HandleFunc("/api/monitors", s.handleMonitors)
+	s.mux.HandleFunc("/api/monitors/", s.handleMonitor)
+	s.mux.HandleFunc("/api/logs/presign", s.handlePresign)
+	s.mux.HandleFunc("/_logs/", s.handleLogUpload)
+	s.mux.HandleFunc("/_devserver/state", s.handleState)
+	return s, nil
+}
+
+func (st *DevServerState) init() {
+	if st.Monitors == nil {
+		st.Monitors = []map[string]any{}
+	}
+	if st.Pings == nil {
+		st.Pings = []DevPing{}
+	}
+	if st.Logs == nil {
+		st.Logs = []DevLog{}
+	}
+}
+
+func (s *DevServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// State returns a copy of everything the server has received
+func (s *DevServer) State() DevServerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, _ := json.Marshal(s.state)
+	var state DevServerState
+	json.Unmarshal(data, &state)
+	return state
+}
+
+func (s *DevServer) logf(format string, args ...any) {
+	if s.Logger != nil {
+		s.Logger(fmt.Sprintf(format, args...))
+	}
+}
+
+// save writes the state to the state file. It is called with the lock held.
+func (s *DevServer) save() {
+	if s.path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		s.logf("Cannot save dev server state: %v", err)
+		return
+	}
+	tempFile := s.path + ".tmp"
+	if err := ioutil.WriteFile(tempFile, data, 0644); err != nil {
+		s.logf("Cannot save dev server state: %v", err)
+		return
+	}
+	os.Rename(tempFile, s.path)
+}
+
+// authorized checks the API key of a request, sent as the basic auth username like the CLI does
+func (s *DevServer) authorized(w http.ResponseWriter, r *http.Request) bool {
+	key, _, ok := r.BasicAuth()
+	if !ok || key == "" {
+		devServerError(w, http.StatusUnauthorized, "Authentication credentials were not provided.")
+		return false
+	}
+	if s.APIKey != "" && key != s.APIKey {
+		devServerError(w, http.StatusForbidden, "Invalid API key.")
+		return false
+	}
+	return true
+}
+
+func devServerError(w http.ResponseWriter, status int, message string) {
+	devServerJSON(w, status, map[string]string{"error": message})
+}
+
+func devServerJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func devServerID(length int) string {
+	b := make([]byte, (length+1)/2)
+	rand.Read(b)
+	return hex.EncodeToString(b)[:length]
+}
+
+// find returns the index of the monitor with this key or code, or -1
+func (s *DevServer) find(keyOrCode string) int {
+	for i, monitor := range s.state.Monitors {
+		if monitor["key"] == keyOrCode || monitor["code"] == keyOrCode {
+			return i
+		}
+	}
+	return -1
+}
+
+// upsert creates a monitor or changes the given fields of an existing one, and returns it
+func (s *DevServer) upsert(fields map[string]any) map[string]any {
+	key, _ := fields["key"].(string)
+	if i := s.find(key); key != "" && i >= 0 {
+		monitor := s.state.Monitors[i]
+		for field, value := range fields {
+			if field != "code" {
+				monitor[field] = value
+			}
+		}
+		devServerAttributes(monitor)
+		return monitor
+	}
+
+	monitor := map[string]any{"type": "job", "passing": true, "paused": false, "created": time.Now().UTC().Format(time.RFC3339)}
+	for field, value := range fields {
+		monitor[field] = value
+	}
+	if code, _ := monitor["code"].(string); code == "" {
+		monitor["code"] = devServerID(6)
+	}
+	if key == "" {
+		monitor["key"] = monitor["code"]
+	}
+	if name, _ := monitor["name"].(string); name == "" {
+		monitor["name"] = monitor["key"]
+		if defaultName, _ := monitor["defaultName"].(string); defaultName != "" {
+			monitor["name"] = defaultName
+		}
+	}
+	devServerAttributes(monitor)
+	s.state.Monitors = append(s.state.Monitors, monitor)
+	return monitor
+}
+
+// devServerAttributes fills in the attributes object that `cronitor discover` reads monitor codes from
+func devServerAttributes(monitor map[string]any) {
+	group, _ := monitor["group"].(string)
+	monitor["attributes"] = map[string]any{"key": monitor["key"], "code": monitor["code"], "group_name": group}
+}
+
+func (s *DevServer) handleRoot(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" {
+		s.handleUI(w, r)
+		return
+	}
+
+	// Unauthenticated pings: /<code>/<state>
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	s.ping(w, r, parts[0], parts[1])
+}
+
+// handleAuthenticatedPing receives /ping/<api key>/<monitor key or code>?state=<state>
+func (s *DevServer) handleAuthenticatedPing(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/ping/"), "/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	if s.APIKey != "" && parts[0] != s.APIKey {
+		http.Error(w, "Invalid API key", http.StatusForbidden)
+		return
+	}
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		state = "complete"
+	}
+	s.ping(w, r, parts[1], state)
+}
+
+func (s *DevServer) ping(w http.ResponseWriter, r *http.Request, keyOrCode, state string) {
+	switch state {
+	case "run", "complete", "fail", "ok", "tick":
+	default:
+		http.Error(w, fmt.Sprintf("Unknown state '%s'", state), http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]string{}
+	for name, values := range r.URL.Query() {
+		if name != "state" && name != "try" {
+			params[name] = strings.Join(values, ",")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var monitor map[string]any
+	if i := s.find(keyOrCode); i >= 0 {
+		monitor = s.state.Monitors[i]
+	} else {
+		monitor = s.upsert(map[string]any{"key": keyOrCode})
+	}
+	switch state {
+	case "complete", "ok", "tick":
+		monitor["passing"] = true
+	case "fail":
+		monitor["passing"] = false
+	}
+
+	event := map[string]any{"stamp": float64(now.UnixNano()) / 1e9, "event": state}
+	for name, value := range params {
+		event[name] = value
+	}
+	events, _ := monitor["latest_events"].([]any)
+	events = append([]any{event}, events...)
+	if len(events) > devServerMaxEvents {
+		events = events[:devServerMaxEvents]
+	}
+	monitor["latest_event"] = event
+	monitor["latest_events"] = events
+
+	s.state.Pings = append(s.state.Pings, DevPing{Received: now, Monitor: monitor["key"].(string), State: state, Params: params})
+	if len(s.state.Pings) > devServerMaxPings {
+		s.state.Pings = s.state.Pings[len(s.state.Pings)-devServerMaxPings:]
+	}
+	s.save()
+	s.logf("ping %s %s", monitor["key"], state)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleMonitors serves /api/monitors: list, create, bulk upsert and bulk delete
+func (s *DevServer) handleMonitors(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		monitors := s.filter(r)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		page, pageSize = max(page, 1), max(pageSize, 0)
+		if pageSize == 0 {
+			pageSize = devServerPageSize
+		}
+		start := min(len(monitors), (page-1)*pageSize)
+		end := min(len(monitors), start+pageSize)
+
+		if r.URL.Query().Get("format") == "yaml" {
+			devServerYAML(w, monitors[start:end])
+			return
+		}
+		devServerJSON(w, http.StatusOK, map[string]any{
+			"monitors":            monitors[start:end],
+			"page":                page,
+			"page_size":           pageSize,
+			"total_monitor_count": len(monitors),
+			"page_info":           map[string]int{"page": page, "pageSize": pageSize, "totalMonitorCount": len(monitors)},
+		})
+
+	case http.MethodPost:
+		var fields map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			devServerError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+			return
+		}
+		if key, _ := fields["key"].(string); key != "" && s.find(key) >= 0 {
+			devServerError(w, http.StatusBadRequest, fmt.Sprintf("A monitor with key '%s' already exists.", key))
+			return
+		}
+		monitor := s.upsert(fields)
+		s.save()
+		s.logf("created monitor %s", monitor["key"])
+		devServerJSON(w, http.StatusCreated, monitor)
+
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		isYAML := strings.Contains(r.Header.Get("Content-Type"), "yaml")
+		updates, err := devServerUpdates(body, isYAML)
+		if err != nil {
+			devServerError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var upserted []map[string]any
+		for _, fields := range updates {
+			upserted = append(upserted, s.upsert(fields))
+		}
+		s.save()
+		s.logf("updated %d monitors", len(upserted))
+		if isYAML {
+			devServerYAML(w, upserted)
+			return
+		}
+		devServerJSON(w, http.StatusOK, upserted)
+
+	case http.MethodDelete:
+		var request struct {
+			Monitors []string `json:"monitors"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			devServerError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+			return
+		}
+		missing := []string{}
+		deleted := 0
+		for _, key := range request.Monitors {
+			if s.remove(key) {
+				deleted++
+			} else {
+				missing = append(missing, key)
+			}
+		}
+		s.save()
+		s.logf("deleted %d monitors", deleted)
+		devServerJSON(w, http.StatusOK, map[string]any{"deleted_count": deleted, "requested_count": len(request.Monitors), "errors": map[string]any{"missing": missing}})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMonitor serves /api/monitors/<key> and /api/monitors/<key>/pause[/<hours>]
+func (s *DevServer) handleMonitor(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/monitors/"), "/"), "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(parts[0])
+	if i < 0 {
+		devServerError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	monitor := s.state.Monitors[i]
+
+	if len(parts) > 1 && parts[1] == "pause" {
+		hours := ""
+		if len(parts) > 2 {
+			hours = parts[2]
+		}
+		monitor["paused"] = hours != "0"
+		s.save()
+		s.logf("paused monitor %s: %v", monitor["key"], monitor["paused"])
+		devServerJSON(w, http.StatusOK, map[string]any{})
+		return
+	} else if len(parts) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		devServerJSON(w, http.StatusOK, monitor)
+	case http.MethodPut:
+		var fields map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			devServerError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+			return
+		}
+		fields["key"] = monitor["key"]
+		monitor = s.upsert(fields)
+		s.save()
+		s.logf("updated monitor %s", monitor["key"])
+		devServerJSON(w, http.StatusOK, monitor)
+	case http.MethodDelete:
+		s.remove(parts[0])
+		s.save()
+		s.logf("deleted monitor %s", parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *DevServer) remove(key string) bool {
+	i := s.find(key)
+	if i < 0 {
+		return false
+	}
+	s.state.Monitors = append(s.state.Monitors[:i], s.state.Monitors[i+1:]...)
+	return true
+}
+
+// filter returns the monitors matching the list filters of a request
+func (s *DevServer) filter(r *http.Request) []map[string]any {
+	query := r.URL.Query()
+	split := func(name string) []string {
+		var values []string
+		for _, value := range query[name] {
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					values = append(values, v)
+				}
+			}
+		}
+		return values
+	}
+	tags, types, states := split("tag"), split("type"), split("state")
+	group, search := query.Get("group"), strings.ToLower(query.Get("search"))
+
+	monitors := []map[string]any{}
+	for _, monitor := range s.state.Monitors {
+		if len(types) > 0 && !devServerContains(types, monitor["type"]) {
+			continue
+		}
+		if group != "" && monitor["group"] != group {
+			continue
+		}
+		if len(tags) > 0 {
+			monitorTags, _ := monitor["tags"].([]any)
+			if !devServerContainsAny(tags, monitorTags) {
+				continue
+			}
+		}
+		if len(states) > 0 && !devServerContains(states, devServerMonitorState(monitor)) {
+			continue
+		}
+		if search != "" {
+			name, _ := monitor["name"].(string)
+			key, _ := monitor["key"].(string)
+			if !strings.Contains(strings.ToLower(name), search) && !strings.Contains(strings.ToLower(key), search) {
+				continue
+			}
+		}
+		monitors = append(monitors, monitor)
+	}
+	return monitors
+}
+
+func devServerMonitorState(monitor map[string]any) string {
+	if paused, _ := monitor["paused"].(bool); paused {
+		return "paused"
+	}
+	if passing, _ := monitor["passing"].(bool); passing {
+		return "passing"
+	}
+	return "failing"
+}
+
+func devServerContains(values []string, v any) bool {
+	for _, value := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func devServerContainsAny(values []string, vs []any) bool {
+	for _, v := range vs {
+		if devServerContains(values, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// devServerUpdates reads the monitors of a bulk PUT: a JSON array, or a YAML config document with
+// jobs, checks, heartbeats and sites sections keyed by monitor key
+func devServerUpdates(body []byte, isYAML bool) ([]map[string]any, error) {
+	if !isYAML {
+		var updates []map[string]any
+		if err := json.Unmarshal(body, &updates); err != nil {
+			return nil, fmt.Errorf("Invalid JSON: expected an array of monitors: %v", err)
+		}
+		return updates, nil
+	}
+
+	var config map[string]map[string]map[string]any
+	if err := yaml.Unmarshal(body, &config); err != nil {
+		return nil, fmt.Errorf("Invalid YAML: %v", err)
+	}
+	var updates []map[string]any
+	for monitorType, section := range devServerTypes {
+		keys := make([]string, 0, len(config[section]))
+		for key := range config[section] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fields := config[section][key]
+			if fields == nil {
+				fields = map[string]any{}
+			}
+			fields["key"], fields["type"] = key, monitorType
+			updates = append(updates, fields)
+		}
+	}
+	return updates, nil
+}
+
+// devServerYAML writes monitors as a YAML config document
+func devServerYAML(w http.ResponseWriter, monitors []map[string]any) {
+	config := map[string]map[string]map[string]any{}
+	for _, monitor := range monitors {
+		monitorType, _ := monitor["type"].(string)
+		section, ok := devServerTypes[monitorType]
+		if !ok {
+			section = devServerTypes["job"]
+		}
+		fields := map[string]any{}
+		for field, value := range monitor {
+			switch field {
+			case "key", "type", "attributes", "latest_event", "latest_events":
+			default:
+				fields[field] = value
+			}
+		}
+		if config[section] == nil {
+			config[section] = map[string]map[string]any{}
+		}
+		config[section][monitor["key"].(string)] = fields
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	if len(config) == 0 {
+		return
+	}
+	data, _ := yaml.Marshal(config)
+	w.Write(data)
+}
+
+// handlePresign hands out a URL on this server to upload a job's gzipped log to, as S3 would
+func (s *DevServer) handlePresign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(w, r) {
+		return
+	}
+
+	var request struct {
+		JobKey string `json:"job_key"`
+		Series string `json:"series"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.JobKey == "" {
+		devServerError(w, http.StatusBadRequest, "job_key is required")
+		return
+	}
+
+	s.mu.Lock()
+	log := DevLog{ID: devServerID(16), Monitor: request.JobKey, Series: request.Series}
+	s.state.Logs = append(s.state.Logs, log)
+	if len(s.state.Logs) > devServerMaxLogs {
+		s.state.Logs = s.state.Logs[len(s.state.Logs)-devServerMaxLogs:]
+	}
+	s.save()
+	s.mu.Unlock()
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	devServerJSON(w, http.StatusOK, map[string]string{"url": fmt.Sprintf("%s://%s/_logs/%s", scheme, r.Host, log.ID)})
+}
+
+// handleLogUpload receives the PUT to a presigned URL
+func (s *DevServer) handleLogUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 10*1024*1024))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if gz, err := gzip.NewReader(bytes.NewReader(body)); err == nil {
+		if unzipped, err := io.ReadAll(gz); err == nil {
+			body = unzipped
+		}
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/_logs/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.Logs {
+		if s.state.Logs[i].ID == id {
+			now := time.Now()
+			s.state.Logs[i].Received = &now
+			s.state.Logs[i].Output = string(body)
+			s.save()
+			s.logf("received %d bytes of logs for %s", len(body), s.state.Logs[i].Monitor)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	http.Error(w, "The presigned URL has expired or does not exist", http.StatusForbidden)
+}
+
+// handleState serves everything received as JSON, and clears it on DELETE
+func (s *DevServer) handleState(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		devServerJSON(w, http.StatusOK, s.State())
+	case http.MethodDelete:
+		s.mu.Lock()
+		s.state = DevServerState{}
+		s.state.init()
+		s.save()
+		s.mu.Unlock()
+		s.logf("cleared state")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+var devServerPage = template.Must(template.New("devserver").Funcs(template.FuncMap{
+	"state": devServerMonitorState,
+	"time":  func(t time.Time) string { return t.Format("15:04:05") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Cronitor dev server</title>
+<meta http-equiv="refresh" content="5">
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2em; color: #111827; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { text-align: left; padding: 4px 12px; border-bottom: 1px solid #e5e7eb; font-size: 14px; }
+th { background: #7C3AED; color: white; }
+.passing { color: #10B981; } .failing { color: #EF4444; } .paused { color: #F59E0B; }
+pre { margin: 0; max-height: 8em; overflow: auto; }
+</style>
+</head>
+<body>
+<h1>Cronitor dev server</h1>
+<p>Everything received is also available as JSON at <a href="/_devserver/state">/_devserver/state</a>.</p>
+<h2>Monitors</h2>
+<table>
+<tr><th>Key</th><th>Code</th><th>Name</th><th>Type</th><th>Status</th></tr>
+{{range .Monitors}}<tr><td>{{.key}}</td><td>{{.code}}</td><td>{{.name}}</td><td>{{.type}}</td><td class="{{state .}}">{{state .}}</td></tr>
+{{else}}<tr><td colspan="5">No monitors yet</td></tr>{{end}}
+</table>
+<h2>Recent pings</h2>
+<table>
+<tr><th>Received</th><th>Monitor</th><th>State</th><th>Params</th></tr>
+{{range .Pings}}<tr><td>{{time .Received}}</td><td>{{.Monitor}}</td><td>{{.State}}</td><td>{{range $k, $v := .Params}}{{$k}}={{$v}} {{end}}</td></tr>
+{{else}}<tr><td colspan="4">No pings yet</td></tr>{{end}}
+</table>
+<h2>Logs</h2>
+<table>
+<tr><th>Monitor</th><th>Series</th><th>Output</th></tr>
+{{range .Logs}}<tr><td>{{.Monitor}}</td><td>{{.Series}}</td><td><pre>{{.Output}}</pre></td></tr>
+{{else}}<tr><td colspan="3">No logs yet</td></tr>{{end}}
+</table>
+</body>
+</html>
+`))
+
+func (s *DevServer) handleUI(w http.ResponseWriter, r *http.Request) {
+	state := s.State()
+
+	// Newest first, and only the latest of each
+	pings := state.Pings
+	for i, j := 0, len(pings)-1; i < j; i, j = i+1, j-1 {
+		pings[i], pings[j] = pings[j], pings[i]
+	}
+	state.Pings = pings[:min(len(pings), 50)]
+	logs := state.Logs
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+	state.Logs = logs[:min(len(logs), 20)]
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	devServerPage.Execute(w, state)
+}
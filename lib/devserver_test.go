@@ -0,0 +1,202 @@
+package lib_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cronitorio/cronitor-cli/lib"
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/viper"
+)
+
+// newTestDevServer starts a dev server and points CRONITOR_URL at it
+func newTestDevServer(t *testing.T, path string) (*lib.DevServer, *httptest.Server) {
+	t.Helper()
+	server, err := lib.NewDevServer(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	viper.Set("CRONITOR_URL", ts.URL)
+	viper.Set("CRONITOR_API_KEY", "test-api-key")
+	t.Cleanup(func() {
+		ts.Close()
+		viper.Set("CRONITOR_URL", "")
+		viper.Set("CRONITOR_API_KEY", "")
+	})
+	return server, ts
+}
+
+func TestDevServer_Pings(t *testing.T) {
+	server, ts := newTestDevServer(t, "")
+
+	for _, path := range []string{"/ping/test-api-key/backup?state=run&host=web1", "/ping/test-api-key/backup?state=fail&status_code=2", "/abc123/complete"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != 200 {
+			t.Fatalf("expected %s to be accepted, got %d", path, resp.StatusCode)
+		}
+	}
+
+	state := server.State()
+	if len(state.Pings) != 3 || state.Pings[1].State != "fail" || state.Pings[1].Params["status_code"] != "2" {
+		t.Fatalf("expected the pings to be recorded, got %+v", state.Pings)
+	}
+	if len(state.Monitors) != 2 {
+		t.Fatalf("expected pings to create a monitor for each key, got %v", state.Monitors)
+	}
+	if backup := state.Monitors[0]; backup["key"] != "backup" || backup["passing"] != false || len(backup["latest_events"].([]any)) != 2 {
+		t.Errorf("expected the failed ping to mark backup failing, got %v", backup)
+	}
+
+	resp, _ := http.Get(ts.URL + "/abc123/explode")
+	if resp.StatusCode != 400 {
+		t.Errorf("expected an unknown state to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestDevServer_PingRejectsWrongKey(t *testing.T) {
+	server, ts := newTestDevServer(t, "")
+	server.APIKey = "secret"
+
+	resp, _ := http.Get(ts.URL + "/ping/other/backup?state=run")
+	if resp.StatusCode != 403 || len(server.State().Pings) != 0 {
+		t.Errorf("expected a ping with the wrong key to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestDevServer_MonitorsAPI(t *testing.T) {
+	newTestDevServer(t, "")
+	client := lib.NewCronitorClient(false, nil)
+	ctx := context.Background()
+
+	if _, err := client.Monitors.Create(ctx, &api.Monitor{Key: "backup", Type: "job", Tags: []string{"db"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Monitors.Upsert(ctx, []*api.Monitor{{Key: "homepage", Type: "check", Name: "Homepage"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	page, err := client.Monitors.List(ctx, &api.MonitorListOptions{Tags: []string{"db"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Key != "backup" {
+		t.Errorf("expected the tag filter to match backup, got %+v", page.Items)
+	}
+
+	if err := client.Monitors.Pause(ctx, "homepage", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	monitor, err := client.Monitors.Get(ctx, "homepage", nil)
+	if err != nil || !monitor.Paused {
+		t.Errorf("expected homepage to be paused, got %+v, %v", monitor, err)
+	}
+
+	config, err := client.Monitors.ListYAML(ctx, nil)
+	if err != nil || !strings.Contains(string(config), "checks:\n    homepage:") {
+		t.Errorf("expected a YAML config document, got %s, %v", config, err)
+	}
+	if _, err := client.Monitors.ApplyYAML(ctx, []byte("heartbeats:\n  nightly-report:\n    name: Nightly report\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := client.Monitors.DeleteMany(ctx, []string{"backup", "missing"})
+	if err != nil || result.DeletedCount != 1 {
+		t.Errorf("expected one monitor to be deleted, got %+v, %v", result, err)
+	}
+	if _, err := client.Monitors.Get(ctx, "backup", nil); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected backup to be gone, got %v", err)
+	}
+	if err := client.Monitors.Delete(ctx, "nightly-report"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDevServer_LegacyClient(t *testing.T) {
+	newTestDevServer(t, "")
+	cronitorApi := lib.CronitorApi{ApiKey: "test-api-key", UserAgent: "CronitorCLI/test", Logger: func(string) {}}
+
+	monitors, err := cronitorApi.PutMonitors(map[string]*lib.Monitor{"backup": {Key: "backup", Type: "job", DefaultName: "Nightly backup"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code := monitors["backup"].Attributes.Code
+	if code == "" {
+		t.Fatal("expected discover to get a code back for the monitor")
+	}
+
+	listed, err := cronitorApi.GetMonitors()
+	if err != nil || len(listed) != 1 || listed[0].Code != code || listed[0].Name != "Nightly backup" {
+		t.Errorf("expected the monitor to be listed, got %+v, %v", listed, err)
+	}
+}
+
+func TestDevServer_LogUpload(t *testing.T) {
+	server, _ := newTestDevServer(t, "")
+
+	if _, err := lib.SendLogData("test-api-key", "backup", "series-1", "hello\nworld\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := server.State().Logs
+	if len(logs) != 1 || logs[0].Monitor != "backup" || logs[0].Series != "series-1" || logs[0].Output != "hello\nworld\n" {
+		t.Errorf("expected the gzipped log to be stored, got %+v", logs)
+	}
+}
+
+func TestDevServer_StateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	_, ts := newTestDevServer(t, path)
+
+	resp, _ := http.Get(ts.URL + "/backup/run")
+	resp.Body.Close()
+
+	reloaded, err := lib.NewDevServer(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state := reloaded.State(); len(state.Pings) != 1 || len(state.Monitors) != 1 {
+		t.Errorf("expected the state to be loaded from the file, got %+v", state)
+	}
+
+	resp, _ = http.Get(ts.URL + "/_devserver/state")
+	var state lib.DevServerState
+	json.NewDecoder(resp.Body).Decode(&state)
+	resp.Body.Close()
+	if len(state.Pings) != 1 {
+		t.Errorf("expected the state as JSON, got %+v", state)
+	}
+
+	req, _ := http.NewRequest("DELETE", ts.URL+"/_devserver/state", nil)
+	http.DefaultClient.Do(req)
+	reloaded, _ = lib.NewDevServer(path)
+	if state := reloaded.State(); len(state.Pings) != 0 || len(state.Monitors) != 0 {
+		t.Errorf("expected DELETE to clear the saved state, got %+v", state)
+	}
+}
+
+func TestDevServer_UI(t *testing.T) {
+	_, ts := newTestDevServer(t, "")
+	http.Get(ts.URL + "/backup/fail")
+
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	page, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(page), `<td class="failing">failing</td>`) {
+		t.Errorf("expected the monitor in the page, got:\n%s", page)
+	}
+}
@@ -0,0 +1,136 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// userCrontabDirectories are where the crontab command keeps user crontabs on the platforms we support
+var userCrontabDirectories = []string{
+	"/var/spool/cron/crontabs", // Debian, Ubuntu, Alpine
+	"/var/spool/cron",          // RHEL, Fedora, Arch
+	"/var/at/tabs",             // FreeBSD
+	"/usr/lib/cron/tabs",       // MacOS
+}
+
+// CrontabPaths returns the files and directories that hold crontabs on this host. Directories that
+// do not exist are left out.
+func CrontabPaths() []string {
+	paths := []string{}
+	for _, path := range append([]string{SYSTEM_CRONTAB, DROP_IN_DIRECTORY}, userCrontabDirectories...) {
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// FileWatcher reports changes to a set of files and directories. Bursts of events, like an editor
+// writing a temp file and renaming it over a crontab, are delivered as a single batch of paths.
+type FileWatcher struct {
+	Changes  chan []string
+	watcher  *fsnotify.Watcher
+	files    map[string]bool
+	dirs     map[string]bool
+	debounce time.Duration
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewFileWatcher watches the given paths. A file is watched through its directory so it is still
+// followed after being replaced, and only events for that file are reported.
+func NewFileWatcher(paths []string, debounce time.Duration) (*FileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &FileWatcher{
+		Changes:  make(chan []string, 1),
+		watcher:  watcher,
+		files:    make(map[string]bool),
+		dirs:     make(map[string]bool),
+		debounce: debounce,
+		done:     make(chan struct{}),
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		dir := path
+		if info.IsDir() {
+			w.dirs[path] = true
+		} else {
+			dir = filepath.Dir(path)
+			w.files[path] = true
+		}
+
+		// Directories we cannot read, like another user's crontab spool, are skipped
+		watcher.Add(dir)
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// relevant reports whether an event is for a watched file or inside a directory watched in full
+func (w *FileWatcher) relevant(path string) bool {
+	return w.files[path] || w.dirs[filepath.Dir(path)] || w.dirs[path]
+}
+
+func (w *FileWatcher) run() {
+	pending := make(map[string]bool)
+	var timer <-chan time.Time
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if !w.relevant(event.Name) {
+				continue
+			}
+			pending[event.Name] = true
+			if timer == nil {
+				timer = time.After(w.debounce)
+			}
+		case _, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-timer:
+			timer = nil
+			changed := make([]string, 0, len(pending))
+			for path := range pending {
+				changed = append(changed, path)
+			}
+			pending = make(map[string]bool)
+
+			select {
+			case w.Changes <- changed:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+// Close stops watching
+func (w *FileWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.watcher.Close()
+	})
+	return err
+}
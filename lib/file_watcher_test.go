@@ -0,0 +1,44 @@
+package lib
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileWatcherBatchesChanges(t *testing.T) {
+	dir := t.TempDir()
+	other := t.TempDir()
+	crontab := filepath.Join(other, "crontab")
+	ioutil.WriteFile(crontab, []byte("* * * * * true\n"), 0644)
+
+	watcher, err := NewFileWatcher([]string{dir, crontab}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	// Files next to a watched file are not reported
+	ioutil.WriteFile(filepath.Join(other, "unrelated"), []byte("x"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "a.json"), []byte("{}"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte("{}"), 0644)
+	ioutil.WriteFile(crontab, []byte("*/5 * * * * true\n"), 0644)
+
+	changed := make(map[string]bool)
+	timeout := time.After(3 * time.Second)
+	for len(changed) < 3 {
+		select {
+		case paths := <-watcher.Changes:
+			for _, path := range paths {
+				changed[path] = true
+			}
+		case <-timeout:
+			t.Fatalf("expected changes to both files in the directory and the crontab, got %v", changed)
+		}
+	}
+
+	if changed[filepath.Join(other, "unrelated")] {
+		t.Error("expected files next to a watched file to be ignored")
+	}
+}
@@ -0,0 +1,97 @@
+package lib
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cronitorio/cronitor-cli/lib/api"
+	"github.com/spf13/viper"
+)
+
+// DefaultHTTPTimeout bounds an API call, including its retries, unless CRONITOR_TIMEOUT is set
+const DefaultHTTPTimeout = 120 * time.Second
+
+var (
+	sharedTransport     http.RoundTripper
+	sharedTransportOnce sync.Once
+)
+
+// SharedTransport returns the transport every Cronitor HTTP client is built on, so connections
+// are pooled across requests. It honors HTTPS_PROXY, HTTP_PROXY and NO_PROXY, and trusts the
+// certificates in CRONITOR_CA_BUNDLE in addition to the system roots, for proxies that
+// re-sign TLS traffic.
+func SharedTransport() http.RoundTripper {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = newTransport(viper.GetString("CRONITOR_CA_BUNDLE"))
+	})
+	return sharedTransport
+}
+
+// NewHTTPClient returns a client on the shared transport that retries rate limited and failed
+// requests. timeout is the command's default limit for a call and its retries; CRONITOR_TIMEOUT
+// overrides it.
+func NewHTTPClient(timeout time.Duration, logger func(string)) *http.Client {
+	retry := api.NewRetryTransport(SharedTransport())
+	retry.Logger = logger
+	return &http.Client{Timeout: HTTPTimeout(timeout), Transport: retry}
+}
+
+// HTTPTimeout returns the CRONITOR_TIMEOUT setting, a duration such as "30s" or a number of
+// seconds, or fallback when it is unset or not positive
+func HTTPTimeout(fallback time.Duration) time.Duration {
+	value := viper.GetString("CRONITOR_TIMEOUT")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+		return timeout
+	}
+	return fallback
+}
+
+func newTransport(caBundle string) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if caBundle != "" {
+		roots, err := loadCABundle(caBundle)
+		if err != nil {
+			return failingTransport{err}
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	}
+	return transport
+}
+
+func loadCABundle(path string) (*x509.CertPool, error) {
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil || roots == nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", path)
+	}
+	return roots, nil
+}
+
+// failingTransport reports a misconfigured transport on every request rather than silently
+// falling back to one the user did not ask for
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
@@ -0,0 +1,75 @@
+package lib
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestHTTPTimeout(t *testing.T) {
+	defer viper.Set("CRONITOR_TIMEOUT", nil)
+
+	tests := []struct {
+		setting  string
+		expected time.Duration
+	}{
+		{"", 10 * time.Second},
+		{"0s", 10 * time.Second},
+		{"45", 45 * time.Second},
+		{"1m30s", 90 * time.Second},
+		{"soon", 10 * time.Second},
+	}
+	for _, tt := range tests {
+		viper.Set("CRONITOR_TIMEOUT", tt.setting)
+		if got := HTTPTimeout(10 * time.Second); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.setting, tt.expected, got)
+		}
+	}
+}
+
+func TestNewTransport_CABundle(t *testing.T) {
+	dir := t.TempDir()
+
+	missing := newTransport(filepath.Join(dir, "missing.pem"))
+	req, _ := http.NewRequest(http.MethodGet, "https://cronitor.io/api/monitors", nil)
+	if _, err := missing.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "CA bundle") {
+		t.Errorf("expected a missing bundle to fail every request, got %v", err)
+	}
+
+	empty := filepath.Join(dir, "empty.pem")
+	os.WriteFile(empty, []byte("not a certificate"), 0644)
+	if _, err := newTransport(empty).RoundTrip(req); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Errorf("expected a bundle without certificates to be rejected, got %v", err)
+	}
+
+	transport, ok := newTransport("").(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		t.Error("expected the default transport to honor proxy environment variables")
+	}
+}
+
+func TestNewTransport_TrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "proxy-ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	os.WriteFile(bundle, certificate, 0644)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := newTransport("").RoundTrip(req); err == nil {
+		t.Fatal("expected the test server's certificate to be untrusted without the bundle")
+	}
+	resp, err := newTransport(bundle).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected the bundle to be trusted, got %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,271 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RunningInstance is a job run started by `cronitor exec`. Each run is recorded in the instance registry
+// so the dashboard and `cronitor ps` can find it without matching command strings against `ps` output.
+type RunningInstance struct {
+	PID     int       `json:"pid"`
+	PGID    int       `json:"pgid"`
+	ExecPID int       `json:"exec_pid"`
+	Code    string    `json:"code"`
+	Series  string    `json:"series"`
+	Command string    `json:"command"`
+	User    string    `json:"user,omitempty"`
+	Started time.Time `json:"started"`
+}
+
+// InstanceRegistry stores one JSON file per running instance in a state directory shared by every user that runs jobs
+type InstanceRegistry struct {
+	Dir string
+}
+
+// DefaultStateDir is used when no state directory is configured. It is created by root, so a local user cannot
+// create it first and control what the dashboard reads from it.
+func DefaultStateDir() string {
+	return defaultStateDir()
+}
+
+// NewInstanceRegistry returns a registry in dir, or in the default state directory when dir is empty
+func NewInstanceRegistry(dir string) *InstanceRegistry {
+	if dir == "" {
+		dir = filepath.Join(DefaultStateDir(), "instances")
+	}
+	return &InstanceRegistry{Dir: dir}
+}
+
+// EnsureSharedDir creates a directory that jobs running as any user can write to. The directory it is created in
+// is not made shared, and a directory that is a symlink or belongs to another user is refused.
+func EnsureSharedDir(dir string) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(dir, 0755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	if err := checkSharedDir(dir); err != nil {
+		return err
+	}
+	os.Chmod(dir, os.ModeSticky|0777)
+	return nil
+}
+
+// InstanceRegistration removes a registered instance when the run finishes
+type InstanceRegistration struct {
+	registry *InstanceRegistry
+	instance RunningInstance
+	mu       sync.Mutex
+	removed  bool
+}
+
+// Register records a running instance. The directory is created sticky and world-writable, like /tmp,
+// so jobs running as different users can each register without being able to remove each other's entries.
+// Entries are only trusted when the file belongs to the user the process runs as.
+func (r *InstanceRegistry) Register(instance RunningInstance) (*InstanceRegistration, error) {
+	if err := EnsureSharedDir(r.Dir); err != nil {
+		return nil, fmt.Errorf("cannot create instance registry %s: %v", r.Dir, err)
+	}
+
+	registration := &InstanceRegistration{registry: r, instance: instance}
+	if err := r.write(instance); err != nil {
+		return nil, err
+	}
+	return registration, nil
+}
+
+// Instance returns the instance as last recorded
+func (reg *InstanceRegistration) Instance() RunningInstance {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	return reg.instance
+}
+
+// Remove deletes the instance from the registry
+func (reg *InstanceRegistration) Remove() {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if !reg.removed {
+		os.Remove(reg.registry.path(reg.instance.PID))
+		reg.removed = true
+	}
+}
+
+func (r *InstanceRegistry) path(pid int) string {
+	return filepath.Join(r.Dir, fmt.Sprintf("%d.json", pid))
+}
+
+func (r *InstanceRegistry) killedPath(pid int) string {
+	return filepath.Join(r.Dir, fmt.Sprintf("%d.killed", pid))
+}
+
+// killMarker records who stopped an instance. It names the `cronitor exec` process so a marker left
+// behind for a recycled pid is not mistaken for a kill of a later run.
+type killMarker struct {
+	ExecPID  int    `json:"exec_pid"`
+	KilledBy string `json:"killed_by"`
+}
+
+// MarkKilled records that the instance is being stopped on request. The run is then reported as failed
+// by whoever stopped it, and `cronitor exec` does not report it again. The mark replaces whatever is at
+// its path, so a file or symlink planted there by another user is neither followed nor kept.
+func (r *InstanceRegistry) MarkKilled(instance RunningInstance, killedBy string) error {
+	data, err := json.Marshal(killMarker{ExecPID: instance.ExecPID, KilledBy: killedBy})
+	if err != nil {
+		return err
+	}
+	return r.replace(instance.PID, r.killedPath(instance.PID), data)
+}
+
+// ClearKilled removes the mark left by MarkKilled, used when the instance could not be stopped
+func (r *InstanceRegistry) ClearKilled(pid int) {
+	os.Remove(r.killedPath(pid))
+}
+
+// WasKilled reports whether the instance was marked as killed by root or by the user it runs as
+func (reg *InstanceRegistration) WasKilled() bool {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	path := reg.registry.killedPath(reg.instance.PID)
+	info, err := os.Lstat(path)
+	if err != nil || !info.Mode().IsRegular() || !ownedByUser(info, reg.instance.User) {
+		return false
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var marker killMarker
+	return json.Unmarshal(data, &marker) == nil && marker.ExecPID == reg.instance.ExecPID
+}
+
+// write replaces the instance file atomically so readers never see a partial record
+func (r *InstanceRegistry) write(instance RunningInstance) error {
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return err
+	}
+	return r.replace(instance.PID, r.path(instance.PID), data)
+}
+
+// replace writes a new file in the registry and renames it over path. The rename replaces a symlink
+// at path rather than writing through it.
+func (r *InstanceRegistry) replace(pid int, path string, data []byte) error {
+	tempFile, err := ioutil.TempFile(r.Dir, fmt.Sprintf(".%d-*.tmp", pid))
+	if err != nil {
+		return fmt.Errorf("cannot write to instance registry %s: %v", r.Dir, err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Chmod(0644); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), path)
+}
+
+// List returns the instances that are still running, oldest first. Entries left behind by a run that
+// was killed before it could clean up are removed when possible and skipped otherwise. Entries written by a
+// user other than the one the process runs as are skipped, so a user cannot register someone else's process.
+func (r *InstanceRegistry) List() ([]RunningInstance, error) {
+	entries, err := ioutil.ReadDir(r.Dir)
+	if os.IsNotExist(err) {
+		return []RunningInstance{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	instances := []RunningInstance{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, ".killed") {
+			// Kill marks are read by `cronitor exec` before it removes its entry, so marks without one are spent
+			if _, err := os.Stat(filepath.Join(r.Dir, strings.TrimSuffix(name, ".killed")+".json")); os.IsNotExist(err) {
+				os.Remove(filepath.Join(r.Dir, name))
+			}
+			continue
+		}
+		if !strings.HasSuffix(name, ".json") || strings.HasPrefix(name, ".") || !entry.Mode().IsRegular() {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimSuffix(name, ".json")); err != nil {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(r.Dir, name))
+		if err != nil {
+			continue
+		}
+
+		var instance RunningInstance
+		if err := json.Unmarshal(data, &instance); err != nil {
+			continue
+		}
+
+		if !instance.IsRunning() {
+			os.Remove(filepath.Join(r.Dir, name))
+			continue
+		}
+		if !ownedByProcessOwner(entry, instance.PID) {
+			continue
+		}
+		instances = append(instances, instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Started.Before(instances[j].Started) })
+	return instances, nil
+}
+
+// Find returns the running instance whose pid or process group matches
+func (r *InstanceRegistry) Find(pid int) (*RunningInstance, error) {
+	instances, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		if instance.PID == pid || instance.PGID == pid {
+			return &instance, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsRunning reports whether both the job and the `cronitor exec` process that started it are still alive.
+// Requiring both guards against a recycled PID being mistaken for the job.
+func (i RunningInstance) IsRunning() bool {
+	if !processIsAlive(i.PID) {
+		return false
+	}
+	return i.ExecPID == 0 || processIsAlive(i.ExecPID)
+}
+
+// HasValidProcessGroup reports whether the recorded process group is the one the process is actually in, and
+// is safe to signal as a whole
+func (i RunningInstance) HasValidProcessGroup() bool {
+	return i.PGID > 1 && i.PGID == processGroupID(i.PID)
+}
+
+// ProcessGroupID returns the process group of a running process, or the pid itself where groups are not supported
+func ProcessGroupID(pid int) int {
+	return processGroupID(pid)
+}
@@ -0,0 +1,135 @@
+package lib
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInstanceRegistryLifecycle(t *testing.T) {
+	registry := NewInstanceRegistry(filepath.Join(t.TempDir(), "instances"))
+
+	registration, err := registry.Register(RunningInstance{
+		PID:     os.Getpid(),
+		PGID:    ProcessGroupID(os.Getpid()),
+		ExecPID: os.Getpid(),
+		Code:    "abc123",
+		Series:  "1700000000.000",
+		Command: "/usr/bin/backup",
+		Started: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instances, err := registry.List()
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("expected one registered instance, got %v (%v)", instances, err)
+	}
+	if instances[0].Code != "abc123" {
+		t.Errorf("unexpected instance %+v", instances[0])
+	}
+
+	if found, _ := registry.Find(os.Getpid()); found == nil || found.Series != "1700000000.000" {
+		t.Errorf("expected to find the instance by pid, got %+v", found)
+	}
+
+	registration.Remove()
+	if instances, _ := registry.List(); len(instances) != 0 {
+		t.Errorf("expected the registry to be empty after the run finished, got %v", instances)
+	}
+}
+
+func TestInstanceRegistryPrunesExitedRuns(t *testing.T) {
+	registry := NewInstanceRegistry(t.TempDir())
+
+	finished := exec.Command("true")
+	if err := finished.Run(); err != nil {
+		t.Skip("cannot start a process to test with")
+	}
+
+	if _, err := registry.Register(RunningInstance{PID: finished.Process.Pid, Code: "gone", Started: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	instances, err := registry.List()
+	if err != nil || len(instances) != 0 {
+		t.Errorf("expected a run whose process exited to be skipped, got %v (%v)", instances, err)
+	}
+	if _, err := os.Stat(registry.path(finished.Process.Pid)); !os.IsNotExist(err) {
+		t.Error("expected the stale entry to be removed")
+	}
+}
+
+func TestInstanceRegistryKillMark(t *testing.T) {
+	registry := NewInstanceRegistry(filepath.Join(t.TempDir(), "instances"))
+	instance := RunningInstance{PID: os.Getpid(), ExecPID: os.Getpid(), Code: "abc123", User: CurrentUsername(), Started: time.Now()}
+	registration, err := registry.Register(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if registration.WasKilled() {
+		t.Error("expected an instance that was not killed to be reported normally")
+	}
+
+	// A mark left for an earlier run with the same pid is ignored
+	registry.MarkKilled(RunningInstance{PID: instance.PID, ExecPID: instance.ExecPID + 1}, "ops")
+	if registration.WasKilled() {
+		t.Error("expected a mark for another run to be ignored")
+	}
+
+	// A symlink planted at the mark's path is replaced, not written through
+	target := filepath.Join(t.TempDir(), "target")
+	os.WriteFile(target, []byte("keep"), 0644)
+	os.Remove(registry.killedPath(instance.PID))
+	if err := os.Symlink(target, registry.killedPath(instance.PID)); err != nil {
+		t.Skip("cannot create a symlink to test with")
+	}
+
+	registry.MarkKilled(instance, "ops")
+	if !registration.WasKilled() {
+		t.Error("expected the instance to be marked as killed")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "keep" {
+		t.Errorf("expected the symlink target to be left alone, got %q", data)
+	}
+
+	registration.Remove()
+	registry.List()
+	if _, err := os.Stat(registry.killedPath(instance.PID)); !os.IsNotExist(err) {
+		t.Error("expected the mark to be removed once the instance is gone")
+	}
+}
+
+func TestMissingInstanceRegistryIsEmpty(t *testing.T) {
+	registry := NewInstanceRegistry(filepath.Join(t.TempDir(), "missing"))
+	if instances, err := registry.List(); err != nil || len(instances) != 0 {
+		t.Errorf("expected no instances, got %v (%v)", instances, err)
+	}
+}
+
+func TestInstanceProcessGroupMustMatchProcess(t *testing.T) {
+	pid := os.Getpid()
+	if ProcessGroupID(pid) > 1 && !(RunningInstance{PID: pid, PGID: ProcessGroupID(pid)}).HasValidProcessGroup() {
+		t.Error("expected the process's own group to be valid")
+	}
+	for _, pgid := range []int{0, 1, ProcessGroupID(pid) + 1} {
+		if (RunningInstance{PID: pid, PGID: pgid}).HasValidProcessGroup() {
+			t.Errorf("expected process group %d not to be trusted", pgid)
+		}
+	}
+}
+
+func TestEnsureSharedDirRefusesSymlink(t *testing.T) {
+	target := t.TempDir()
+	link := filepath.Join(t.TempDir(), "instances")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip("cannot create symlinks")
+	}
+	if err := EnsureSharedDir(link); err == nil {
+		t.Error("expected a symlinked state directory to be refused")
+	}
+}
@@ -0,0 +1,97 @@
+//go:build !windows
+// +build !windows
+
+package lib
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+func defaultStateDir() string {
+	return "/var/lib/cronitor"
+}
+
+// processIsAlive sends signal 0, which checks for the process without affecting it. EPERM means
+// the process exists but belongs to another user.
+func processIsAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+func processGroupID(pid int) int {
+	if pgid, err := syscall.Getpgid(pid); err == nil {
+		return pgid
+	}
+	return pid
+}
+
+// checkSharedDir refuses a directory that is a symlink or that belongs to a user other than root or this one
+func checkSharedDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if owner, ok := fileOwner(info); !ok || (owner != 0 && owner != uint32(os.Getuid())) {
+		return fmt.Errorf("%s belongs to another user", dir)
+	}
+	return nil
+}
+
+func fileOwner(info os.FileInfo) (uint32, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return stat.Uid, true
+}
+
+// processOwner returns the user a process runs as, from /proc where there is one and ps otherwise
+func processOwner(pid int) (uint32, bool) {
+	if info, err := os.Stat(fmt.Sprintf("/proc/%d", pid)); err == nil {
+		return fileOwner(info)
+	}
+	output, err := exec.Command("ps", "-o", "uid=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return 0, false
+	}
+	uid, err := strconv.ParseUint(strings.TrimSpace(string(output)), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(uid), true
+}
+
+// ownedByProcessOwner reports whether a file was written by the user the process runs as
+func ownedByProcessOwner(info os.FileInfo, pid int) bool {
+	owner, ok := fileOwner(info)
+	if !ok {
+		return false
+	}
+	uid, ok := processOwner(pid)
+	return ok && owner == uid
+}
+
+// ownedByUser reports whether a file was written by this user or by root
+func ownedByUser(info os.FileInfo, username string) bool {
+	owner, ok := fileOwner(info)
+	if !ok {
+		return false
+	}
+	if owner == 0 {
+		return true
+	}
+	u, err := user.LookupId(strconv.FormatUint(uint64(owner), 10))
+	return err == nil && username != "" && u.Username == username
+}
@@ -0,0 +1,56 @@
+//go:build windows
+// +build windows
+
+package lib
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func defaultStateDir() string {
+	if programData := os.Getenv("ProgramData"); programData != "" {
+		return filepath.Join(programData, "Cronitor")
+	}
+	return filepath.Join(os.TempDir(), "cronitor")
+}
+
+// processIsAlive relies on FindProcess opening a handle, which fails once the process has exited
+func processIsAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
+
+// Windows has no process groups; the pid stands in for the group
+func processGroupID(pid int) int {
+	return pid
+}
+
+// Directories under ProgramData inherit their permissions, so there is no owner to check
+func checkSharedDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// File ownership is not exposed through os.FileInfo on Windows, so every entry is trusted
+func ownedByProcessOwner(info os.FileInfo, pid int) bool {
+	return true
+}
+
+func ownedByUser(info os.FileInfo, username string) bool {
+	return true
+}
@@ -0,0 +1,119 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LastRun is the outcome of the most recent run of a monitored job, kept by `cronitor exec` in the state
+// directory so the metrics exporter can read it without the dashboard's run history.
+type LastRun struct {
+	Code          string    `json:"code"`
+	JobKey        string    `json:"job_key,omitempty"`
+	User          string    `json:"user,omitempty"`
+	StartedAt     time.Time `json:"started_at"`
+	EndedAt       time.Time `json:"ended_at"`
+	ExitCode      int       `json:"exit_code"`
+	LastSuccessAt time.Time `json:"last_success_at,omitempty"`
+}
+
+// Duration is how long the run took
+func (l LastRun) Duration() time.Duration {
+	return l.EndedAt.Sub(l.StartedAt)
+}
+
+func lastRunPath(dir string, code string) (string, error) {
+	if code == "" || strings.ContainsAny(code, `/\`) || strings.HasPrefix(code, ".") {
+		return "", fmt.Errorf("invalid monitor code %q", code)
+	}
+	return filepath.Join(dir, code+".json"), nil
+}
+
+// RecordLastRun replaces the last run of the job, carrying the last successful run forward from the previous one
+func RecordLastRun(dir string, record RunRecord) error {
+	path, err := lastRunPath(dir, record.Code)
+	if err != nil {
+		return err
+	}
+
+	lastRun := LastRun{
+		Code:      record.Code,
+		JobKey:    record.JobKey,
+		User:      record.User,
+		StartedAt: record.StartedAt,
+		EndedAt:   record.EndedAt,
+		ExitCode:  record.ExitCode,
+	}
+	if record.ExitCode == 0 {
+		lastRun.LastSuccessAt = record.EndedAt
+	} else if data, err := ioutil.ReadFile(path); err == nil {
+		var previous LastRun
+		if json.Unmarshal(data, &previous) == nil && previous.User == record.User {
+			lastRun.LastSuccessAt = previous.LastSuccessAt
+		}
+	}
+
+	data, err := json.Marshal(lastRun)
+	if err != nil {
+		return err
+	}
+
+	if err := EnsureSharedDir(dir); err != nil {
+		return fmt.Errorf("cannot create %s: %v", dir, err)
+	}
+
+	tempFile, err := ioutil.TempFile(dir, "."+record.Code+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot write last run to %s: %v", dir, err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Chmod(0644); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), path)
+}
+
+// ReadLastRuns returns the last run of every job, by monitor code. The directory is shared by every user that
+// runs jobs, so a last run is only read when the file was written by the user it is recorded for, or by root.
+func ReadLastRuns(dir string) (map[string]LastRun, error) {
+	lastRuns := make(map[string]LastRun)
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return lastRuns, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".json") || strings.HasPrefix(name, ".") || !entry.Mode().IsRegular() {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+
+		var lastRun LastRun
+		if err := json.Unmarshal(data, &lastRun); err != nil || lastRun.Code == "" || !ownedByUser(entry, lastRun.User) {
+			continue
+		}
+		lastRuns[lastRun.Code] = lastRun
+	}
+	return lastRuns, nil
+}
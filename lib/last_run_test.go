@@ -0,0 +1,49 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRecordLastRunKeepsLastSuccess(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	RecordLastRun(dir, RunRecord{Code: "abc123", StartedAt: start, EndedAt: start.Add(5 * time.Second), User: CurrentUsername(), ExitCode: 0})
+	RecordLastRun(dir, RunRecord{Code: "abc123", StartedAt: start.Add(time.Minute), EndedAt: start.Add(time.Minute + 2*time.Second), User: CurrentUsername(), ExitCode: 2})
+
+	lastRuns, err := ReadLastRuns(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastRun := lastRuns["abc123"]
+	if lastRun.ExitCode != 2 || lastRun.Duration() != 2*time.Second {
+		t.Errorf("expected the failed run to be the last run, got %+v", lastRun)
+	}
+	if !lastRun.LastSuccessAt.Equal(start.Add(5 * time.Second)) {
+		t.Errorf("expected the last success to be carried forward, got %v", lastRun.LastSuccessAt)
+	}
+
+	if err := RecordLastRun(dir, RunRecord{Code: "../escape"}); err == nil {
+		t.Error("expected monitor codes with path separators to be rejected")
+	}
+}
+
+func TestReadLastRunsSkipsRunsOfAnotherUser(t *testing.T) {
+	if os.Getuid() != 0 || runtime.GOOS == "windows" {
+		t.Skip("changing the owner of a last run file requires root")
+	}
+	dir := t.TempDir()
+	RecordLastRun(dir, RunRecord{Code: "abc123", User: "root", StartedAt: time.Now(), EndedAt: time.Now()})
+	if err := os.Chown(filepath.Join(dir, "abc123.json"), 65534, 65534); err != nil {
+		t.Skip("cannot change the owner of the last run file")
+	}
+
+	if lastRuns, _ := ReadLastRuns(dir); len(lastRuns) != 0 {
+		t.Errorf("expected a last run written by another user to be skipped, got %+v", lastRuns)
+	}
+}
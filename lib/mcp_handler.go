@@ -25,6 +25,14 @@ type MCPInstance struct {
 	URL      string `json:"url"`
 	Username string `json:"username"`
 	Password string `json:"password"`
+	ReadOnly bool   `json:"read_only,omitempty"`
+}
+
+// MCPCaller is who an MCP tool call acts for. ReadOnly callers can only use the tools that read.
+type MCPCaller struct {
+	Username string
+	ClientIP string
+	ReadOnly bool
 }
 
 // CronitorMCPHandler handles MCP requests for Cronitor
@@ -33,13 +41,24 @@ type CronitorMCPHandler struct {
 	apiURL       string
 	username     string
 	password     string
+	readOnly     bool
+	local        bool
+	client       *http.Client
+	api          *APIClient
 	systemPrompt string
+
+	subscriptions *mcpSubscriptions
+	pollInterval  time.Duration
+
+	auditLog *AuditLog
+	caller   func(ctx context.Context) MCPCaller
 }
 
 // NewCronitorMCPHandler creates a new MCP handler for Cronitor
 func NewCronitorMCPHandler(instanceName string) *CronitorMCPHandler {
 	handler := &CronitorMCPHandler{
 		instanceName: instanceName,
+		api:          NewAPIClient(false, nil),
 	}
 
 	// Load instance configuration
@@ -51,6 +70,53 @@ func NewCronitorMCPHandler(instanceName string) *CronitorMCPHandler {
 	return handler
 }
 
+// NewLocalMCPHandler creates an MCP handler for the crontabs on this host. Requests for jobs and crontabs are served
+// in-process by the dashboard's API handlers through the given transport, so no dashboard needs to be running.
+func NewLocalMCPHandler(dashboard http.RoundTripper, readOnly bool) *CronitorMCPHandler {
+	handler := &CronitorMCPHandler{
+		instanceName: "local",
+		apiURL:       "http://localhost",
+		readOnly:     readOnly,
+		local:        true,
+		client:       &http.Client{Transport: dashboard},
+		api:          NewAPIClient(false, nil),
+	}
+	handler.setSystemPrompt()
+	return handler
+}
+
+// IsRemoteMCPInstance reports whether an instance is a dashboard reached over HTTP rather than this host
+func IsRemoteMCPInstance(instanceName string) bool {
+	if viper.IsSet(fmt.Sprintf("mcp_instances.%s", instanceName)) {
+		return true
+	}
+	return (instanceName == "default" || instanceName == "") && os.Getenv("CRONITOR_DASH_URL") != ""
+}
+
+// RestrictToReadOnly leaves out the tools that make changes, whatever the instance allows
+func (h *CronitorMCPHandler) RestrictToReadOnly() {
+	h.readOnly = true
+}
+
+// SetAuditLog records the changes the Cronitor API tools make in the audit log. Changes to jobs and crontabs are
+// recorded by the dashboard that makes them.
+func (h *CronitorMCPHandler) SetAuditLog(log *AuditLog) {
+	h.auditLog = log
+}
+
+// SetCaller tells the handler who each tool call acts for, so that the tools that make changes can be refused to
+// callers who may only read. Without it every call acts as the user running the server.
+func (h *CronitorMCPHandler) SetCaller(caller func(ctx context.Context) MCPCaller) {
+	h.caller = caller
+}
+
+func (h *CronitorMCPHandler) callerFor(ctx context.Context) MCPCaller {
+	if h.caller == nil {
+		return MCPCaller{}
+	}
+	return h.caller(ctx)
+}
+
 func (h *CronitorMCPHandler) loadInstanceConfig(instanceName string) {
 	// Try to load from instances configuration first
 	instanceKey := fmt.Sprintf("mcp_instances.%s", instanceName)
@@ -62,6 +128,7 @@ func (h *CronitorMCPHandler) loadInstanceConfig(instanceName string) {
 		h.apiURL = instanceConfig["url"]
 		h.username = instanceConfig["username"]
 		h.password = instanceConfig["password"]
+		h.readOnly = instanceConfig["read_only"] == "true"
 
 		// Set default URL if not specified
 		if h.apiURL == "" {
@@ -76,6 +143,7 @@ func (h *CronitorMCPHandler) loadInstanceConfig(instanceName string) {
 		// from the main config (already loaded by viper)
 		h.username = viper.GetString("CRONITOR_DASH_USER")
 		h.password = viper.GetString("CRONITOR_DASH_PASS")
+		h.readOnly = viper.GetBool("CRONITOR_MCP_READ_ONLY")
 
 		// Default URL is localhost:9000
 		h.apiURL = "http://localhost:9000"
@@ -91,6 +159,7 @@ func (h *CronitorMCPHandler) loadInstanceConfig(instanceName string) {
 	h.apiURL = "http://localhost:9000"
 	h.username = viper.GetString("CRONITOR_DASH_USER")
 	h.password = viper.GetString("CRONITOR_DASH_PASS")
+	h.readOnly = viper.GetBool("CRONITOR_MCP_READ_ONLY")
 }
 
 // setSystemPrompt sets instance-specific system prompts
@@ -140,6 +209,13 @@ When using Cronitor MCP tools:
 - Always confirm which instance before making changes
 - List existing jobs before creating new ones to avoid duplicates
 
+## Monitoring and Incidents
+- Use list_failing_monitors and get_monitor_activity to investigate alerts before suggesting changes
+- Do NOT pause monitors, open or resolve issues, or create maintenance windows without explicit confirmation from the user
+- Prefer pausing a monitor for a number of hours over pausing it indefinitely
+- Some instances are read-only: tools that make changes are not available on them
+- To keep watching a monitor, issue, crontab line or job's output, subscribe to its resource, e.g. cronitor://monitor/{key}
+
 ## REMINDERS
 - Users will often have multiple connected Cronitor MCP servers
 - Use "default" instance for local development
@@ -185,7 +261,7 @@ func (h *CronitorMCPHandler) RegisterTools(s *server.MCPServer) error {
 			mcp.Description("User to run the job as"),
 		),
 	)
-	s.AddTool(tool, h.handleCreateCronjob)
+	h.registerTool(s, tool, h.handleCreateCronjob, true)
 
 	// List Cronjobs Tool
 	tool = mcp.NewTool(
@@ -195,7 +271,7 @@ func (h *CronitorMCPHandler) RegisterTools(s *server.MCPServer) error {
 			mcp.Description("Filter by name or command"),
 		),
 	)
-	s.AddTool(tool, h.handleListCronjobs)
+	h.registerTool(s, tool, h.handleListCronjobs, false)
 
 	// Update Cronjob Tool
 	tool = mcp.NewTool(
@@ -221,7 +297,7 @@ func (h *CronitorMCPHandler) RegisterTools(s *server.MCPServer) error {
 			mcp.Description("Enable/disable monitoring"),
 		),
 	)
-	s.AddTool(tool, h.handleUpdateCronjob)
+	h.registerTool(s, tool, h.handleUpdateCronjob, true)
 
 	// Delete Cronjob Tool
 	tool = mcp.NewTool(
@@ -232,7 +308,7 @@ func (h *CronitorMCPHandler) RegisterTools(s *server.MCPServer) error {
 			mcp.Description("Job key or identifier"),
 		),
 	)
-	s.AddTool(tool, h.handleDeleteCronjob)
+	h.registerTool(s, tool, h.handleDeleteCronjob, true)
 
 	// Run Job Now Tool
 	tool = mcp.NewTool(
@@ -243,14 +319,16 @@ func (h *CronitorMCPHandler) RegisterTools(s *server.MCPServer) error {
 			mcp.Description("Job key or identifier"),
 		),
 	)
-	s.AddTool(tool, h.handleRunCronjobNow)
+	h.registerTool(s, tool, h.handleRunCronjobNow, true)
 
 	// Get Current Instance Tool
 	tool = mcp.NewTool(
 		"get_cronitor_instance",
 		mcp.WithDescription("Get information about the current Cronitor instance"),
 	)
-	s.AddTool(tool, h.handleGetInstance)
+	h.registerTool(s, tool, h.handleGetInstance, false)
+
+	h.registerMonitoringTools(s)
 
 	return nil
 }
@@ -275,6 +353,8 @@ func (h *CronitorMCPHandler) RegisterResources(s *server.MCPServer) error {
 	)
 	s.AddResource(resource, h.handleJobsResource)
 
+	h.registerResourceTemplates(s)
+
 	return nil
 }
 
@@ -344,7 +424,7 @@ func (h *CronitorMCPHandler) handleCreateCronjob(ctx context.Context, req mcp.Ca
 	}
 
 	// Make API call to dashboard
-	resp, err := h.makeAuthenticatedRequest("POST", h.apiURL+"/api/jobs", jobData)
+	resp, err := h.makeAuthenticatedRequest(ctx, "POST", h.apiURL+"/api/jobs", jobData)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to create job: %v", err)), nil
 	}
@@ -379,7 +459,7 @@ func (h *CronitorMCPHandler) handleListCronjobs(ctx context.Context, req mcp.Cal
 	filter := req.GetString("filter", "")
 
 	// Get jobs from dashboard API
-	resp, err := h.makeAuthenticatedRequest("GET", h.apiURL+"/api/jobs", nil)
+	resp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/jobs", nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to list jobs: %v", err)), nil
 	}
@@ -462,7 +542,7 @@ func (h *CronitorMCPHandler) handleUpdateCronjob(ctx context.Context, req mcp.Ca
 	}
 
 	// Make API call to dashboard
-	_, err = h.makeAuthenticatedRequest("PUT", h.apiURL+"/api/jobs", updateData)
+	_, err = h.makeAuthenticatedRequest(ctx, "PUT", h.apiURL+"/api/jobs", updateData)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to update job: %v", err)), nil
 	}
@@ -484,7 +564,7 @@ func (h *CronitorMCPHandler) handleDeleteCronjob(ctx context.Context, req mcp.Ca
 	}
 
 	// Make API call to dashboard
-	_, err = h.makeAuthenticatedRequest("DELETE", h.apiURL+"/api/jobs", deleteData)
+	_, err = h.makeAuthenticatedRequest(ctx, "DELETE", h.apiURL+"/api/jobs", deleteData)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete job: %v", err)), nil
 	}
@@ -501,7 +581,7 @@ func (h *CronitorMCPHandler) handleRunCronjobNow(ctx context.Context, req mcp.Ca
 	}
 
 	// First, get the job details to find the command
-	jobsResp, err := h.makeAuthenticatedRequest("GET", h.apiURL+"/api/jobs", nil)
+	jobsResp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/jobs", nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to get job details: %v", err)), nil
 	}
@@ -538,7 +618,7 @@ func (h *CronitorMCPHandler) handleRunCronjobNow(ctx context.Context, req mcp.Ca
 	}
 
 	// Make API call to run the job
-	_, err = h.makeAuthenticatedRequest("POST", h.apiURL+"/api/jobs/run", runData)
+	_, err = h.makeAuthenticatedRequest(ctx, "POST", h.apiURL+"/api/jobs/run", runData)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to run job: %v", err)), nil
 	}
@@ -549,15 +629,23 @@ func (h *CronitorMCPHandler) handleRunCronjobNow(ctx context.Context, req mcp.Ca
 }
 
 func (h *CronitorMCPHandler) handleGetInstance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	info := fmt.Sprintf("Connected to Cronitor instance: %s\nAPI URL: %s\n\n%s",
-		h.instanceName, h.apiURL, h.systemPrompt)
+	permissions := "read and write"
+	if h.readOnly {
+		permissions = "read-only"
+	}
+	location := fmt.Sprintf("API URL: %s", h.apiURL)
+	if h.local {
+		location = "Serving the crontabs on this host directly"
+	}
+	info := fmt.Sprintf("Connected to Cronitor instance: %s\n%s\nPermissions: %s\n\n%s",
+		h.instanceName, location, permissions, h.systemPrompt)
 	return mcp.NewToolResultText(info), nil
 }
 
 // Resource Handlers
 
 func (h *CronitorMCPHandler) handleCrontabsResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	resp, err := h.makeAuthenticatedRequest("GET", h.apiURL+"/api/crontabs", nil)
+	resp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/crontabs", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -584,7 +672,7 @@ func (h *CronitorMCPHandler) handleCrontabsResource(ctx context.Context, req mcp
 }
 
 func (h *CronitorMCPHandler) handleJobsResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	resp, err := h.makeAuthenticatedRequest("GET", h.apiURL+"/api/jobs", nil)
+	resp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/jobs", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -621,100 +709,22 @@ var httpClient = &http.Client{
 	}(),
 }
 
-func (h *CronitorMCPHandler) makeAuthenticatedRequest(method, apiURL string, body interface{}) ([]byte, error) {
-	// For state-changing requests, we need to get a CSRF token first
-	if method == "POST" || method == "PUT" || method == "DELETE" {
-		// First, make a GET request to get a CSRF token
-		getReq, err := http.NewRequest("GET", h.apiURL+"/api/settings", nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create CSRF token request: %v", err)
-		}
-
-		// Set auth header for the GET request
-		if h.username != "" && h.password != "" {
-			getReq.SetBasicAuth(h.username, h.password)
-		}
-
-		getResp, err := httpClient.Do(getReq)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get CSRF token: %v", err)
-		}
-		defer getResp.Body.Close()
-
-		// Check for authentication error
-		if getResp.StatusCode == 401 {
-			return nil, fmt.Errorf("authentication failed - check username and password for instance '%s'", h.instanceName)
-		}
-
-		// Read the response body to ensure cookies are set
-		io.ReadAll(getResp.Body)
-
-		// Extract CSRF token from response header
-		csrfToken := getResp.Header.Get("X-CSRF-Token")
-
-		// If no token in header, try to extract from cookies
-		if csrfToken == "" {
-			parsedURL, _ := url.Parse(h.apiURL)
-			for _, cookie := range httpClient.Jar.Cookies(parsedURL) {
-				if cookie.Name == "csrf_token" {
-					csrfToken = cookie.Value
-					break
-				}
-			}
-		}
-
-		if csrfToken == "" {
-			return nil, fmt.Errorf("failed to obtain CSRF token")
-		}
-
-		// Now make the actual request with the CSRF token
-		var reqBody io.Reader
-		if body != nil {
-			jsonBody, err := json.Marshal(body)
-			if err != nil {
-				return nil, err
-			}
-			reqBody = bytes.NewReader(jsonBody)
-		}
-
-		req, err := http.NewRequest(method, apiURL, reqBody)
-		if err != nil {
-			return nil, err
-		}
-
-		// Set auth header
-		if h.username != "" && h.password != "" {
-			req.SetBasicAuth(h.username, h.password)
-		}
-
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
-		}
-
-		// Always add CSRF token to header for state-changing requests
-		req.Header.Set("X-CSRF-Token", csrfToken)
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
+func (h *CronitorMCPHandler) makeAuthenticatedRequest(ctx context.Context, method, apiURL string, body interface{}) ([]byte, error) {
+	client := httpClient
+	if h.client != nil {
+		client = h.client
+	}
 
-		// Read response
-		respBody, err := io.ReadAll(resp.Body)
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-
-		if resp.StatusCode >= 400 {
-			return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
-		}
-
-		return respBody, nil
+		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	// For non-state-changing requests (GET), proceed normally
-	req, err := http.NewRequest(method, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -724,7 +734,24 @@ func (h *CronitorMCPHandler) makeAuthenticatedRequest(method, apiURL string, bod
 		req.SetBasicAuth(h.username, h.password)
 	}
 
-	resp, err := httpClient.Do(req)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// State-changing requests to a dashboard need a CSRF token. Requests served in-process skip the
+	// dashboard's middleware, so they don't.
+	if method == "POST" || method == "PUT" || method == "DELETE" {
+		if !h.local {
+			csrfToken, err := h.fetchCSRFToken(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("X-CSRF-Token", csrfToken)
+		}
+		req.Header.Set(AuditClientHeader, "mcp")
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -743,6 +770,52 @@ func (h *CronitorMCPHandler) makeAuthenticatedRequest(method, apiURL string, bod
 	return respBody, nil
 }
 
+// fetchCSRFToken signs in to the dashboard and returns the CSRF token it hands out
+func (h *CronitorMCPHandler) fetchCSRFToken(ctx context.Context, client *http.Client) (string, error) {
+	getReq, err := http.NewRequestWithContext(ctx, "GET", h.apiURL+"/api/settings", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create CSRF token request: %v", err)
+	}
+
+	// Set auth header for the GET request
+	if h.username != "" && h.password != "" {
+		getReq.SetBasicAuth(h.username, h.password)
+	}
+
+	getResp, err := client.Do(getReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to get CSRF token: %v", err)
+	}
+	defer getResp.Body.Close()
+
+	// Check for authentication error
+	if getResp.StatusCode == 401 {
+		return "", fmt.Errorf("authentication failed - check username and password for instance '%s'", h.instanceName)
+	}
+
+	// Read the response body to ensure cookies are set
+	io.ReadAll(getResp.Body)
+
+	// Extract CSRF token from response header
+	csrfToken := getResp.Header.Get("X-CSRF-Token")
+
+	// If no token in header, try to extract from cookies
+	if csrfToken == "" && client.Jar != nil {
+		parsedURL, _ := url.Parse(h.apiURL)
+		for _, cookie := range client.Jar.Cookies(parsedURL) {
+			if cookie.Name == "csrf_token" {
+				csrfToken = cookie.Value
+				break
+			}
+		}
+	}
+
+	if csrfToken == "" {
+		return "", fmt.Errorf("failed to obtain CSRF token")
+	}
+	return csrfToken, nil
+}
+
 // ParseSchedule converts human-readable schedules to cron expressions
 func ParseSchedule(input string) string {
 	input = strings.TrimSpace(input)
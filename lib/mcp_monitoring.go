@@ -0,0 +1,505 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerTool adds a tool to the server. Tools that change anything are left out on read-only instances,
+// so an assistant connected to one cannot call them at all, and are refused to read-only callers on the others.
+func (h *CronitorMCPHandler) registerTool(s *server.MCPServer, tool mcp.Tool, handler server.ToolHandlerFunc, writes bool) {
+	if writes && h.readOnly {
+		return
+	}
+	if writes {
+		handler = h.requireWriteAccess(tool.Name, handler)
+	}
+	s.AddTool(tool, handler)
+}
+
+// requireWriteAccess refuses a tool call from a caller who may only read. The Cronitor API tools act with the
+// server's API key, so this is the only check between a read-only account and its monitors and issues.
+func (h *CronitorMCPHandler) requireWriteAccess(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if caller := h.callerFor(ctx); caller.ReadOnly {
+			return mcp.NewToolResultError(fmt.Sprintf("Forbidden: %s makes changes, and the account %s can only read", name, caller.Username)), nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// registerMonitoringTools registers the tools backed by the Cronitor API, used for on-call work
+func (h *CronitorMCPHandler) registerMonitoringTools(s *server.MCPServer) {
+	h.registerTool(s, mcp.NewTool(
+		"list_failing_monitors",
+		mcp.WithDescription("List Cronitor monitors that are currently failing"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("type",
+			mcp.Description("Only monitors of this type"),
+			mcp.Enum("job", "check", "heartbeat", "site"),
+		),
+		mcp.WithString("tag",
+			mcp.Description("Only monitors with this tag"),
+		),
+		mcp.WithString("group",
+			mcp.Description("Only monitors in this group"),
+		),
+		mcp.WithString("env",
+			mcp.Description("Environment key"),
+		),
+	), h.handleListFailingMonitors, false)
+
+	h.registerTool(s, mcp.NewTool(
+		"get_monitor_activity",
+		mcp.WithDescription("Get a monitor's recent invocations and telemetry events, including log messages sent with each ping"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("key",
+			mcp.Required(),
+			mcp.Description("Monitor key"),
+		),
+		mcp.WithString("env",
+			mcp.Description("Environment key"),
+		),
+	), h.handleGetMonitorActivity, false)
+
+	h.registerTool(s, mcp.NewTool(
+		"list_issues",
+		mcp.WithDescription("List Cronitor issues (incidents)"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("state",
+			mcp.Description("Only issues in this state"),
+			mcp.Enum("unresolved", "investigating", "identified", "monitoring", "resolved"),
+		),
+		mcp.WithString("severity",
+			mcp.Description("Only issues with this severity"),
+		),
+		mcp.WithString("monitor",
+			mcp.Description("Only issues for this monitor key"),
+		),
+		mcp.WithString("time",
+			mcp.Description("Time range, e.g. 24h, 7d"),
+		),
+	), h.handleListIssues, false)
+
+	h.registerTool(s, mcp.NewTool(
+		"get_metric_aggregates",
+		mcp.WithDescription("Get aggregated metrics such as mean duration, success rate, total runs and uptime"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("monitor",
+			mcp.Description("Monitor key, or several separated by commas"),
+		),
+		mcp.WithString("group",
+			mcp.Description("Group key"),
+		),
+		mcp.WithString("tag",
+			mcp.Description("Tag name"),
+		),
+		mcp.WithString("time",
+			mcp.Description("Time range, e.g. 24h, 7d, 30d (default 24h)"),
+		),
+		mcp.WithString("env",
+			mcp.Description("Environment key"),
+		),
+	), h.handleGetMetricAggregates, false)
+
+	h.registerTool(s, mcp.NewTool(
+		"pause_monitor",
+		mcp.WithDescription("Pause alerts for a monitor, indefinitely or for a number of hours"),
+		mcp.WithString("key",
+			mcp.Required(),
+			mcp.Description("Monitor key"),
+		),
+		mcp.WithNumber("hours",
+			mcp.Description("Hours to pause for; omit to pause until unpaused"),
+		),
+	), h.handlePauseMonitor, true)
+
+	h.registerTool(s, mcp.NewTool(
+		"unpause_monitor",
+		mcp.WithDescription("Resume alerts for a paused monitor"),
+		mcp.WithString("key",
+			mcp.Required(),
+			mcp.Description("Monitor key"),
+		),
+	), h.handleUnpauseMonitor, true)
+
+	h.registerTool(s, mcp.NewTool(
+		"create_issue",
+		mcp.WithDescription("Open a Cronitor issue (incident)"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Issue title"),
+		),
+		mcp.WithString("severity",
+			mcp.Description("Issue severity (default outage)"),
+			mcp.Enum("missing_data", "operational", "maintenance", "degraded_performance", "minor_outage", "outage"),
+		),
+		mcp.WithString("state",
+			mcp.Description("Issue state (default unresolved)"),
+			mcp.Enum("unresolved", "investigating", "identified", "monitoring"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Details of the issue"),
+		),
+		mcp.WithString("monitors",
+			mcp.Description("Affected monitor keys, separated by commas"),
+		),
+	), h.handleCreateIssue, true)
+
+	h.registerTool(s, mcp.NewTool(
+		"resolve_issue",
+		mcp.WithDescription("Mark a Cronitor issue as resolved"),
+		mcp.WithString("key",
+			mcp.Required(),
+			mcp.Description("Issue key"),
+		),
+	), h.handleResolveIssue, true)
+
+	h.registerTool(s, mcp.NewTool(
+		"create_maintenance_window",
+		mcp.WithDescription("Schedule a maintenance window, during which alerts for the affected monitors are suppressed"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the maintenance window"),
+		),
+		mcp.WithString("start",
+			mcp.Required(),
+			mcp.Description("Start time in ISO 8601 format, e.g. 2024-01-15T02:00:00Z"),
+		),
+		mcp.WithString("end",
+			mcp.Required(),
+			mcp.Description("End time in ISO 8601 format"),
+		),
+		mcp.WithString("monitors",
+			mcp.Description("Affected monitor keys, separated by commas"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Details shown on status pages"),
+		),
+	), h.handleCreateMaintenanceWindow, true)
+}
+
+// apiRequest calls the Cronitor API and returns the response body, or an error suitable for a tool result
+func (h *CronitorMCPHandler) apiRequest(method, endpoint string, body interface{}, params map[string]string) ([]byte, error) {
+	if h.api.ApiKey == "" {
+		return nil, fmt.Errorf("no Cronitor API key is configured, run 'cronitor configure --api-key <key>'")
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := h.api.Request(method, endpoint, data, params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsNotFound() {
+		return nil, fmt.Errorf("not found")
+	}
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, resp.ParseError())
+	}
+	return resp.Body, nil
+}
+
+// auditAPIChange records a change made through the Cronitor API in the audit log
+func (h *CronitorMCPHandler) auditAPIChange(ctx context.Context, action string, key string, request interface{}, err error) {
+	if h.auditLog == nil {
+		return
+	}
+
+	caller := h.callerFor(ctx)
+	entry := AuditEntry{
+		User:     caller.Username,
+		ClientIP: caller.ClientIP,
+		Source:   "mcp",
+		Action:   action,
+		Key:      key,
+		Status:   http.StatusOK,
+	}
+	if request != nil {
+		entry.Request, _ = json.Marshal(request)
+	}
+	if err != nil {
+		entry.Status = http.StatusBadGateway
+	}
+	if err := h.auditLog.Append(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write audit log: %v\n", err)
+	}
+}
+
+// splitKeys turns "a, b,c" into [a b c]
+func splitKeys(value string) []string {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func prettyJSON(data []byte) string {
+	return (&APIResponse{Body: data}).FormatJSON()
+}
+
+func (h *CronitorMCPHandler) handleListFailingMonitors(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := map[string]string{
+		"state": "failing",
+		"type":  req.GetString("type", ""),
+		"tag":   req.GetString("tag", ""),
+		"group": req.GetString("group", ""),
+		"env":   req.GetString("env", ""),
+	}
+
+	resp, err := h.apiRequest("GET", "/monitors", nil, params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list monitors: %v", err)), nil
+	}
+
+	var result struct {
+		Monitors []struct {
+			Key     string `json:"key"`
+			Name    string `json:"name"`
+			Type    string `json:"type"`
+			Passing bool   `json:"passing"`
+			Paused  bool   `json:"paused"`
+			Group   string `json:"group"`
+		} `json:"monitors"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return mcp.NewToolResultError("Failed to parse monitors"), nil
+	}
+
+	var output strings.Builder
+	count := 0
+	for _, monitor := range result.Monitors {
+		// The state filter is applied by the API; this guards against an API that ignores it
+		if monitor.Passing {
+			continue
+		}
+		count++
+		status := "failing"
+		if monitor.Paused {
+			status = "failing, paused"
+		}
+		output.WriteString(fmt.Sprintf("- %s (%s) [%s, key: %s", monitor.Name, monitor.Type, status, monitor.Key))
+		if monitor.Group != "" {
+			output.WriteString(fmt.Sprintf(", group: %s", monitor.Group))
+		}
+		output.WriteString("]\n")
+	}
+
+	if count == 0 {
+		return mcp.NewToolResultText("No failing monitors"), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Found %d failing monitors:\n\n%s", count, output.String())), nil
+}
+
+func (h *CronitorMCPHandler) handleGetMonitorActivity(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key, err := req.RequireString("key")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid key: %v", err)), nil
+	}
+
+	params := map[string]string{
+		"withInvocations": "true",
+		"withEvents":      "true",
+		"env":             req.GetString("env", ""),
+	}
+	resp, err := h.apiRequest("GET", fmt.Sprintf("/monitors/%s", url.PathEscape(key)), nil, params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get monitor '%s': %v", key, err)), nil
+	}
+	return mcp.NewToolResultText(prettyJSON(resp)), nil
+}
+
+func (h *CronitorMCPHandler) handleListIssues(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := map[string]string{
+		"state":    req.GetString("state", ""),
+		"severity": req.GetString("severity", ""),
+		"job":      req.GetString("monitor", ""),
+		"time":     req.GetString("time", ""),
+	}
+
+	resp, err := h.apiRequest("GET", "/issues", nil, params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list issues: %v", err)), nil
+	}
+
+	var result struct {
+		Data []struct {
+			Key      string `json:"key"`
+			Name     string `json:"name"`
+			State    string `json:"state"`
+			Severity string `json:"severity"`
+			Started  string `json:"started"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return mcp.NewToolResultError("Failed to parse issues"), nil
+	}
+	if len(result.Data) == 0 {
+		return mcp.NewToolResultText("No issues found"), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Found %d issues:\n\n", len(result.Data)))
+	for _, issue := range result.Data {
+		output.WriteString(fmt.Sprintf("- %s [%s, %s, started %s, key: %s]\n", issue.Name, issue.State, issue.Severity, issue.Started, issue.Key))
+	}
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (h *CronitorMCPHandler) handleGetMetricAggregates(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := map[string]string{
+		"monitor": req.GetString("monitor", ""),
+		"group":   req.GetString("group", ""),
+		"tag":     req.GetString("tag", ""),
+		"time":    req.GetString("time", "24h"),
+		"env":     req.GetString("env", ""),
+	}
+	if params["monitor"] == "" && params["group"] == "" && params["tag"] == "" {
+		return mcp.NewToolResultError("At least one of monitor, group or tag is required"), nil
+	}
+
+	resp, err := h.apiRequest("GET", "/aggregates", nil, params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get aggregates: %v", err)), nil
+	}
+	return mcp.NewToolResultText(prettyJSON(resp)), nil
+}
+
+func (h *CronitorMCPHandler) handlePauseMonitor(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key, err := req.RequireString("key")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid key: %v", err)), nil
+	}
+
+	endpoint := fmt.Sprintf("/monitors/%s/pause", url.PathEscape(key))
+	hours := req.GetInt("hours", 0)
+	if hours > 0 {
+		endpoint = fmt.Sprintf("%s/%d", endpoint, hours)
+	}
+
+	_, err = h.apiRequest("GET", endpoint, nil, nil)
+	h.auditAPIChange(ctx, "monitor.pause", key, map[string]int{"hours": hours}, err)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to pause monitor '%s': %v", key, err)), nil
+	}
+
+	if hours > 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Paused monitor '%s' for %d hours", key, hours)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Paused monitor '%s' until it is unpaused", key)), nil
+}
+
+func (h *CronitorMCPHandler) handleUnpauseMonitor(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key, err := req.RequireString("key")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid key: %v", err)), nil
+	}
+
+	_, err = h.apiRequest("GET", fmt.Sprintf("/monitors/%s/pause/0", url.PathEscape(key)), nil, nil)
+	h.auditAPIChange(ctx, "monitor.unpause", key, nil, err)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to unpause monitor '%s': %v", key, err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Unpaused monitor '%s'", key)), nil
+}
+
+func (h *CronitorMCPHandler) handleCreateIssue(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := req.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid name: %v", err)), nil
+	}
+
+	issue := map[string]interface{}{
+		"name":     name,
+		"severity": req.GetString("severity", "outage"),
+		"state":    req.GetString("state", "unresolved"),
+	}
+	if description := req.GetString("description", ""); description != "" {
+		issue["description"] = description
+	}
+	if monitors := splitKeys(req.GetString("monitors", "")); len(monitors) > 0 {
+		issue["monitors"] = monitors
+	}
+
+	resp, err := h.apiRequest("POST", "/issues", issue, nil)
+	var created struct {
+		Key string `json:"key"`
+	}
+	json.Unmarshal(resp, &created)
+	h.auditAPIChange(ctx, "issue.create", created.Key, issue, err)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create issue: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Created issue '%s'\nIssue key: %s", name, created.Key)), nil
+}
+
+func (h *CronitorMCPHandler) handleResolveIssue(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key, err := req.RequireString("key")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid key: %v", err)), nil
+	}
+
+	// The API replaces the whole issue on update, so send it back with only the state changed
+	resp, err := h.apiRequest("GET", fmt.Sprintf("/issues/%s", url.PathEscape(key)), nil, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get issue '%s': %v", key, err)), nil
+	}
+	var issue map[string]interface{}
+	if err := json.Unmarshal(resp, &issue); err != nil {
+		return mcp.NewToolResultError("Failed to parse issue"), nil
+	}
+	issue["state"] = "resolved"
+
+	_, err = h.apiRequest("PUT", fmt.Sprintf("/issues/%s", url.PathEscape(key)), issue, nil)
+	h.auditAPIChange(ctx, "issue.resolve", key, nil, err)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve issue '%s': %v", key, err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Resolved issue '%s'", key)), nil
+}
+
+func (h *CronitorMCPHandler) handleCreateMaintenanceWindow(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	window := map[string]interface{}{}
+	for _, field := range []string{"name", "start", "end"} {
+		value, err := req.RequireString(field)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid %s: %v", field, err)), nil
+		}
+		window[field] = value
+	}
+	if description := req.GetString("description", ""); description != "" {
+		window["description"] = description
+	}
+	if monitors := splitKeys(req.GetString("monitors", "")); len(monitors) > 0 {
+		window["monitors"] = monitors
+	}
+
+	resp, err := h.apiRequest("POST", "/maintenance_windows", window, nil)
+	var created struct {
+		Key string `json:"key"`
+	}
+	json.Unmarshal(resp, &created)
+	h.auditAPIChange(ctx, "maintenance_window.create", created.Key, window, err)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create maintenance window: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Created maintenance window '%s' from %s to %s\nKey: %s",
+		window["name"], window["start"], window["end"], created.Key)), nil
+}
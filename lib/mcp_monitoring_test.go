@@ -0,0 +1,202 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newMonitoringAPI serves canned responses keyed by "METHOD /path" and records the last request
+func newMonitoringAPI(t *testing.T, responses map[string]string) (*httptest.Server, *http.Request, *string) {
+	last := &http.Request{}
+	lastBody := new(string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*last = *r
+		*lastBody = string(body)
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, last, lastBody
+}
+
+func newTestMCPHandler(apiURL string, readOnly bool) *CronitorMCPHandler {
+	return &CronitorMCPHandler{
+		instanceName: "test",
+		readOnly:     readOnly,
+		api:          &APIClient{BaseURL: apiURL, ApiKey: "test-api-key", UserAgent: "CronitorCLI/test"},
+	}
+}
+
+func registeredToolNames(t *testing.T, h *CronitorMCPHandler) map[string]bool {
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	h.RegisterTools(s)
+
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	data, _ := json.Marshal(response)
+	var result struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]bool{}
+	for _, tool := range result.Result.Tools {
+		names[tool.Name] = true
+	}
+	return names
+}
+
+func TestReadOnlyInstanceOmitsWriteTools(t *testing.T) {
+	writeTools := []string{"create_cronjob", "update_cronjob", "delete_cronjob", "run_cronjob_now", "pause_monitor", "unpause_monitor", "create_issue", "resolve_issue", "create_maintenance_window"}
+	readTools := []string{"list_cronjobs", "get_cronitor_instance", "list_failing_monitors", "get_monitor_activity", "list_issues", "get_metric_aggregates"}
+
+	readWrite := registeredToolNames(t, newTestMCPHandler("", false))
+	readOnly := registeredToolNames(t, newTestMCPHandler("", true))
+
+	for _, name := range readTools {
+		if !readWrite[name] || !readOnly[name] {
+			t.Errorf("expected %s on every instance", name)
+		}
+	}
+	for _, name := range writeTools {
+		if !readWrite[name] {
+			t.Errorf("expected %s on a read-write instance", name)
+		}
+		if readOnly[name] {
+			t.Errorf("expected %s to be left out on a read-only instance", name)
+		}
+	}
+}
+
+func callTool(h func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) (string, bool) {
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, _ := h(context.Background(), req)
+	var text strings.Builder
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			text.WriteString(textContent.Text)
+		}
+	}
+	return text.String(), result.IsError
+}
+
+func TestListFailingMonitors(t *testing.T) {
+	fixture, err := os.ReadFile("../testdata/monitors_list.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, last, _ := newMonitoringAPI(t, map[string]string{"GET /monitors": string(fixture)})
+
+	h := newTestMCPHandler(api.URL, true)
+	text, isError := callTool(h.handleListFailingMonitors, map[string]interface{}{"tag": "critical"})
+	if isError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+
+	if query := last.URL.Query(); query.Get("state") != "failing" || query.Get("tag") != "critical" {
+		t.Errorf("expected failing monitors with the tag to be requested, got %v", query)
+	}
+	if !strings.Contains(text, "Health Check") || strings.Contains(text, "Nightly Backup") {
+		t.Errorf("expected only the failing monitor, got %q", text)
+	}
+}
+
+func TestResolveIssueKeepsOtherFields(t *testing.T) {
+	api, last, lastBody := newMonitoringAPI(t, map[string]string{
+		"GET /issues/issue-001": `{"key":"issue-001","name":"Database connection timeout","state":"unresolved","severity":"outage"}`,
+		"PUT /issues/issue-001": `{"key":"issue-001"}`,
+	})
+
+	h := newTestMCPHandler(api.URL, false)
+	if text, isError := callTool(h.handleResolveIssue, map[string]interface{}{"key": "issue-001"}); isError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+
+	if last.Method != "PUT" {
+		t.Errorf("expected the issue to be updated last, got %s", last.Method)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal([]byte(*lastBody), &sent)
+	if sent["state"] != "resolved" || sent["severity"] != "outage" || sent["name"] != "Database connection timeout" {
+		t.Errorf("expected the issue to be sent back resolved, got %v", sent)
+	}
+}
+
+func TestMonitoringToolsRequireAPIKey(t *testing.T) {
+	h := newTestMCPHandler("http://127.0.0.1:1", false)
+	h.api.ApiKey = ""
+	if text, isError := callTool(h.handleListIssues, nil); !isError || !strings.Contains(text, "API key") {
+		t.Errorf("expected a missing API key error, got %q", text)
+	}
+}
+
+func TestMonitoringChangesAreAudited(t *testing.T) {
+	api, last, _ := newMonitoringAPI(t, map[string]string{"GET /monitors/db/backup/pause/2": `{}`})
+
+	h := newTestMCPHandler(api.URL, false)
+	auditLog := &AuditLog{Path: filepath.Join(t.TempDir(), "audit.log")}
+	h.SetAuditLog(auditLog)
+	h.SetCaller(func(ctx context.Context) MCPCaller { return MCPCaller{Username: "alice"} })
+
+	if text, isError := callTool(h.handlePauseMonitor, map[string]interface{}{"key": "db/backup", "hours": 2}); isError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+	if path := last.URL.EscapedPath(); path != "/monitors/db%2Fbackup/pause/2" {
+		t.Errorf("expected the monitor key to be escaped in the path, got %s", path)
+	}
+	callTool(h.handleUnpauseMonitor, map[string]interface{}{"key": "db/backup"})
+
+	entries, err := auditLog.Read(AuditFilter{})
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected both changes to be audited, got %+v (%v)", entries, err)
+	}
+	pause, unpause := entries[0], entries[1]
+	if pause.Action == "monitor.unpause" {
+		pause, unpause = unpause, pause
+	}
+	if pause.Action != "monitor.pause" || pause.Source != "mcp" || pause.User != "alice" || pause.Key != "db/backup" || !pause.Succeeded() {
+		t.Errorf("unexpected audit entry %+v", pause)
+	}
+	if unpause.Action != "monitor.unpause" || unpause.Succeeded() {
+		t.Errorf("expected the failed unpause to be audited as failed, got %+v", unpause)
+	}
+}
+
+func TestWriteToolsRefuseReadOnlyCallers(t *testing.T) {
+	api, last, _ := newMonitoringAPI(t, map[string]string{"GET /monitors/backup/pause": `{}`})
+
+	h := newTestMCPHandler(api.URL, false)
+	h.SetCaller(func(ctx context.Context) MCPCaller { return MCPCaller{Username: "bob", ReadOnly: true} })
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	h.RegisterTools(s)
+
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"pause_monitor","arguments":{"key":"backup"}}}`))
+	data, _ := json.Marshal(response)
+	if !strings.Contains(string(data), "Forbidden: pause_monitor makes changes") {
+		t.Errorf("expected the tool to be refused, got %s", data)
+	}
+	if last.Method != "" {
+		t.Errorf("expected no API request, got %s %s", last.Method, last.URL)
+	}
+}
@@ -0,0 +1,377 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMCPPollInterval is how often subscribed monitors, issues and runs are checked for changes
+const DefaultMCPPollInterval = 30 * time.Second
+
+// mcpResourceTemplate is a family of resources addressed by URI, like cronitor://monitor/{key}
+type mcpResourceTemplate struct {
+	template mcp.ResourceTemplate
+	mimeType string
+	// read returns the resource's content, and its state: subscribers are notified when the state changes
+	read func(ctx context.Context, args map[string]string) (string, string, error)
+	// crontab resources are checked as soon as a crontab on this host changes, not only on the next poll
+	crontab bool
+}
+
+// match returns the variables of a URI if the template names it
+func (t *mcpResourceTemplate) match(uri string) (map[string]string, bool) {
+	if !t.template.URITemplate.Regexp().MatchString(uri) {
+		return nil, false
+	}
+	args := map[string]string{}
+	for name, value := range t.template.URITemplate.Match(uri) {
+		args[name] = value.String()
+	}
+	return args, true
+}
+
+func (h *CronitorMCPHandler) resourceTemplates() []*mcpResourceTemplate {
+	return []*mcpResourceTemplate{
+		{
+			template: mcp.NewResourceTemplate("cronitor://monitor/{key}", "Monitor",
+				mcp.WithTemplateDescription("A Cronitor monitor and its status. Subscribers are notified when it starts failing or recovers."),
+				mcp.WithTemplateMIMEType("application/json")),
+			mimeType: "application/json",
+			read:     h.readMonitorResource(false),
+		},
+		{
+			template: mcp.NewResourceTemplate("cronitor://monitor/{key}/invocations", "Monitor invocations",
+				mcp.WithTemplateDescription("A Cronitor monitor with its recent invocations. Subscribers are notified when it starts failing or recovers."),
+				mcp.WithTemplateMIMEType("application/json")),
+			mimeType: "application/json",
+			read:     h.readMonitorResource(true),
+		},
+		{
+			template: mcp.NewResourceTemplate("cronitor://issue/{key}", "Issue",
+				mcp.WithTemplateDescription("A Cronitor issue (incident). Subscribers are notified when its state or severity changes."),
+				mcp.WithTemplateMIMEType("application/json")),
+			mimeType: "application/json",
+			read:     h.readIssueResource,
+		},
+		{
+			template: mcp.NewResourceTemplate("cronitor://crontab/{+file}/line/{n}", "Crontab line",
+				mcp.WithTemplateDescription("A line of a crontab, e.g. cronitor://crontab/etc/cron.d/backup/line/3 or cronitor://crontab/user:deploy/line/1. Subscribers are notified when the line changes."),
+				mcp.WithTemplateMIMEType("application/json")),
+			mimeType: "application/json",
+			read:     h.readCrontabLineResource,
+			crontab:  true,
+		},
+		{
+			template: mcp.NewResourceTemplate("cronitor://job/{key}/last-output", "Job last output",
+				mcp.WithTemplateDescription("The output of the most recent run of a cron job, by job key or monitor code. Subscribers are notified when the job runs again."),
+				mcp.WithTemplateMIMEType("text/plain")),
+			mimeType: "text/plain",
+			read:     h.readJobLastOutputResource,
+		},
+	}
+}
+
+// registerResourceTemplates adds the resource templates to the server
+func (h *CronitorMCPHandler) registerResourceTemplates(s *server.MCPServer) {
+	h.resourceSubscriptions().server = s
+
+	for _, template := range h.resourceTemplates() {
+		template := template
+		s.AddResourceTemplate(template.template, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			args, _ := template.match(req.Params.URI)
+			content, _, err := template.read(ctx, args)
+			if err != nil {
+				return nil, err
+			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      req.Params.URI,
+					MIMEType: template.mimeType,
+					Text:     content,
+				},
+			}, nil
+		})
+	}
+}
+
+// matchResource finds the template that names a URI
+func (h *CronitorMCPHandler) matchResource(uri string) (*mcpResourceTemplate, map[string]string) {
+	for _, template := range h.resourceTemplates() {
+		if args, ok := template.match(uri); ok {
+			return template, args
+		}
+	}
+	return nil, nil
+}
+
+func (h *CronitorMCPHandler) readMonitorResource(withInvocations bool) func(context.Context, map[string]string) (string, string, error) {
+	return func(ctx context.Context, args map[string]string) (string, string, error) {
+		var params map[string]string
+		if withInvocations {
+			params = map[string]string{"withInvocations": "true"}
+		}
+		resp, err := h.apiRequest("GET", fmt.Sprintf("/monitors/%s", url.PathEscape(args["key"])), nil, params)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get monitor '%s': %v", args["key"], err)
+		}
+
+		var monitor struct {
+			Passing bool `json:"passing"`
+		}
+		json.Unmarshal(resp, &monitor)
+		state := "failing"
+		if monitor.Passing {
+			state = "passing"
+		}
+		return prettyJSON(resp), state, nil
+	}
+}
+
+func (h *CronitorMCPHandler) readIssueResource(ctx context.Context, args map[string]string) (string, string, error) {
+	resp, err := h.apiRequest("GET", fmt.Sprintf("/issues/%s", url.PathEscape(args["key"])), nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get issue '%s': %v", args["key"], err)
+	}
+
+	var issue struct {
+		State    string `json:"state"`
+		Severity string `json:"severity"`
+	}
+	json.Unmarshal(resp, &issue)
+	return prettyJSON(resp), issue.State + " " + issue.Severity, nil
+}
+
+func (h *CronitorMCPHandler) readCrontabLineResource(ctx context.Context, args map[string]string) (string, string, error) {
+	// URIs leave out the leading slash of a path, as the dashboard's crontab routes do
+	filename := args["file"]
+	if !strings.HasPrefix(filename, "user:") && !strings.HasPrefix(filename, "/") {
+		filename = "/" + filename
+	}
+	lineNumber, err := strconv.Atoi(args["n"])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid line number '%s'", args["n"])
+	}
+
+	resp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/crontabs", nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	var crontabs []struct {
+		Filename string            `json:"filename"`
+		Lines    []json.RawMessage `json:"lines"`
+	}
+	if err := json.Unmarshal(resp, &crontabs); err != nil {
+		return "", "", fmt.Errorf("failed to parse crontabs: %v", err)
+	}
+
+	for _, crontab := range crontabs {
+		if crontab.Filename != filename {
+			continue
+		}
+		for _, data := range crontab.Lines {
+			var line struct {
+				LineNumber int    `json:"line_number"`
+				LineText   string `json:"line_text"`
+			}
+			if json.Unmarshal(data, &line) == nil && line.LineNumber == lineNumber {
+				return prettyJSON(data), line.LineText, nil
+			}
+		}
+		return "", "", fmt.Errorf("crontab %s has no line %d", filename, lineNumber)
+	}
+	return "", "", fmt.Errorf("crontab %s not found", filename)
+}
+
+func (h *CronitorMCPHandler) readJobLastOutputResource(ctx context.Context, args map[string]string) (string, string, error) {
+	// A job is found by its crontab line key, or by its monitor code
+	var runs []RunRecord
+	for _, field := range []string{"key", "code"} {
+		query := url.Values{field: {args["key"]}, "limit": {"1"}}
+		resp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/runs?"+query.Encode(), nil)
+		if err != nil {
+			return "", "", err
+		}
+		if err := json.Unmarshal(resp, &runs); err != nil {
+			return "", "", fmt.Errorf("failed to parse runs: %v", err)
+		}
+		if len(runs) > 0 {
+			break
+		}
+	}
+	if len(runs) == 0 {
+		return "", "", fmt.Errorf("no runs recorded for job '%s'", args["key"])
+	}
+
+	// Runs are listed without their output
+	resp, err := h.makeAuthenticatedRequest(ctx, "GET", h.apiURL+"/api/runs/"+url.PathEscape(runs[0].ID), nil)
+	if err != nil {
+		return "", "", err
+	}
+	var run RunRecord
+	if err := json.Unmarshal(resp, &run); err != nil {
+		return "", "", fmt.Errorf("failed to parse run: %v", err)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Command: %s\n", run.Command))
+	output.WriteString(fmt.Sprintf("Started: %s\n", run.StartedAt.Format(time.RFC3339)))
+	output.WriteString(fmt.Sprintf("Duration: %s\n", run.Duration().Round(time.Millisecond)))
+	output.WriteString(fmt.Sprintf("Exit code: %d\n", run.ExitCode))
+	if run.Truncated {
+		output.WriteString("Output was truncated to the last part\n")
+	}
+	output.WriteString("\n")
+	output.WriteString(run.Output)
+	return output.String(), run.ID, nil
+}
+
+// mcpSubscription is a session's subscription to a resource
+type mcpSubscription struct {
+	uri     string
+	session string
+	// ctx is the context of the subscribe request, so the resource is read with the subscriber's identity
+	ctx   context.Context
+	state string
+}
+
+// mcpSubscriptions tracks resource subscriptions and notifies sessions when a resource they subscribed to changes
+type mcpSubscriptions struct {
+	mu      sync.Mutex
+	server  *server.MCPServer
+	entries map[string]*mcpSubscription
+	started bool
+}
+
+func (h *CronitorMCPHandler) resourceSubscriptions() *mcpSubscriptions {
+	if h.subscriptions == nil {
+		h.subscriptions = &mcpSubscriptions{entries: map[string]*mcpSubscription{}}
+	}
+	return h.subscriptions
+}
+
+// ServerOptions are the options an MCP server needs to serve the handler's resources, including subscriptions
+func (h *CronitorMCPHandler) ServerOptions() []server.ServerOption {
+	hooks := &server.Hooks{}
+	hooks.AddAfterSubscribe(func(ctx context.Context, id any, req *mcp.SubscribeRequest, result *mcp.EmptyResult) {
+		h.subscribe(ctx, req.Params.URI)
+	})
+	hooks.AddAfterUnsubscribe(func(ctx context.Context, id any, req *mcp.UnsubscribeRequest, result *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			h.unsubscribe(session.SessionID(), req.Params.URI)
+		}
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		h.unsubscribe(session.SessionID(), "")
+	})
+
+	return []server.ServerOption{
+		server.WithResourceCapabilities(true, false),
+		server.WithHooks(hooks),
+	}
+}
+
+// subscribe records the current state of a resource for the session, and starts watching for changes
+func (h *CronitorMCPHandler) subscribe(ctx context.Context, uri string) {
+	session := server.ClientSessionFromContext(ctx)
+	template, args := h.matchResource(uri)
+	if session == nil || template == nil {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	_, state, _ := template.read(ctx, args)
+
+	subs := h.resourceSubscriptions()
+	subs.mu.Lock()
+	defer subs.mu.Unlock()
+	subs.entries[session.SessionID()+" "+uri] = &mcpSubscription{uri: uri, session: session.SessionID(), ctx: ctx, state: state}
+	if !subs.started {
+		subs.started = true
+		go h.watchSubscriptions()
+	}
+}
+
+// unsubscribe removes a session's subscription to a resource, or all of its subscriptions when uri is empty
+func (h *CronitorMCPHandler) unsubscribe(session string, uri string) {
+	subs := h.resourceSubscriptions()
+	subs.mu.Lock()
+	defer subs.mu.Unlock()
+	for key, subscription := range subs.entries {
+		if subscription.session == session && (uri == "" || subscription.uri == uri) {
+			delete(subs.entries, key)
+		}
+	}
+}
+
+// watchSubscriptions checks subscribed resources on every poll. On this host, crontabs are also checked as soon
+// as a crontab file changes.
+func (h *CronitorMCPHandler) watchSubscriptions() {
+	interval := h.pollInterval
+	if interval <= 0 {
+		interval = DefaultMCPPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var crontabChanges chan []string
+	if h.local {
+		if watcher, err := NewFileWatcher(CrontabPaths(), time.Second); err == nil {
+			defer watcher.Close()
+			crontabChanges = watcher.Changes
+		}
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			h.checkSubscriptions(false)
+		case <-crontabChanges:
+			h.checkSubscriptions(true)
+		}
+	}
+}
+
+// checkSubscriptions reads subscribed resources again and notifies sessions of the ones whose state changed
+func (h *CronitorMCPHandler) checkSubscriptions(crontabsOnly bool) {
+	subs := h.resourceSubscriptions()
+	subs.mu.Lock()
+	pending := make([]mcpSubscription, 0, len(subs.entries))
+	for _, subscription := range subs.entries {
+		pending = append(pending, *subscription)
+	}
+	subs.mu.Unlock()
+
+	for _, subscription := range pending {
+		template, args := h.matchResource(subscription.uri)
+		if template == nil || (crontabsOnly && !template.crontab) {
+			continue
+		}
+
+		// A resource that cannot be read right now, like during an API outage, is left as it was
+		_, state, err := template.read(subscription.ctx, args)
+		if err != nil || state == subscription.state {
+			continue
+		}
+
+		subs.mu.Lock()
+		current, ok := subs.entries[subscription.session+" "+subscription.uri]
+		if ok {
+			current.state = state
+		}
+		subs.mu.Unlock()
+
+		if ok && subs.server != nil {
+			subs.server.SendNotificationToSpecificClient(subscription.session, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": subscription.uri})
+		}
+	}
+}
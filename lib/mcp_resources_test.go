@@ -0,0 +1,174 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// testSession is an MCP client session that collects the notifications sent to it
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) SessionID() string { return "test-session" }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func newTestResourceServer(t *testing.T, h *CronitorMCPHandler) (*server.MCPServer, context.Context, *testSession) {
+	s := server.NewMCPServer("test", "0.0.0", h.ServerOptions()...)
+	if err := h.RegisterResources(s); err != nil {
+		t.Fatal(err)
+	}
+
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+	return s, s.WithContext(context.Background(), session), session
+}
+
+func readResource(t *testing.T, s *server.MCPServer, ctx context.Context, uri string) (string, string) {
+	request, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": map[string]string{"uri": uri},
+	})
+	data, _ := json.Marshal(s.HandleMessage(ctx, request))
+
+	var response struct {
+		Result struct {
+			Contents []struct {
+				Text string `json:"text"`
+			} `json:"contents"`
+		} `json:"result"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Result.Contents) == 0 {
+		return "", response.Error.Message
+	}
+	return response.Result.Contents[0].Text, ""
+}
+
+func TestResourceTemplatesAreListed(t *testing.T) {
+	s, ctx, _ := newTestResourceServer(t, newTestMCPHandler("", true))
+
+	data, _ := json.Marshal(s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/templates/list"}`)))
+	for _, template := range []string{
+		"cronitor://monitor/{key}",
+		"cronitor://monitor/{key}/invocations",
+		"cronitor://issue/{key}",
+		"cronitor://crontab/{+file}/line/{n}",
+		"cronitor://job/{key}/last-output",
+	} {
+		if !strings.Contains(string(data), template) {
+			t.Errorf("expected template %s, got %s", template, data)
+		}
+	}
+}
+
+func TestReadResources(t *testing.T) {
+	api, last, _ := newMonitoringAPI(t, map[string]string{
+		"GET /monitors/db-backup": `{"key":"db-backup","passing":false}`,
+		"GET /issues/issue-001":   `{"key":"issue-001","state":"unresolved"}`,
+		"GET /api/crontabs": `[{"filename":"/etc/cron.d/backup","lines":[
+			{"line_number":0,"line_text":"SHELL=/bin/bash"},
+			{"line_number":1,"line_text":"0 2 * * * /usr/bin/backup","is_job":true}]}]`,
+		"GET /api/runs":   `[{"id":"1","command":"/usr/bin/backup","exit_code":1}]`,
+		"GET /api/runs/1": `{"id":"1","command":"/usr/bin/backup","exit_code":1,"output":"disk full\n"}`,
+	})
+	h := newTestMCPHandler(api.URL, true)
+	h.apiURL = api.URL
+	s, ctx, _ := newTestResourceServer(t, h)
+
+	if text, _ := readResource(t, s, ctx, "cronitor://monitor/db-backup/invocations"); !strings.Contains(text, `"passing": false`) {
+		t.Errorf("expected the monitor, got %q", text)
+	}
+	if last.URL.Query().Get("withInvocations") != "true" {
+		t.Errorf("expected invocations to be requested, got %v", last.URL.Query())
+	}
+
+	if text, _ := readResource(t, s, ctx, "cronitor://issue/issue-001"); !strings.Contains(text, "unresolved") {
+		t.Errorf("expected the issue, got %q", text)
+	}
+
+	if text, _ := readResource(t, s, ctx, "cronitor://crontab/etc/cron.d/backup/line/1"); !strings.Contains(text, "/usr/bin/backup") {
+		t.Errorf("expected line 1 of the crontab, got %q", text)
+	}
+	if _, message := readResource(t, s, ctx, "cronitor://crontab/etc/cron.d/backup/line/7"); !strings.Contains(message, "no line 7") {
+		t.Errorf("expected a missing line error, got %q", message)
+	}
+
+	text, _ := readResource(t, s, ctx, "cronitor://job/abc123/last-output")
+	if !strings.Contains(text, "Exit code: 1") || !strings.Contains(text, "disk full") {
+		t.Errorf("expected the last run's output, got %q", text)
+	}
+	if last.URL.Path != "/api/runs/1" {
+		t.Errorf("expected the latest run of the job to be requested, got %s", last.URL)
+	}
+}
+
+func TestSubscribersAreNotifiedWhenMonitorStartsFailing(t *testing.T) {
+	responses := map[string]string{"GET /monitors/db-backup": `{"key":"db-backup","passing":true}`}
+	api, _, _ := newMonitoringAPI(t, responses)
+	h := newTestMCPHandler(api.URL, true)
+	s, ctx, session := newTestResourceServer(t, h)
+
+	s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"cronitor://monitor/db-backup"}}`))
+
+	// Nothing changed yet
+	h.checkSubscriptions(false)
+	if len(session.notifications) != 0 {
+		t.Fatalf("expected no notification while the monitor is passing")
+	}
+
+	responses["GET /monitors/db-backup"] = `{"key":"db-backup","passing":false}`
+	h.checkSubscriptions(false)
+	if len(session.notifications) != 1 {
+		t.Fatalf("expected a notification when the monitor started failing, got %d", len(session.notifications))
+	}
+	notification := <-session.notifications
+	if notification.Method != mcp.MethodNotificationResourceUpdated || notification.Params.AdditionalFields["uri"] != "cronitor://monitor/db-backup" {
+		t.Errorf("unexpected notification %+v", notification)
+	}
+
+	// Still failing, and crontab changes do not recheck monitors
+	h.checkSubscriptions(false)
+	h.checkSubscriptions(true)
+	if len(session.notifications) != 0 {
+		t.Errorf("expected a single notification per change")
+	}
+
+	s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/unsubscribe","params":{"uri":"cronitor://monitor/db-backup"}}`))
+	responses["GET /monitors/db-backup"] = `{"key":"db-backup","passing":true}`
+	h.checkSubscriptions(false)
+	if len(session.notifications) != 0 {
+		t.Errorf("expected no notifications after unsubscribing")
+	}
+}
+
+func TestSubscribersAreNotifiedWhenCrontabLineChanges(t *testing.T) {
+	responses := map[string]string{"GET /api/crontabs": `[{"filename":"user:deploy","lines":[{"line_number":1,"line_text":"0 2 * * * backup"}]}]`}
+	api, _, _ := newMonitoringAPI(t, responses)
+	h := newTestMCPHandler(api.URL, true)
+	h.apiURL = api.URL
+	s, ctx, session := newTestResourceServer(t, h)
+
+	s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"cronitor://crontab/user:deploy/line/1"}}`))
+
+	responses["GET /api/crontabs"] = `[{"filename":"user:deploy","lines":[{"line_number":1,"line_text":"30 3 * * * backup"}]}]`
+	h.checkSubscriptions(true)
+	if len(session.notifications) != 1 {
+		t.Fatalf("expected a notification when the line changed, got %d", len(session.notifications))
+	}
+}
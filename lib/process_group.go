@@ -0,0 +1,73 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var signalsByName = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"TERM": syscall.SIGTERM,
+}
+
+// ParseSignal converts a signal name such as TERM, SIGTERM or 15 to a signal
+func ParseSignal(name string) (syscall.Signal, error) {
+	name = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "SIG")
+	if signal, ok := signalsByName[name]; ok {
+		return signal, nil
+	}
+	for _, signal := range signalsByName {
+		if fmt.Sprintf("%d", int(signal)) == name {
+			return signal, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported signal %q; use one of HUP, INT, QUIT, KILL or TERM", name)
+}
+
+// SignalName returns the short name of a signal, e.g. TERM
+func SignalName(signal syscall.Signal) string {
+	for name, s := range signalsByName {
+		if s == signal {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", int(signal))
+}
+
+// TerminateProcessGroup sends signal to every process in the group and waits up to grace for them to exit,
+// then sends SIGKILL to whatever is left. It reports whether the group had to be killed forcefully.
+func TerminateProcessGroup(pgid int, signal syscall.Signal, grace time.Duration) (bool, error) {
+	return terminate(func(s syscall.Signal) error { return SignalProcessGroup(pgid, s) }, func() bool { return ProcessGroupExists(pgid) }, signal, grace)
+}
+
+// TerminateProcess is TerminateProcessGroup for a single process that does not lead its own group
+func TerminateProcess(pid int, signal syscall.Signal, grace time.Duration) (bool, error) {
+	return terminate(func(s syscall.Signal) error { return signalProcess(pid, s) }, func() bool { return processIsAlive(pid) }, signal, grace)
+}
+
+func terminate(send func(syscall.Signal) error, exists func() bool, signal syscall.Signal, grace time.Duration) (bool, error) {
+	if err := send(signal); err != nil {
+		return false, err
+	}
+	if signal == syscall.SIGKILL {
+		return false, nil
+	}
+
+	deadline := time.Now().Add(grace)
+	for time.Now().Before(deadline) {
+		if !exists() {
+			return false, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if !exists() {
+		return false, nil
+	}
+	return true, send(syscall.SIGKILL)
+}
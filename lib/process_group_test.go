@@ -0,0 +1,47 @@
+package lib
+
+import (
+	"os/exec"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseSignal(t *testing.T) {
+	for _, name := range []string{"TERM", "term", "SIGTERM", "15"} {
+		if signal, err := ParseSignal(name); err != nil || signal != syscall.SIGTERM {
+			t.Errorf("ParseSignal(%q) = %v, %v; want SIGTERM", name, signal, err)
+		}
+	}
+	if _, err := ParseSignal("USR9"); err == nil {
+		t.Error("expected an error for an unknown signal")
+	}
+}
+
+func TestTerminateProcessGroupEscalatesToKill(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are not supported on windows")
+	}
+
+	// The shell ignores TERM and so does the sleep it starts, so only KILL stops the group
+	cmd := exec.Command("sh", "-c", `trap "" TERM; sleep 30 & wait`)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waited := make(chan struct{})
+	go func() { cmd.Wait(); close(waited) }()
+	time.Sleep(100 * time.Millisecond)
+
+	forced, err := TerminateProcessGroup(cmd.Process.Pid, syscall.SIGTERM, 300*time.Millisecond)
+	if err != nil || !forced {
+		t.Fatalf("expected the group to be killed after the grace period, got forced=%v err=%v", forced, err)
+	}
+
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("process group is still running")
+	}
+}
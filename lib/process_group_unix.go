@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package lib
+
+import "syscall"
+
+// SignalProcessGroup sends a signal to every process in the group. A group that has already exited is not an error.
+func SignalProcessGroup(pgid int, signal syscall.Signal) error {
+	if err := syscall.Kill(-pgid, signal); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	return nil
+}
+
+// ProcessGroupExists reports whether any process in the group is still running
+func ProcessGroupExists(pgid int) bool {
+	err := syscall.Kill(-pgid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+func signalProcess(pid int, signal syscall.Signal) error {
+	if err := syscall.Kill(pid, signal); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	return nil
+}
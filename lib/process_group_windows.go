@@ -0,0 +1,33 @@
+//go:build windows
+// +build windows
+
+package lib
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+// SignalProcessGroup ends the process and its children with taskkill. Windows has no signals, so
+// SIGKILL forces termination and anything else asks the processes to close.
+func SignalProcessGroup(pgid int, signal syscall.Signal) error {
+	args := []string{"/T", "/PID", fmt.Sprintf("%d", pgid)}
+	if signal == syscall.SIGKILL {
+		args = append([]string{"/F"}, args...)
+	}
+	if output, err := exec.Command("taskkill", args...).CombinedOutput(); err != nil && ProcessGroupExists(pgid) {
+		return fmt.Errorf("taskkill failed: %s", output)
+	}
+	return nil
+}
+
+// ProcessGroupExists reports whether the process that leads the group is still running
+func ProcessGroupExists(pgid int) bool {
+	return processIsAlive(pgid)
+}
+
+// Without process groups, a single process is stopped the same way as a group
+func signalProcess(pid int, signal syscall.Signal) error {
+	return SignalProcessGroup(pid, signal)
+}
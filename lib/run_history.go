@@ -0,0 +1,333 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Run triggers recorded in the run history
+const (
+	RunTriggerDashboard = "dashboard"
+	RunTriggerExec      = "exec"
+	RunTriggerMCP       = "mcp"
+	RunTriggerTUI       = "tui"
+)
+
+// MaxRunOutput is how much output, from the end, is kept for each run
+const MaxRunOutput = 16 * 1024
+
+const (
+	DefaultRunHistoryDays = 30
+	DefaultRunHistoryRuns = 100
+)
+
+var runsBucket = []byte("runs")
+
+// The spool is only read while the dashboard runs, so it is capped to keep it from growing without one
+const (
+	maxSpooledRuns = 1000
+	maxSpoolAge    = 7 * 24 * time.Hour
+)
+
+// RunRecord is a finished job run observed on this host
+type RunRecord struct {
+	ID        string    `json:"id"`
+	JobKey    string    `json:"job_key,omitempty"`
+	Code      string    `json:"code,omitempty"`
+	Command   string    `json:"command"`
+	Trigger   string    `json:"trigger"`
+	User      string    `json:"user,omitempty"`
+	PID       int       `json:"pid,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	ExitCode  int       `json:"exit_code"`
+	Output    string    `json:"output,omitempty"`
+	Truncated bool      `json:"output_truncated,omitempty"`
+}
+
+// Duration is how long the run took
+func (r RunRecord) Duration() time.Duration {
+	return r.EndedAt.Sub(r.StartedAt)
+}
+
+// matches reports whether the run belongs to the job with this crontab line key or monitor code
+func (r RunRecord) matches(key, code string) bool {
+	return (key != "" && r.JobKey == key) || (code != "" && r.Code == code)
+}
+
+// jobIdentity groups runs of the same job when applying the per-job retention limit. The monitor code comes
+// first because runs recorded by `cronitor exec` only know the code, not the crontab line key.
+func (r RunRecord) jobIdentity() string {
+	if r.Code != "" {
+		return "code:" + r.Code
+	}
+	if r.JobKey != "" {
+		return "key:" + r.JobKey
+	}
+	return "command:" + r.Command
+}
+
+// NewRunRecord fills in the ID and truncates the output to the last MaxRunOutput bytes
+func NewRunRecord(record RunRecord) RunRecord {
+	if record.ID == "" {
+		// Zero padded nanoseconds sort in start order, which keeps the database in time order
+		record.ID = fmt.Sprintf("%019d-%d", record.StartedAt.UnixNano(), record.PID)
+	}
+	if len(record.Output) > MaxRunOutput {
+		record.Output = record.Output[len(record.Output)-MaxRunOutput:]
+		record.Truncated = true
+	}
+	return record
+}
+
+// RunHistoryFilter selects runs returned by RunHistory.List
+type RunHistoryFilter struct {
+	JobKey string
+	Code   string
+	Limit  int
+	Allow  func(RunRecord) bool
+}
+
+// RunHistory is the embedded database of finished runs. Only one process can open it at a time, so the
+// dashboard owns it and `cronitor exec` hands finished runs over through a spool directory instead.
+type RunHistory struct {
+	db      *bolt.DB
+	MaxAge  time.Duration
+	MaxRuns int
+}
+
+// OpenRunHistory opens or creates the run history database at path
+func OpenRunHistory(path string, maxAgeDays int, maxRuns int) (*RunHistory, error) {
+	if maxAgeDays <= 0 {
+		maxAgeDays = DefaultRunHistoryDays
+	}
+	if maxRuns <= 0 {
+		maxRuns = DefaultRunHistoryRuns
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("cannot create run history directory: %v", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open run history %s: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(runsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &RunHistory{db: db, MaxAge: time.Duration(maxAgeDays) * 24 * time.Hour, MaxRuns: maxRuns}, nil
+}
+
+// Close releases the database
+func (h *RunHistory) Close() error {
+	return h.db.Close()
+}
+
+// Add records a finished run and applies the retention limits
+func (h *RunHistory) Add(record RunRecord) (RunRecord, error) {
+	record = NewRunRecord(record)
+	data, err := json.Marshal(record)
+	if err != nil {
+		return record, err
+	}
+
+	err = h.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(runsBucket).Put([]byte(record.ID), data); err != nil {
+			return err
+		}
+		return h.prune(tx)
+	})
+	return record, err
+}
+
+// Get returns a single run with its output, or nil when there is no run with this ID
+func (h *RunHistory) Get(id string) (*RunRecord, error) {
+	var record *RunRecord
+	err := h.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(runsBucket).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		record = &RunRecord{}
+		return json.Unmarshal(data, record)
+	})
+	return record, err
+}
+
+// List returns runs newest first without their output
+func (h *RunHistory) List(filter RunHistoryFilter) ([]RunRecord, error) {
+	records := []RunRecord{}
+	err := h.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(runsBucket).Cursor()
+		for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+			var record RunRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				continue
+			}
+			if (filter.JobKey != "" || filter.Code != "") && !record.matches(filter.JobKey, filter.Code) {
+				continue
+			}
+			if filter.Allow != nil && !filter.Allow(record) {
+				continue
+			}
+
+			record.Output = ""
+			records = append(records, record)
+			if filter.Limit > 0 && len(records) >= filter.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	return records, err
+}
+
+// prune removes runs older than MaxAge and all but the newest MaxRuns runs of each job
+func (h *RunHistory) prune(tx *bolt.Tx) error {
+	cutoff := []byte(fmt.Sprintf("%019d", time.Now().Add(-h.MaxAge).UnixNano()))
+	counts := make(map[string]int)
+	expired := [][]byte{}
+
+	cursor := tx.Bucket(runsBucket).Cursor()
+	for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+		if string(k) < string(cutoff) {
+			expired = append(expired, append([]byte{}, k...))
+			continue
+		}
+
+		var record RunRecord
+		if err := json.Unmarshal(v, &record); err != nil {
+			expired = append(expired, append([]byte{}, k...))
+			continue
+		}
+
+		identity := record.jobIdentity()
+		counts[identity]++
+		if counts[identity] > h.MaxRuns {
+			expired = append(expired, append([]byte{}, k...))
+		}
+	}
+
+	for _, k := range expired {
+		if err := tx.Bucket(runsBucket).Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SpoolRun writes a finished run to the spool directory for the dashboard to pick up. The directory is
+// sticky and world-writable, like the instance registry, because jobs run as many different users, and each
+// run is only readable by the user that wrote it since job output often contains secrets.
+func SpoolRun(dir string, record RunRecord) error {
+	record = NewRunRecord(record)
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err := EnsureSharedDir(dir); err != nil {
+		return fmt.Errorf("cannot create run spool %s: %v", dir, err)
+	}
+	if pruneSpool(dir) >= maxSpooledRuns {
+		return fmt.Errorf("run spool %s is full, start the dashboard to read it", dir)
+	}
+
+	tempFile, err := ioutil.TempFile(dir, ".run-*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot write to run spool %s: %v", dir, err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Chmod(0600); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), filepath.Join(dir, record.ID+".json"))
+}
+
+// pruneSpool removes spooled runs older than maxSpoolAge, where this user is allowed to, and returns how many remain
+func pruneSpool(dir string) int {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+
+	spooled := 0
+	cutoff := time.Now().Add(-maxSpoolAge)
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if entry.ModTime().Before(cutoff) && os.Remove(filepath.Join(dir, entry.Name())) == nil {
+			continue
+		}
+		spooled++
+	}
+	return spooled
+}
+
+// IngestSpool moves spooled runs into the database. Runs are keyed by ID, so a spool file that cannot
+// be removed is only stored once no matter how many times it is read. A run is only stored when the file
+// was written by the user the run is recorded for, or by root, so users cannot forge each other's runs.
+func (h *RunHistory) IngestSpool(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-h.MaxAge)
+	return h.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(runsBucket)
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasSuffix(name, ".json") || strings.HasPrefix(name, ".") || !entry.Mode().IsRegular() {
+				continue
+			}
+
+			path := filepath.Join(dir, name)
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				continue
+			}
+
+			var record RunRecord
+			if err := json.Unmarshal(data, &record); err != nil || record.ID == "" || record.StartedAt.Before(cutoff) || !ownedByUser(entry, record.User) {
+				os.Remove(path)
+				continue
+			}
+
+			record = NewRunRecord(record)
+			data, _ = json.Marshal(record)
+			if err := bucket.Put([]byte(record.ID), data); err != nil {
+				return err
+			}
+			os.Remove(path)
+		}
+		return h.prune(tx)
+	})
+}
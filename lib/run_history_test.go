@@ -0,0 +1,148 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openTestRunHistory(t *testing.T, maxRuns int) *RunHistory {
+	history, err := OpenRunHistory(filepath.Join(t.TempDir(), "runs.db"), 30, maxRuns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { history.Close() })
+	return history
+}
+
+func TestRunHistoryListsNewestFirst(t *testing.T) {
+	history := openTestRunHistory(t, 10)
+	start := time.Now().Add(-time.Hour)
+
+	for i := 0; i < 3; i++ {
+		history.Add(RunRecord{JobKey: "backup", Command: "backup.sh", Trigger: RunTriggerExec, PID: 100 + i, StartedAt: start.Add(time.Duration(i) * time.Minute), EndedAt: start.Add(time.Duration(i)*time.Minute + time.Second), Output: "done"})
+	}
+	history.Add(RunRecord{Code: "abc123", Command: "report.sh", Trigger: RunTriggerDashboard, StartedAt: start, EndedAt: start})
+
+	runs, err := history.List(RunHistoryFilter{JobKey: "backup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 3 || runs[0].PID != 102 || runs[2].PID != 100 {
+		t.Fatalf("expected the three backup runs newest first, got %+v", runs)
+	}
+	if runs[0].Output != "" {
+		t.Error("expected listed runs to leave out their output")
+	}
+
+	run, err := history.Get(runs[0].ID)
+	if err != nil || run == nil || run.Output != "done" {
+		t.Errorf("expected Get to return the run with its output, got %+v %v", run, err)
+	}
+
+	if runs, _ := history.List(RunHistoryFilter{Code: "abc123"}); len(runs) != 1 || runs[0].Trigger != RunTriggerDashboard {
+		t.Errorf("expected to find the run by monitor code, got %+v", runs)
+	}
+}
+
+func TestRunHistoryRetention(t *testing.T) {
+	history := openTestRunHistory(t, 2)
+	now := time.Now()
+
+	history.Add(RunRecord{JobKey: "old", Command: "old.sh", StartedAt: now.Add(-40 * 24 * time.Hour)})
+	for i := 0; i < 4; i++ {
+		history.Add(RunRecord{JobKey: "busy", Command: "busy.sh", PID: i, StartedAt: now.Add(time.Duration(i) * time.Second)})
+	}
+
+	if runs, _ := history.List(RunHistoryFilter{JobKey: "old"}); len(runs) != 0 {
+		t.Errorf("expected runs older than the retention period to be removed, got %+v", runs)
+	}
+	if runs, _ := history.List(RunHistoryFilter{JobKey: "busy"}); len(runs) != 2 || runs[1].PID != 2 {
+		t.Errorf("expected only the newest two runs to be kept, got %+v", runs)
+	}
+}
+
+func TestRunHistoryTruncatesOutputFromTheEnd(t *testing.T) {
+	record := NewRunRecord(RunRecord{StartedAt: time.Now(), Output: strings.Repeat("a", MaxRunOutput) + "tail"})
+	if len(record.Output) != MaxRunOutput || !strings.HasSuffix(record.Output, "tail") || !record.Truncated {
+		t.Errorf("expected the last %d bytes of output to be kept", MaxRunOutput)
+	}
+}
+
+func TestRunHistoryIngestsSpool(t *testing.T) {
+	history := openTestRunHistory(t, 10)
+	spool := filepath.Join(t.TempDir(), "runs")
+
+	if err := SpoolRun(spool, RunRecord{Code: "abc123", Command: "sync.sh", Trigger: RunTriggerExec, User: CurrentUsername(), PID: 42, StartedAt: time.Now(), ExitCode: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(spool); len(entries) == 1 && runtime.GOOS != "windows" {
+		if info, _ := entries[0].Info(); info.Mode().Perm() != 0600 {
+			t.Errorf("expected spooled runs to be readable only by their user, got %v", info.Mode())
+		}
+	}
+	if err := history.IngestSpool(spool); err != nil {
+		t.Fatal(err)
+	}
+	// Reading the spool again must not record the run twice
+	history.IngestSpool(spool)
+
+	runs, _ := history.List(RunHistoryFilter{Code: "abc123"})
+	if len(runs) != 1 || runs[0].ExitCode != 3 {
+		t.Fatalf("expected the spooled run to be recorded once, got %+v", runs)
+	}
+
+	entries, _ := os.ReadDir(spool)
+	if len(entries) != 0 {
+		t.Errorf("expected ingested runs to be removed from the spool, found %d files", len(entries))
+	}
+}
+
+func TestSpoolRunPrunesOldRuns(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "runs")
+	if err := SpoolRun(spool, RunRecord{Code: "old", StartedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := os.ReadDir(spool)
+	old := time.Now().Add(-maxSpoolAge - time.Hour)
+	os.Chtimes(filepath.Join(spool, entries[0].Name()), old, old)
+
+	if err := SpoolRun(spool, RunRecord{Code: "new", StartedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if remaining := pruneSpool(spool); remaining != 1 {
+		t.Errorf("expected the old run to be removed from the spool, %d remain", remaining)
+	}
+}
+
+func TestIngestSpoolRejectsRunsOfAnotherUser(t *testing.T) {
+	if os.Getuid() != 0 || runtime.GOOS == "windows" {
+		t.Skip("changing the owner of a spool file requires root")
+	}
+	history := openTestRunHistory(t, 10)
+	spool := filepath.Join(t.TempDir(), "runs")
+
+	if err := SpoolRun(spool, RunRecord{Code: "forged", User: "root", StartedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := os.ReadDir(spool)
+	if err := os.Chown(filepath.Join(spool, entries[0].Name()), 65534, 65534); err != nil {
+		t.Skip("cannot change the owner of the spool file")
+	}
+
+	history.IngestSpool(spool)
+	if runs, _ := history.List(RunHistoryFilter{Code: "forged"}); len(runs) != 0 {
+		t.Errorf("expected a run spooled by another user to be rejected, got %+v", runs)
+	}
+}
+
+func TestRunsOfAMonitoredJobShareAnIdentity(t *testing.T) {
+	fromExec := RunRecord{Code: "abc123", Command: "/usr/bin/backup"}
+	fromDashboard := RunRecord{JobKey: "line-key", Code: "abc123", Command: "/usr/bin/backup"}
+	if fromExec.jobIdentity() != fromDashboard.jobIdentity() {
+		t.Errorf("expected runs of the same monitor to share a retention limit, got %q and %q", fromExec.jobIdentity(), fromDashboard.jobIdentity())
+	}
+}
@@ -0,0 +1,42 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	fiveFieldParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	sixFieldParser  = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+// NextRun returns the first time after `after` that the cron expression fires in the given location.
+// Expressions that do not run on a schedule, like @reboot, return an error.
+func NextRun(expression string, sixFields bool, location *time.Location, after time.Time) (time.Time, error) {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "@reboot") {
+		return time.Time{}, fmt.Errorf("%s does not run on a schedule", expression)
+	}
+
+	parser := fiveFieldParser
+	if sixFields {
+		parser = sixFieldParser
+	}
+
+	schedule, err := parser.Parse(expression)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if location == nil {
+		location = time.Local
+	}
+	next := schedule.Next(after.In(location))
+	if next.IsZero() {
+		return next, fmt.Errorf("%s never runs", expression)
+	}
+	return next, nil
+}
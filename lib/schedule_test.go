@@ -0,0 +1,32 @@
+package lib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2024, 3, 1, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		sixFields  bool
+		expected   time.Time
+	}{
+		{"*/15 * * * *", false, time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC)},
+		{"0 2 * * *", false, time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", false, time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC)},
+		{"45 */10 * * * *", true, time.Date(2024, 3, 1, 10, 10, 45, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		next, err := NextRun(test.expression, test.sixFields, time.UTC, after)
+		if err != nil || !next.Equal(test.expected) {
+			t.Errorf("NextRun(%q) = %v, %v; want %v", test.expression, next, err, test.expected)
+		}
+	}
+
+	if _, err := NextRun("@reboot", false, time.UTC, after); err == nil {
+		t.Error("expected @reboot to have no next run")
+	}
+}
@@ -0,0 +1,136 @@
+package lib
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"runtime"
+)
+
+// Transport is how crontabs are read and written. The default LocalTransport works against
+// this machine; SSHTransport lets a single host manage crontabs on another server.
+type Transport interface {
+	// Name identifies the host this transport operates on, e.g. "local" or "deploy@web1"
+	Name() string
+	IsRemote() bool
+
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, data []byte) error
+	ReadDir(dir string) ([]string, error)
+	Exists(path string) bool
+	IsDir(path string) bool
+	IsWritable(path string) bool
+
+	// Run executes a command with optional stdin and extra environment variables and returns its combined output
+	Run(stdin io.Reader, env []string, name string, args ...string) ([]byte, error)
+
+	CurrentUser() (string, error)
+	Hostname() (string, error)
+}
+
+// ActiveTransport is used for all crontab reads, writes and user lookups.
+var ActiveTransport Transport = LocalTransport{}
+
+// UseTransport replaces the active transport, e.g. after connecting to a remote host with --host.
+func UseTransport(t Transport) {
+	if t == nil {
+		t = LocalTransport{}
+	}
+	ActiveTransport = t
+}
+
+// isLocalWindows reports whether crontab operations would run on a Windows machine
+func isLocalWindows() bool {
+	return runtime.GOOS == "windows" && !ActiveTransport.IsRemote()
+}
+
+// CurrentUsername returns the user that crontab operations run as on the active transport
+func CurrentUsername() string {
+	username, _ := ActiveTransport.CurrentUser()
+	return username
+}
+
+// LocalTransport reads and writes crontabs on this machine.
+type LocalTransport struct{}
+
+func (LocalTransport) Name() string {
+	return "local"
+}
+
+func (LocalTransport) IsRemote() bool {
+	return false
+}
+
+func (LocalTransport) ReadFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+func (LocalTransport) WriteFile(path string, data []byte) error {
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func (LocalTransport) ReadDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+func (LocalTransport) Exists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
+
+func (LocalTransport) IsDir(path string) bool {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return fileInfo.Mode().IsDir()
+}
+
+func (LocalTransport) IsWritable(path string) bool {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0666)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	return true
+}
+
+func (LocalTransport) Run(stdin io.Reader, env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if env != nil {
+		cmd.Env = env
+	}
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	return output.Bytes(), err
+}
+
+func (LocalTransport) CurrentUser() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+func (LocalTransport) Hostname() (string, error) {
+	return os.Hostname()
+}
@@ -0,0 +1,271 @@
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHOptions configures how DialSSH authenticates. Empty values fall back to the ssh agent,
+// the default identity files in ~/.ssh and ~/.ssh/known_hosts.
+type SSHOptions struct {
+	IdentityFile   string
+	KnownHostsFile string
+	Timeout        time.Duration
+}
+
+// SSHTransport reads and writes crontabs on a remote host by running commands over SSH.
+type SSHTransport struct {
+	target string
+	client *ssh.Client
+
+	mu          sync.Mutex
+	hostname    string
+	currentUser string
+}
+
+// DialSSH connects to target, given as [user@]host[:port], and returns a transport for it.
+func DialSSH(target string, options SSHOptions) (*SSHTransport, error) {
+	username, address := parseSSHTarget(target)
+	if username == "" {
+		username, _ = LocalTransport{}.CurrentUser()
+	}
+
+	hostKeyCallback, err := sshHostKeyCallback(options.KnownHostsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	authMethods, err := sshAuthMethods(options.IdentityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = 15 * time.Second
+	}
+
+	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            username,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", target, err)
+	}
+
+	return NewSSHTransport(target, client), nil
+}
+
+// NewSSHTransport wraps an established SSH connection.
+func NewSSHTransport(target string, client *ssh.Client) *SSHTransport {
+	return &SSHTransport{target: target, client: client}
+}
+
+// Close disconnects from the remote host
+func (t *SSHTransport) Close() error {
+	return t.client.Close()
+}
+
+func (t *SSHTransport) Name() string {
+	return t.target
+}
+
+func (t *SSHTransport) IsRemote() bool {
+	return true
+}
+
+func (t *SSHTransport) ReadFile(path string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	if err := t.exec(nil, &stdout, &stderr, "cat -- "+shellquote.Join(path)); err != nil {
+		if !t.Exists(path) {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("cannot read %s on %s: %s", path, t.target, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+func (t *SSHTransport) WriteFile(path string, data []byte) error {
+	var output bytes.Buffer
+	if err := t.exec(bytes.NewReader(data), &output, &output, "umask 022 && cat > "+shellquote.Join(path)); err != nil {
+		return fmt.Errorf("cannot write %s on %s: %s", path, t.target, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+func (t *SSHTransport) ReadDir(dir string) ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	if err := t.exec(nil, &stdout, &stderr, "ls -1A -- "+shellquote.Join(dir)); err != nil {
+		return nil, fmt.Errorf("cannot read directory %s on %s: %s", dir, t.target, strings.TrimSpace(stderr.String()))
+	}
+	var names []string
+	for _, name := range strings.Split(stdout.String(), "\n") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (t *SSHTransport) Exists(path string) bool {
+	return t.test("-e", path)
+}
+
+func (t *SSHTransport) IsDir(path string) bool {
+	return t.test("-d", path)
+}
+
+func (t *SSHTransport) IsWritable(path string) bool {
+	return t.test("-w", path)
+}
+
+func (t *SSHTransport) Run(stdin io.Reader, env []string, name string, args ...string) ([]byte, error) {
+	command := shellquote.Join(append([]string{name}, args...)...)
+	if len(env) > 0 {
+		command = "env " + shellquote.Join(env...) + " " + command
+	}
+
+	var output bytes.Buffer
+	err := t.exec(stdin, &output, &output, command)
+	return output.Bytes(), err
+}
+
+func (t *SSHTransport) CurrentUser() (string, error) {
+	return t.cached(&t.currentUser, "id -un")
+}
+
+func (t *SSHTransport) Hostname() (string, error) {
+	return t.cached(&t.hostname, "hostname")
+}
+
+func (t *SSHTransport) test(flag, path string) bool {
+	return t.exec(nil, ioutil.Discard, ioutil.Discard, "test "+flag+" "+shellquote.Join(path)) == nil
+}
+
+// cached runs a command once and remembers its trimmed output, for values that do not change during a session
+func (t *SSHTransport) cached(value *string, command string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if *value != "" {
+		return *value, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := t.exec(nil, &stdout, &stderr, command); err != nil {
+		return "", fmt.Errorf("%s failed on %s: %s", command, t.target, strings.TrimSpace(stderr.String()))
+	}
+	*value = strings.TrimSpace(stdout.String())
+	return *value, nil
+}
+
+func (t *SSHTransport) exec(stdin io.Reader, stdout, stderr io.Writer, command string) error {
+	session, err := t.client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	if stdin != nil {
+		session.Stdin = stdin
+	}
+	session.Stdout = stdout
+	session.Stderr = stderr
+	return session.Run(command)
+}
+
+// parseSSHTarget splits [user@]host[:port] into a username and a dialable address
+func parseSSHTarget(target string) (string, string) {
+	var username string
+	host := target
+	if at := strings.LastIndex(target, "@"); at >= 0 {
+		username = target[:at]
+		host = target[at+1:]
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+	return username, host
+}
+
+func sshHostKeyCallback(knownHostsFile string) (ssh.HostKeyCallback, error) {
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load known hosts from %s: %w", knownHostsFile, err)
+	}
+	return callback, nil
+}
+
+func sshAuthMethods(identityFile string) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	var identityFiles []string
+	if identityFile != "" {
+		identityFiles = []string{identityFile}
+	} else if home, err := os.UserHomeDir(); err == nil {
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			identityFiles = append(identityFiles, filepath.Join(home, ".ssh", name))
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, path := range identityFiles {
+		key, err := ioutil.ReadFile(path)
+		if err != nil {
+			if identityFile != "" {
+				return nil, fmt.Errorf("cannot read identity file %s: %w", path, err)
+			}
+			continue
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			if identityFile != "" {
+				return nil, fmt.Errorf("cannot use identity file %s: %w", path, err)
+			}
+			// Encrypted default keys are expected to be loaded in the agent
+			continue
+		}
+		signers = append(signers, signer)
+	}
+
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		return nil, errors.New("no SSH credentials found; start ssh-agent or pass an identity file")
+	}
+	return methods, nil
+}
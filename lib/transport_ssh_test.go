@@ -0,0 +1,192 @@
+package lib
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestSSHServer runs an in-process SSH server that executes requested commands with the local shell.
+func startTestSSHServer(t *testing.T) *SSHTransport {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test SSH server requires a POSIX shell")
+	}
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+
+	client, err := ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+		User:            "test",
+		HostKeyCallback: ssh.FixedHostKey(hostSigner.PublicKey()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := NewSSHTransport("test@"+listener.Addr().String(), client)
+	t.Cleanup(func() { transport.Close() })
+	return transport
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			defer channel.Close()
+			for request := range channelRequests {
+				if request.Type != "exec" {
+					request.Reply(false, nil)
+					continue
+				}
+				request.Reply(true, nil)
+
+				length := binary.BigEndian.Uint32(request.Payload[:4])
+				cmd := exec.Command("sh", "-c", string(request.Payload[4:4+length]))
+				cmd.Stdin = channel
+				cmd.Stdout = channel
+				cmd.Stderr = channel.Stderr()
+
+				status := make([]byte, 4)
+				if err := cmd.Run(); err != nil {
+					if exitErr, ok := err.(*exec.ExitError); ok {
+						binary.BigEndian.PutUint32(status, uint32(exitErr.ExitCode()))
+					} else {
+						binary.BigEndian.PutUint32(status, 127)
+					}
+				}
+				channel.SendRequest("exit-status", false, status)
+				return
+			}
+		}()
+	}
+}
+
+func TestSSHTransportFileOperations(t *testing.T) {
+	transport := startTestSSHServer(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "my crontab")
+
+	if transport.Exists(path) {
+		t.Fatal("expected file to not exist yet")
+	}
+	if _, err := transport.ReadFile(path); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+
+	if err := transport.WriteFile(path, []byte("0 * * * * echo 'hi'\n")); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := transport.ReadFile(path)
+	if err != nil || string(contents) != "0 * * * * echo 'hi'\n" {
+		t.Errorf("unexpected contents %q (%v)", contents, err)
+	}
+
+	if !transport.IsDir(dir) || transport.IsDir(path) || !transport.IsWritable(path) {
+		t.Error("unexpected results from file tests")
+	}
+
+	names, err := transport.ReadDir(dir)
+	if err != nil || len(names) != 1 || names[0] != "my crontab" {
+		t.Errorf("unexpected directory listing %v (%v)", names, err)
+	}
+
+	output, err := transport.Run(strings.NewReader("from stdin"), []string{"GREETING=hello"}, "sh", "-c", `printf '%s %s' "$GREETING" "$(cat)"`)
+	if err != nil || string(output) != "hello from stdin" {
+		t.Errorf("unexpected command output %q (%v)", output, err)
+	}
+
+	if hostname, err := transport.Hostname(); err != nil || hostname == "" {
+		t.Errorf("expected a hostname, got %q (%v)", hostname, err)
+	}
+}
+
+func TestCrontabOverSSHTransport(t *testing.T) {
+	transport := startTestSSHServer(t)
+	UseTransport(transport)
+	defer UseTransport(nil)
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "jobs")
+	if err := os.WriteFile(filename, []byte("0 * * * * /usr/bin/backup\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	crontabs := ReadCrontabsInDirectory("", dir, nil)
+	if len(crontabs) != 1 || len(crontabs[0].Lines) == 0 || !crontabs[0].Lines[0].IsJob {
+		t.Fatalf("expected one crontab with a job, got %+v", crontabs)
+	}
+
+	crontab := crontabs[0]
+	crontab.Lines[0].Code = "abc123"
+	if err := crontab.Save(crontab.Write()); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, _ := os.ReadFile(filename)
+	if !strings.Contains(string(saved), "cronitor exec abc123 /usr/bin/backup") {
+		t.Errorf("expected wrapped job to be saved over SSH, got %q", saved)
+	}
+}
+
+func TestParseSSHTarget(t *testing.T) {
+	tests := []struct {
+		target, user, address string
+	}{
+		{"web1", "", "web1:22"},
+		{"deploy@web1", "deploy", "web1:22"},
+		{"deploy@web1:2222", "deploy", "web1:2222"},
+		{"deploy@[::1]", "deploy", "[::1]:22"},
+	}
+
+	for _, test := range tests {
+		user, address := parseSSHTarget(test.target)
+		if user != test.user || address != test.address {
+			t.Errorf("parseSSHTarget(%q) = %q, %q; want %q, %q", test.target, user, address, test.user, test.address)
+		}
+	}
+}
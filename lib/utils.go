@@ -2,7 +2,6 @@ package lib
 
 import (
 	"math/rand"
-	"os"
 	"path/filepath"
 )
 
@@ -12,16 +11,16 @@ func randomMinute() int {
 
 func EnumerateFiles(dirToEnumerate string) []string {
 	var fileList []string
-	entries, err := os.ReadDir(dirToEnumerate)
+	names, err := ActiveTransport.ReadDir(dirToEnumerate)
 	if err != nil {
 		return fileList
 	}
 
-	for _, entry := range entries {
-		if entry.Name()[0] == '.' {
+	for _, name := range names {
+		if name[0] == '.' {
 			continue
 		}
-		fileList = append(fileList, filepath.Join(dirToEnumerate, entry.Name()))
+		fileList = append(fileList, filepath.Join(dirToEnumerate, name))
 	}
 
 	return fileList
@@ -7,6 +7,7 @@ import { CommentCard } from './crontabs/CommentCard';
 import { EnvVarCard } from './crontabs/EnvVarCard';
 import { useLocation, useSearchParams } from 'react-router-dom';
 import { csrfFetcher, csrfFetch } from '../utils/api';
+import { useDashEvents } from '../hooks/useDashEvents';
 
 export default function Crontabs() {
   const location = useLocation();
@@ -25,14 +26,17 @@ export default function Crontabs() {
     refreshInterval: 0
   });
   
+  // Crontab changes are pushed over /api/events while the server is watching them
+  const live = useDashEvents();
+
   // Primary data for Crontabs view - fast refresh
   const { data: crontabs, error, mutate } = useSWR(
     `/api/crontabs?key=${revalidationKey}`,
     csrfFetcher,
     {
-      refreshInterval: 5000, // Keep fast refresh for primary data
+      refreshInterval: live ? 30000 : 5000, // Keep fast refresh for primary data unless changes are pushed
       revalidateOnFocus: true,
-      dedupingInterval: 5000 // 5 seconds deduplication to match refresh
+      dedupingInterval: 5000 // 5 seconds deduplication
     }
   );
   
@@ -470,7 +474,8 @@ export default function Crontabs() {
         }
       }
       
-      const response = await csrfFetch(`/api/crontabs/${selectedCrontab.filename}`, {
+      const hostQuery = selectedCrontab.host ? `?host=${encodeURIComponent(selectedCrontab.host)}` : '';
+      const response = await csrfFetch(`/api/crontabs/${selectedCrontab.filename}${hostQuery}`, {
         method: 'PUT',
         headers: {
           'Content-Type': 'application/json',
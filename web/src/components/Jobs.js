@@ -6,14 +6,18 @@ import { NewCrontabOverlay } from './jobs/NewCrontabOverlay';
 import { useSearchParams, useLocation, Link } from 'react-router-dom';
 import { FilterBar, FILTER_OPTIONS } from './jobs/FilterBar';
 import { csrfFetcher, csrfFetch } from '../utils/api';
+import { useDashEvents } from '../hooks/useDashEvents';
 
 export default function Jobs() {
   const location = useLocation();
   const isJobsView = location.pathname === '/' || location.pathname === '/jobs';
   
+  // Changes are pushed over /api/events; keep a slow poll for jobs that are not wrapped with cronitor exec
+  const live = useDashEvents();
+
   // Primary data for Jobs view - fast refresh
   const { data: jobs, error, mutate } = useSWR('/api/jobs', csrfFetcher, {
-    refreshInterval: 5000,
+    refreshInterval: live ? 30000 : 5000,
     revalidateOnFocus: true
   });
   const { data: monitors } = useSWR('/api/monitors', csrfFetcher, {
@@ -104,6 +104,7 @@ export function ConsoleModal({ job, onClose, isNew = false, onFormChange, onComm
           command,
           crontab_filename: job.crontab_filename,
           key: job.key,
+          host: job.host,
           with_monitoring: job.monitored ? withMonitoring : false
         })
       });
@@ -158,7 +159,7 @@ export function ConsoleModal({ job, onClose, isNew = false, onFormChange, onComm
         headers: {
           'Content-Type': 'application/json',
         },
-        body: JSON.stringify({ pids: [currentPid] }),
+        body: JSON.stringify({ pids: [currentPid], host: job.host }),
       });
       
       // Don't close the event source immediately - let the backend send the completion message
@@ -0,0 +1,109 @@
+import React, { useState } from 'react';
+import useSWR from 'swr';
+import { csrfFetcher } from '../../utils/api';
+
+function formatDuration(startedAt, endedAt) {
+  const seconds = (new Date(endedAt) - new Date(startedAt)) / 1000;
+  if (seconds < 60) {
+    return `${seconds.toFixed(1)}s`;
+  }
+  return `${Math.floor(seconds / 60)}m ${Math.round(seconds % 60)}s`;
+}
+
+const triggerLabels = {
+  dashboard: 'Dashboard',
+  exec: 'Cron',
+  mcp: 'MCP',
+  tui: 'Terminal UI',
+};
+
+export function HistoryTable({ job }) {
+  const [selectedRunId, setSelectedRunId] = useState(null);
+
+  const params = new URLSearchParams({ key: job.key, limit: '20' });
+  if (job.code) {
+    params.set('code', job.code);
+  }
+  if (job.host) {
+    params.set('host', job.host);
+  }
+
+  // New runs also arrive as run-recorded events, which revalidate this key
+  const { data: runs, error } = useSWR(`/api/runs?${params}`, csrfFetcher, {
+    refreshInterval: 30000,
+  });
+
+  const runQuery = job.host ? `?host=${encodeURIComponent(job.host)}` : '';
+  const { data: selectedRun } = useSWR(
+    selectedRunId ? `/api/runs/${selectedRunId}${runQuery}` : null,
+    csrfFetcher
+  );
+
+  return (
+    <div className="mt-2">
+      <table className="min-w-full divide-y divide-gray-200 dark:divide-gray-700">
+        <thead>
+          <tr>
+            <th className="py-2 text-left text-xs font-medium text-gray-500 dark:text-gray-400 uppercase tracking-wider">
+              Started
+            </th>
+            <th className="py-2 text-left text-xs font-medium text-gray-500 dark:text-gray-400 uppercase tracking-wider">
+              Duration
+            </th>
+            <th className="py-2 text-left text-xs font-medium text-gray-500 dark:text-gray-400 uppercase tracking-wider">
+              Trigger
+            </th>
+            <th className="py-2 text-right text-xs font-medium text-gray-500 dark:text-gray-400 uppercase tracking-wider">
+              Exit Code
+            </th>
+          </tr>
+        </thead>
+        <tbody className="divide-y divide-gray-200 dark:divide-gray-700">
+          {error ? (
+            <tr>
+              <td colSpan="4" className="py-2 text-sm text-red-600 dark:text-red-400">
+                Failed to load run history
+              </td>
+            </tr>
+          ) : runs && runs.length > 0 ? (
+            runs.map((run) => (
+              <tr
+                key={run.id}
+                onClick={() => setSelectedRunId(selectedRunId === run.id ? null : run.id)}
+                className="cursor-pointer hover:bg-gray-50 dark:hover:bg-gray-700/50"
+              >
+                <td className="py-2 text-sm text-gray-900 dark:text-gray-100">
+                  {new Date(run.started_at).toLocaleString()}
+                </td>
+                <td className="py-2 text-sm text-gray-900 dark:text-gray-100">
+                  {formatDuration(run.started_at, run.ended_at)}
+                </td>
+                <td className="py-2 text-sm text-gray-900 dark:text-gray-100">
+                  {triggerLabels[run.trigger] || run.trigger}
+                  {run.user ? ` (${run.user})` : ''}
+                </td>
+                <td className={`py-2 text-sm text-right ${run.exit_code === 0 ? 'text-green-600 dark:text-green-400' : 'text-red-600 dark:text-red-400'}`}>
+                  {run.exit_code}
+                </td>
+              </tr>
+            ))
+          ) : (
+            <tr>
+              <td colSpan="4" className="py-2 text-sm text-gray-500 dark:text-gray-400">
+                {runs ? 'No runs recorded yet' : 'Loading...'}
+              </td>
+            </tr>
+          )}
+        </tbody>
+      </table>
+
+      {selectedRunId && (
+        <pre className="mt-2 p-2 max-h-64 overflow-auto text-xs rounded-md bg-gray-900 text-gray-100 whitespace-pre-wrap">
+          {selectedRun
+            ? (selectedRun.output_truncated ? '...\n' : '') + (selectedRun.output || '(no output)')
+            : 'Loading...'}
+        </pre>
+      )}
+    </div>
+  );
+}
@@ -6,6 +6,7 @@ import { CommandSection } from './CommandSection';
 import { MonitoringSection } from './MonitoringSection';
 import { LocationSection } from './LocationSection';
 import { InstancesTable } from './InstancesTable';
+import { HistoryTable } from './HistoryTable';
 import { SuspendOverlay } from './SuspendOverlay';
 import { HideOverlay } from './HideOverlay';
 import { DeleteConfirmation } from './DeleteConfirmation';
@@ -28,6 +29,7 @@ export function JobCard({ job: initialJob, mutate, mutateCrontabs, allJobs, isNe
   const [selectedUser, setSelectedUser] = React.useState('');
   const [isUserCrontab, setIsUserCrontab] = React.useState(false);
   const [showInstances, setShowInstances] = React.useState(false);
+  const [showHistory, setShowHistory] = React.useState(false);
   const [killingPids, setKillingPids] = React.useState(new Set());
   const [isKillingAll, setIsKillingAll] = React.useState(false);
   const [showSuspendedOverlay, setShowSuspendedOverlay] = React.useState(false);
@@ -278,7 +280,7 @@ export function JobCard({ job: initialJob, mutate, mutateCrontabs, allJobs, isNe
     try {
       // Immediately invalidate SWR cache to show updated process list
       mutate();      
-      await killJobProcess(pids);
+      await killJobProcess(pids, initialJob.host);
       if (onJobChange) onJobChange();
       showToast(`Successfully killed ${pids.length} process${pids.length > 1 ? 'es' : ''}`, 'success');
     } catch (error) {
@@ -311,7 +313,8 @@ export function JobCard({ job: initialJob, mutate, mutateCrontabs, allJobs, isNe
         body: JSON.stringify({ 
           command: initialJob.command,
           crontab_filename: initialJob.crontab_filename,
-          key: initialJob.key
+          key: initialJob.key,
+          host: initialJob.host
         })
       });
       if (!response.ok) {
@@ -556,6 +559,8 @@ export function JobCard({ job: initialJob, mutate, mutateCrontabs, allJobs, isNe
         instances={initialJob.instances || []}
         showInstances={showInstances}
         onToggleInstances={() => setShowInstances(!showInstances)}
+        showHistory={showHistory}
+        onToggleHistory={() => setShowHistory(!showHistory)}
         onToggleSuspended={handleToggleSuspendedOverlay}
       />
 
@@ -882,6 +887,10 @@ export function JobCard({ job: initialJob, mutate, mutateCrontabs, allJobs, isNe
           />
         )}
 
+        {showHistory && !isNew && (
+          <HistoryTable job={initialJob} />
+        )}
+
         {showSuspendedOverlay && (
           <SuspendOverlay
             job={allJobs.find(j => j.key === initialJob.key) || initialJob}
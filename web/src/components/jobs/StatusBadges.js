@@ -5,6 +5,8 @@ export function StatusBadges({
   instances, 
   showInstances, 
   onToggleInstances, 
+  showHistory,
+  onToggleHistory,
   onToggleSuspended 
 }) {
   return (
@@ -30,6 +32,17 @@ export function StatusBadges({
               SCHEDULED
             </div>
           )}
+          <button
+            onClick={onToggleHistory}
+            title="Recent runs of this job on this host"
+            className={`inline-flex items-center px-2.5 py-0.5 text-sm font-medium border-r border-white dark:border-gray-600 ${
+              showHistory
+                ? 'bg-gray-300 dark:bg-gray-600 text-gray-700 dark:text-gray-200'
+                : 'bg-gray-200 dark:bg-gray-700 text-gray-600 dark:text-gray-300 hover:bg-gray-300 dark:hover:bg-gray-600'
+            } z-20`}
+          >
+            HISTORY
+          </button>
           <button
             onClick={onToggleInstances}
             title={instances.length > 0 ? `${instances.length} instances of this job are running` : 'Job is not currently running'}
@@ -0,0 +1,43 @@
+import { useEffect, useState } from 'react';
+import { mutate } from 'swr';
+
+// Keys revalidated for each kind of event pushed by /api/events
+const jobKeys = key => typeof key === 'string' && (key.startsWith('/api/jobs') || key.startsWith('/api/crontabs'));
+const runKeys = key => typeof key === 'string' && (key.startsWith('/api/jobs') || key.startsWith('/api/runs'));
+
+// useDashEvents subscribes to job and run events from the dashboard and refreshes the affected data.
+// It returns true while the server is watching crontabs for us, so views can poll much less often.
+export function useDashEvents() {
+  const [live, setLive] = useState(false);
+
+  useEffect(() => {
+    if (typeof EventSource === 'undefined') {
+      return undefined;
+    }
+
+    const source = new EventSource('/api/events');
+
+    source.addEventListener('connected', (e) => {
+      try {
+        setLive(JSON.parse(e.data).watching === true);
+      } catch {
+        setLive(false);
+      }
+    });
+
+    ['job-added', 'job-changed', 'job-removed'].forEach(type => {
+      source.addEventListener(type, () => mutate(jobKeys));
+    });
+
+    ['run-started', 'run-finished', 'run-recorded'].forEach(type => {
+      source.addEventListener(type, () => mutate(runKeys));
+    });
+
+    // EventSource reconnects on its own; poll as usual until it does
+    source.onerror = () => setLive(false);
+
+    return () => source.close();
+  }, []);
+
+  return live;
+}
@@ -195,7 +195,7 @@ export function useJobOperations() {
     }
   }, [jobs, mutate]);
 
-  const killJobProcess = useCallback(async (pids) => {
+  const killJobProcess = useCallback(async (pids, host) => {
     try {
       // Convert string PIDs to integers
       const numericPids = pids.map(pid => parseInt(pid, 10));
@@ -205,7 +205,7 @@ export function useJobOperations() {
         headers: {
           'Content-Type': 'application/json',
         },
-        body: JSON.stringify({ pids: numericPids }),
+        body: JSON.stringify({ pids: numericPids, host }),
       });
 
       if (!response.ok) {